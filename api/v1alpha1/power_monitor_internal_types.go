@@ -82,6 +82,19 @@ type PowerMonitorInternalKeplerConfigSpec struct {
 	// +optional
 	// +kubebuilder:default=0
 	MaxTerminated *int32 `json:"maxTerminated,omitempty"`
+
+	// RevisionHistoryLimit is the number of rendered configs kept as immutable
+	// ConfigMaps; the config is rolled back to the last known-good revision if
+	// Kepler pods crash-loop after a config change. History is not kept if 0.
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// PinnedRevision, if set, deploys the kept config of that revision instead
+	// of the config rendered from the spec
+	// +optional
+	PinnedRevision string `json:"pinnedRevision,omitempty"`
 }
 
 // PowerMonitorInternalKeplerSpec defines the internal Kepler component specification
@@ -98,8 +111,19 @@ type PowerMonitorInternalSpec struct {
 	// Kepler contains the Kepler component specification
 	// +kubebuilder:validation:Required
 	Kepler PowerMonitorInternalKeplerSpec `json:"kepler"`
+	// Monitoring configures how Prometheus scrapes Kepler
+	// +optional
+	Monitoring MonitoringSpec `json:"monitoring,omitempty"`
+	// Carbon records the carbon emissions of the energy measured by Kepler
+	// +optional
+	Carbon *CarbonSpec `json:"carbon,omitempty"`
 	// OpenShift contains OpenShift-specific settings
 	OpenShift PowerMonitorInternalOpenShiftSpec `json:"openshift,omitempty"`
+	// DeletionPolicy controls which objects are deleted along with power-monitor-internal
+	// +kubebuilder:validation:Enum=Delete;Retain;RetainNamespace
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -156,6 +180,50 @@ type PowerMonitorInternalKeplerStatus struct {
 	// power-monitor-internal pod and have none of the power-monitor-internal pod running and available
 	// +optional
 	NumberUnavailable int32 `json:"numberUnavailable,omitempty"`
+
+	// Coverage reports the cluster nodes that are not monitored by power-monitor-internal
+	// +optional
+	Coverage *NodeCoverageStatus `json:"coverage,omitempty"`
+
+	// Restart reports the rollout of the last restart requested by restartedAt
+	// +optional
+	Restart *RestartStatus `json:"restart,omitempty"`
+
+	// Rollout reports the canary rollout of the Kepler config and image
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// DeletionPhase is the phase of the cleanup of a power-monitor-internal being deleted
+// +kubebuilder:validation:Enum=InProgress;TimedOut;Forced
+type DeletionPhase string
+
+const (
+	// DeletionInProgress indicates the objects created for power-monitor-internal
+	// are being deleted; the finalizer is removed once all of them are deleted
+	DeletionInProgress DeletionPhase = "InProgress"
+	// DeletionTimedOut indicates the objects could not be deleted in time; the
+	// finalizer is removed and objects that failed to delete may be orphaned
+	DeletionTimedOut DeletionPhase = "TimedOut"
+	// DeletionForced indicates the deletion was forced with the force-delete
+	// annotation; the finalizer is removed and objects that failed to delete may be orphaned
+	DeletionForced DeletionPhase = "Forced"
+)
+
+// PowerMonitorInternalDeletionStatus reports the progress of the cleanup of a
+// power-monitor-internal being deleted
+type PowerMonitorInternalDeletionStatus struct {
+	// Phase of the deletion
+	Phase DeletionPhase `json:"phase"`
+
+	// FailedSteps lists the cleanup steps that failed in the last attempt
+	// +optional
+	// +listType=atomic
+	FailedSteps []string `json:"failedSteps,omitempty"`
+
+	// Message is a human readable explanation of the phase
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // PowerMonitorInternalStatus defines the observed state of PowerMonitorInternal
@@ -166,6 +234,19 @@ type PowerMonitorInternalStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:conditions"
 	// +listType=atomic
 	Conditions []Condition `json:"conditions"`
+
+	// ConsecutiveRequeues is the number of reconciles of power-monitor-internal in a row that
+	// were requeued; a growing value indicates that the reconcile is stuck
+	// +optional
+	ConsecutiveRequeues int32 `json:"consecutiveRequeues,omitempty"`
+
+	// Deletion reports the cleanup of power-monitor-internal once it is deleted
+	// +optional
+	Deletion *PowerMonitorInternalDeletionStatus `json:"deletion,omitempty"`
+
+	// Drift reports the changes made by other actors to the objects of power-monitor-internal
+	// +optional
+	Drift *DriftStatus `json:"drift,omitempty"`
 }
 
 func (pmi PowerMonitorInternal) Namespace() string {
@@ -4,7 +4,9 @@
 package v1alpha1
 
 import (
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/sustainable.computing.io/kepler-operator/internal/config"
@@ -15,6 +17,33 @@ const (
 	InvalidPowerMonitorResource ConditionReason = "InvalidPowerMonitorResource"
 )
 
+// ForceDeleteAnnotation, when set to "true" on a PowerMonitor being deleted,
+// removes its finalizer even if the objects it created could not be deleted
+const ForceDeleteAnnotation = "powermonitor.sustainable.computing.io/force-delete"
+
+// PausedAnnotation, when set to "true" on a PowerMonitor or PowerMonitorInternal,
+// pauses the reconcile of the objects created for it; only its status is updated
+const PausedAnnotation = "powermonitor.sustainable.computing.io/paused"
+
+// PausedUntilAnnotation, if set along with PausedAnnotation to a RFC 3339 time,
+// resumes the reconcile at that time
+const PausedUntilAnnotation = "powermonitor.sustainable.computing.io/paused-until"
+
+// DeletionPolicy controls which objects are deleted when a PowerMonitor is deleted
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes all objects created for the PowerMonitor,
+	// including the deployment namespace if the operator created it
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps all objects created for the PowerMonitor; they
+	// are no longer managed by the operator
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyRetainNamespace deletes the objects created for the
+	// PowerMonitor but keeps the deployment namespace and anything else in it
+	DeletionPolicyRetainNamespace DeletionPolicy = "RetainNamespace"
+)
+
 // SecurityMode defines the security mode for Kepler metrics access
 type SecurityMode string
 
@@ -58,6 +87,62 @@ type PowerMonitorKeplerDeploymentSpec struct {
 	// +optional
 	// +listType=atomic
 	Secrets []SecretRef `json:"secrets,omitempty"`
+
+	// MinCoveragePercent is the minimum percentage of cluster nodes that must be
+	// monitored by Kepler; the Coverage condition is set to False below it
+	// +optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MinCoveragePercent *int32 `json:"minCoveragePercent,omitempty"`
+
+	// RestartedAt triggers a rolling restart of the Kepler pods when set to a
+	// time later than the last restart, similar to `kubectl rollout restart`
+	// +optional
+	RestartedAt *metav1.Time `json:"restartedAt,omitempty"`
+
+	// Rollout defines how changes to the Kepler config and image are rolled
+	// out; they are rolled out to all nodes at once if unset
+	// +optional
+	Rollout *RolloutPolicy `json:"rollout,omitempty"`
+}
+
+// RolloutPolicy defines how changes to the Kepler config and image are rolled out
+type RolloutPolicy struct {
+	// Canary rolls out a change to a canary set of nodes first and promotes it
+	// to all nodes once the canary pods are healthy for the bake time
+	Canary CanaryPolicy `json:"canary"`
+}
+
+// CanaryPolicy defines the canary nodes and the checks of a canary rollout
+type CanaryPolicy struct {
+	// NodeSelector selects the canary nodes among the nodes running Kepler;
+	// Percent of them are selected if unset
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Percent is the percentage of the nodes running Kepler used as canary
+	// nodes when nodeSelector is unset; at least one node is used
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Percent *int32 `json:"percent,omitempty"`
+
+	// BakeTime is how long the canary pods must run before the change is promoted
+	// +optional
+	// +kubebuilder:default="10m"
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(s|m|h))+$"
+	BakeTime *metav1.Duration `json:"bakeTime,omitempty"`
+
+	// MaxRestarts is the number of container restarts of the canary pods above
+	// which the change is aborted; a few restarts are allowed by default since
+	// a pod may restart for reasons unrelated to the change
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
 }
 
 // PowerMonitorKeplerConfigSpec defines configuration options for Kepler
@@ -104,6 +189,19 @@ type PowerMonitorKeplerConfigSpec struct {
 	// +optional
 	// +kubebuilder:default=0
 	MaxTerminated *int32 `json:"maxTerminated,omitempty"`
+
+	// RevisionHistoryLimit is the number of rendered configs kept as immutable
+	// ConfigMaps; the config is rolled back to the last known-good revision if
+	// Kepler pods crash-loop after a config change. History is not kept if 0.
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// PinnedRevision, if set, deploys the kept config of that revision instead
+	// of the config rendered from the spec
+	// +optional
+	PinnedRevision string `json:"pinnedRevision,omitempty"`
 }
 
 // ConfigMapRef defines a reference to a ConfigMap
@@ -155,9 +253,218 @@ type PowerMonitorKeplerSpec struct {
 	Config PowerMonitorKeplerConfigSpec `json:"config,omitempty"`
 }
 
+// MonitorKind is the kind of the prometheus-operator object that scrapes Kepler
+// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+type MonitorKind string
+
+const (
+	// MonitorKindServiceMonitor scrapes Kepler through its service
+	MonitorKindServiceMonitor MonitorKind = "ServiceMonitor"
+	// MonitorKindPodMonitor scrapes the Kepler pods directly
+	MonitorKindPodMonitor MonitorKind = "PodMonitor"
+)
+
+// ServiceMonitorSpec defines how Prometheus scrapes Kepler
+type ServiceMonitorSpec struct {
+	// Kind of the object created to scrape Kepler; a PodMonitor scrapes the
+	// Kepler pods without going through the Kepler service
+	// +optional
+	// +kubebuilder:default=ServiceMonitor
+	Kind MonitorKind `json:"kind,omitempty"`
+
+	// Interval at which Kepler is scraped; the Prometheus default is used if unset
+	// +optional
+	Interval monv1.Duration `json:"interval,omitempty"`
+
+	// ScrapeTimeout of a scrape of Kepler; it must not be longer than interval
+	// +optional
+	ScrapeTimeout monv1.Duration `json:"scrapeTimeout,omitempty"`
+
+	// MetricRelabelings are applied to the samples scraped before they are ingested
+	// +optional
+	// +listType=atomic
+	MetricRelabelings []monv1.RelabelConfig `json:"metricRelabelings,omitempty"`
+
+	// HonorLabels keeps the labels of the samples scraped when they conflict
+	// with the labels of the target
+	// +optional
+	HonorLabels bool `json:"honorLabels,omitempty"`
+
+	// SampleLimit is the number of samples a scrape may return; the scrape
+	// fails above it
+	// +optional
+	SampleLimit *uint64 `json:"sampleLimit,omitempty"`
+
+	// Labels added to the ServiceMonitor, e.g. to be selected by a Prometheus;
+	// they can't override the labels set by the operator
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the ServiceMonitor
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// AlertSpec enables an alert on Kepler
+type AlertSpec struct {
+	// Enabled creates the alert
+	// +optional
+	// +kubebuilder:default=true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// For is how long the alert is pending before it fires; each alert has its
+	// own default
+	// +optional
+	For monv1.Duration `json:"for,omitempty"`
+}
+
+// ConditionsAlertSpec enables the alert on the conditions of the PowerMonitor
+type ConditionsAlertSpec struct {
+	// Enabled creates the alert; it is disabled by default since it needs the
+	// metrics of the operator to be scraped by the Prometheus evaluating it
+	// +optional
+	// +kubebuilder:default=false
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// For is how long a condition must be False before the alert fires
+	// +optional
+	For monv1.Duration `json:"for,omitempty"`
+}
+
+// NodePowerAlertSpec enables the alert on the power drawn by a node
+type NodePowerAlertSpec struct {
+	// Enabled creates the alert; it is disabled by default since the power
+	// drawn by nodes depends on their hardware
+	// +optional
+	// +kubebuilder:default=false
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// For is how long the power of a node must be above the threshold before
+	// the alert fires
+	// +optional
+	// +kubebuilder:default="15m"
+	For monv1.Duration `json:"for,omitempty"`
+
+	// ThresholdWatts is the power drawn by the CPU packages and DRAM of a node
+	// above which the alert fires
+	// +optional
+	// +kubebuilder:default=500
+	// +kubebuilder:validation:Minimum=1
+	ThresholdWatts *int32 `json:"thresholdWatts,omitempty"`
+}
+
+// PrometheusAlertsSpec defines the alerts of the PrometheusRule of Kepler
+type PrometheusAlertsSpec struct {
+	// TargetDown fires when Prometheus fails to scrape Kepler on a node
+	// +optional
+	TargetDown AlertSpec `json:"targetDown,omitempty"`
+
+	// StaleMetrics fires when the energy Kepler reports for a node stops increasing
+	// +optional
+	StaleMetrics AlertSpec `json:"staleMetrics,omitempty"`
+
+	// NodePower fires when the power drawn by a node is above a threshold
+	// +optional
+	NodePower NodePowerAlertSpec `json:"nodePower,omitempty"`
+
+	// Conditions fires when a condition of the PowerMonitor is False; it needs
+	// the metrics of the operator to be scraped by the same Prometheus
+	// +optional
+	Conditions ConditionsAlertSpec `json:"conditions,omitempty"`
+}
+
+// RecordingRulesSpec enables a group of recording rules
+type RecordingRulesSpec struct {
+	// Enabled creates the recording rules of the group
+	// +optional
+	// +kubebuilder:default=true
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// PrometheusRecordingRulesSpec defines the recording rules of the PrometheusRule of Kepler;
+// rules are only recorded for the metric levels in spec.kepler.config.metricLevels
+type PrometheusRecordingRulesSpec struct {
+	// Namespace records the power and energy of each namespace; it needs the pod metric level
+	// +optional
+	Namespace RecordingRulesSpec `json:"namespace,omitempty"`
+
+	// Workload records the power and energy of each pod, container and VM as
+	// per the pod, container and vm metric levels
+	// +optional
+	Workload RecordingRulesSpec `json:"workload,omitempty"`
+}
+
+// PrometheusRuleSpec defines the PrometheusRule created for Kepler
+type PrometheusRuleSpec struct {
+	// Alerts of the PrometheusRule
+	// +optional
+	Alerts PrometheusAlertsSpec `json:"alerts,omitempty"`
+
+	// RecordingRules of the PrometheusRule
+	// +optional
+	RecordingRules PrometheusRecordingRulesSpec `json:"recordingRules,omitempty"`
+
+	// Labels added to the PrometheusRule, e.g. to be selected by a Prometheus;
+	// they can't override the labels set by the operator
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// MonitoringSpec defines how Kepler is integrated with Prometheus
+type MonitoringSpec struct {
+	// ServiceMonitor configures the object Prometheus uses to scrape Kepler
+	// +optional
+	ServiceMonitor ServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+
+	// PrometheusRule configures the alerts and recording rules on Kepler; no
+	// PrometheusRule is created if all of them are disabled
+	// +optional
+	PrometheusRule PrometheusRuleSpec `json:"prometheusRule,omitempty"`
+}
+
+// CarbonSpec defines the carbon intensity of the electricity used by nodes,
+// from which the carbon emissions of the energy measured by Kepler are recorded
+type CarbonSpec struct {
+	// NodeLabel is the label of nodes whose value, e.g. the region or zone of
+	// a node, selects the carbon intensity of the node
+	// +optional
+	// +kubebuilder:default="topology.kubernetes.io/region"
+	NodeLabel string `json:"nodeLabel,omitempty"`
+
+	// Intensities maps values of nodeLabel to carbon intensities in gCO2e/kWh
+	// +optional
+	Intensities map[string]resource.Quantity `json:"intensities,omitempty"`
+
+	// ConfigMap in the namespace of the Kepler deployment whose data maps
+	// values of nodeLabel to carbon intensities in gCO2e/kWh; they take
+	// precedence over intensities
+	// +optional
+	ConfigMap *ConfigMapRef `json:"configMap,omitempty"`
+
+	// DefaultIntensity is the carbon intensity in gCO2e/kWh of nodes whose
+	// value of nodeLabel has no intensity; their emissions are not recorded if unset
+	// +optional
+	DefaultIntensity *resource.Quantity `json:"defaultIntensity,omitempty"`
+}
+
 // PowerMonitorSpec defines the desired state of Power Monitor
 type PowerMonitorSpec struct {
 	Kepler PowerMonitorKeplerSpec `json:"kepler"`
+
+	// Monitoring configures how Prometheus scrapes Kepler
+	// +optional
+	Monitoring MonitoringSpec `json:"monitoring,omitempty"`
+
+	// Carbon records the carbon emissions of the energy measured by Kepler in
+	// the PrometheusRule of Kepler; they are not recorded if unset
+	// +optional
+	Carbon *CarbonSpec `json:"carbon,omitempty"`
+
+	// DeletionPolicy controls which objects are deleted along with the PowerMonitor
+	// +kubebuilder:validation:Enum=Delete;Retain;RetainNamespace
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -213,6 +520,108 @@ type PowerMonitorKeplerStatus struct {
 	// power-monitor pod and have none of the power-monitor pod running and available
 	// +optional
 	NumberUnavailable int32 `json:"numberUnavailable,omitempty"`
+
+	// Coverage reports the cluster nodes that are not monitored by power-monitor
+	// +optional
+	Coverage *NodeCoverageStatus `json:"coverage,omitempty"`
+
+	// Restart reports the rollout of the last restart requested by restartedAt
+	// +optional
+	Restart *RestartStatus `json:"restart,omitempty"`
+
+	// Rollout reports the canary rollout of the Kepler config and image
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// RolloutPhase is the phase of the canary rollout of a change
+// +kubebuilder:validation:Enum=Canary;Promoted;Aborted
+type RolloutPhase string
+
+const (
+	// RolloutCanary indicates the change runs on the canary nodes only
+	RolloutCanary RolloutPhase = "Canary"
+	// RolloutPromoted indicates the change is rolled out to all nodes
+	RolloutPromoted RolloutPhase = "Promoted"
+	// RolloutAborted indicates the canary pods failed; the change is not
+	// rolled out until the config or image changes again
+	RolloutAborted RolloutPhase = "Aborted"
+)
+
+// RolloutStatus reports the canary rollout of the Kepler config and image
+type RolloutStatus struct {
+	// Phase is the phase of the rollout of Revision
+	Phase RolloutPhase `json:"phase"`
+
+	// Revision identifies the Kepler config and image being rolled out
+	Revision string `json:"revision"`
+
+	// PromotedRevision identifies the Kepler config and image running on all nodes
+	// +optional
+	PromotedRevision string `json:"promotedRevision,omitempty"`
+
+	// CanaryNodes are the nodes running the canary pods
+	// +optional
+	// +listType=atomic
+	CanaryNodes []string `json:"canaryNodes,omitempty"`
+
+	// StartTime is the time the rollout of Revision started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Message is a human readable description of the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RestartStatus reports the rollout of a restart of the Kepler pods
+type RestartStatus struct {
+	// RequestedAt is the restartedAt of the restart
+	RequestedAt metav1.Time `json:"requestedAt"`
+
+	// CompletedAt is the time the restart was found rolled out to every node;
+	// unset while the restart is in progress
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// UnmonitoredReason represents the reason a node is not monitored by Kepler
+type UnmonitoredReason string
+
+const (
+	// NodeSelectorMismatch indicates the node labels do not match the nodeSelector
+	NodeSelectorMismatch UnmonitoredReason = "NodeSelectorMismatch"
+	// UntoleratedTaint indicates the node has a taint that is not tolerated
+	UntoleratedTaint UnmonitoredReason = "UntoleratedTaint"
+	// NonLinuxOS indicates the node does not run linux
+	NonLinuxOS UnmonitoredReason = "NonLinuxOS"
+	// PodPending indicates the node should run Kepler but the pod is not ready
+	PodPending UnmonitoredReason = "PodPending"
+)
+
+// UnmonitoredNode describes a node that is not monitored by Kepler
+type UnmonitoredNode struct {
+	// Name of the node
+	Name string `json:"name"`
+	// Reason the node is not monitored
+	Reason UnmonitoredReason `json:"reason"`
+	// Message is a human readable explanation of the reason
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// NodeCoverageStatus defines the observed coverage of cluster nodes by Kepler
+type NodeCoverageStatus struct {
+	// TotalNodes is the number of nodes in the cluster
+	TotalNodes int32 `json:"totalNodes"`
+
+	// MonitoredNodes is the number of nodes running a ready Kepler pod
+	MonitoredNodes int32 `json:"monitoredNodes"`
+
+	// UnmonitoredNodes lists the nodes not monitored by Kepler along with the reason
+	// +optional
+	// +listType=atomic
+	UnmonitoredNodes []UnmonitoredNode `json:"unmonitoredNodes,omitempty"`
 }
 
 // ConditionType represents the type of condition for a PowerMonitor resource
@@ -223,6 +632,23 @@ const (
 	Available ConditionType = "Available"
 	// Reconciled indicates whether the PowerMonitor has been successfully reconciled
 	Reconciled ConditionType = "Reconciled"
+	// Coverage indicates whether enough cluster nodes are monitored by Kepler
+	Coverage ConditionType = "Coverage"
+	// Progressing indicates whether a rollout of the Kepler DaemonSet is in progress
+	Progressing ConditionType = "Progressing"
+	// Degraded indicates whether the PowerMonitor failed to reach the desired state
+	Degraded ConditionType = "Degraded"
+	// ConfigValid indicates whether the Kepler config was rendered from the spec
+	// and the additional ConfigMaps without errors
+	ConfigValid ConditionType = "ConfigValid"
+	// SecurityReady indicates whether the TLS, token and CA bundle objects required
+	// by the security mode are present
+	SecurityReady ConditionType = "SecurityReady"
+	// MonitoringIntegrated indicates whether Kepler metrics are integrated with
+	// prometheus i.e. the ServiceMonitor and the scrape token are present
+	MonitoringIntegrated ConditionType = "MonitoringIntegrated"
+	// Paused indicates whether the reconcile is paused by the paused annotation
+	Paused ConditionType = "Paused"
 )
 
 // ConditionReason represents the reason for a condition's last transition
@@ -254,15 +680,87 @@ const (
 	// DaemonSetOutOfSync indicates the DaemonSet spec doesn't match the desired state
 	DaemonSetOutOfSync ConditionReason = "DaemonSetOutOfSync"
 
+	// NoPowerMeter indicates Kepler pods fail since the node has no power meter
+	// e.g. no RAPL zones on a virtual machine
+	NoPowerMeter ConditionReason = "NoPowerMeter"
+	// HostPathUnreadable indicates Kepler pods fail to read the host sysfs or procfs
+	HostPathUnreadable ConditionReason = "HostPathUnreadable"
+	// ConfigParseError indicates Kepler pods fail to parse the deployed config
+	ConfigParseError ConditionReason = "ConfigParseError"
+	// PodOOMKilled indicates Kepler containers are killed for running out of memory
+	PodOOMKilled ConditionReason = "PodOOMKilled"
+	// ImagePullFailed indicates the Kepler image can't be pulled
+	ImagePullFailed ConditionReason = "ImagePullFailed"
+	// PodCrashLooping indicates Kepler pods crash-loop for an unclassified reason
+	PodCrashLooping ConditionReason = "PodCrashLooping"
+
 	// SecretNotFound indicates one or more referenced secrets are missing
 	SecretNotFound ConditionReason = "SecretNotFound"
+
+	// NamespaceNotWatched indicates Kepler is deployed to a namespace the
+	// operator can't watch
+	NamespaceNotWatched ConditionReason = "NamespaceNotWatched"
+
+	// CoverageSufficient indicates the percentage of monitored nodes meets the minimum
+	CoverageSufficient ConditionReason = "CoverageSufficient"
+	// CoverageBelowThreshold indicates the percentage of monitored nodes is below the minimum
+	CoverageBelowThreshold ConditionReason = "CoverageBelowThreshold"
+	// CoverageError indicates the coverage could not be computed
+	CoverageError ConditionReason = "CoverageError"
+
+	// RolloutComplete indicates the DaemonSet rollout has finished
+	RolloutComplete ConditionReason = "RolloutComplete"
+
+	// AsExpected indicates the PowerMonitor is not degraded
+	AsExpected ConditionReason = "AsExpected"
+
+	// ConfigRendered indicates the Kepler config was rendered without errors
+	ConfigRendered ConditionReason = "ConfigRendered"
+	// ConfigMapNotFound indicates one or more additional ConfigMaps are missing
+	ConfigMapNotFound ConditionReason = "ConfigMapNotFound"
+	// ConfigInvalid indicates the rendered Kepler config failed validation
+	ConfigInvalid ConditionReason = "ConfigInvalid"
+	// ConfigRolledBack indicates the Kepler pods crash-looped with the config
+	// rendered from the spec, which was rolled back to the last known-good revision
+	ConfigRolledBack ConditionReason = "ConfigRolledBack"
+	// ConfigRevisionNotFound indicates the pinned config revision is not kept
+	ConfigRevisionNotFound ConditionReason = "ConfigRevisionNotFound"
+
+	// SecurityNotRequired indicates the security mode requires no additional objects
+	SecurityNotRequired ConditionReason = "SecurityNotRequired"
+	// SecurityObjectsReady indicates all objects required by the security mode are present
+	SecurityObjectsReady ConditionReason = "SecurityObjectsReady"
+	// SecurityObjectsMissing indicates one or more objects required by the security mode are missing
+	SecurityObjectsMissing ConditionReason = "SecurityObjectsMissing"
+	// SecurityError indicates the security objects could not be checked
+	SecurityError ConditionReason = "SecurityError"
+	// WaitingForDependency indicates the reconcile is waiting for an object
+	// created outside the operator, e.g. by OpenShift
+	WaitingForDependency ConditionReason = "WaitingForDependency"
+
+	// MonitoringReady indicates the ServiceMonitor and the scrape token are present
+	MonitoringReady ConditionReason = "MonitoringReady"
+	// ServiceMonitorNotFound indicates the ServiceMonitor for Kepler is missing
+	ServiceMonitorNotFound ConditionReason = "ServiceMonitorNotFound"
+	// UWMTokenNotFound indicates the token used by user workload monitoring is missing
+	UWMTokenNotFound ConditionReason = "UWMTokenNotFound"
+	// MonitoringError indicates the monitoring objects could not be checked
+	MonitoringError ConditionReason = "MonitoringError"
+	// CRDNotInstalled indicates the prometheus-operator CRDs are not installed
+	CRDNotInstalled ConditionReason = "CRDNotInstalled"
+
+	// ReconcilePaused indicates the reconcile is paused by the paused annotation
+	ReconcilePaused ConditionReason = "ReconcilePaused"
+	// ReconcileActive indicates the reconcile isn't paused
+	ReconcileActive ConditionReason = "ReconcileActive"
+	// PauseExpired indicates the reconcile resumed since the pause deadline passed
+	PauseExpired ConditionReason = "PauseExpired"
 )
 
 // These are valid condition statuses.
 // "ConditionTrue" means a resource is in the condition.
 // "ConditionFalse" means a resource is not in the condition.
 // "ConditionUnknown" means kubernetes can't decide if a resource is in the condition or not.
-// In the future, we could add other intermediate conditions, e.g. ConditionDegraded.
 type ConditionStatus string
 
 const (
@@ -273,6 +771,8 @@ const (
 	// ConditionUnknown indicates the condition status cannot be determined
 	ConditionUnknown ConditionStatus = "Unknown"
 	// ConditionDegraded indicates the resource is operational but in a degraded state
+	//
+	// Deprecated: use the Degraded condition type instead; no condition is set to this status
 	ConditionDegraded ConditionStatus = "Degraded"
 )
 
@@ -306,6 +806,43 @@ type Condition struct {
 	Message string `json:"message"`
 }
 
+// DriftedResource is an object managed by the operator whose fields were
+// changed by other field managers
+type DriftedResource struct {
+	// Kind of the object
+	Kind string `json:"kind"`
+
+	// Namespace of the object; empty if cluster-scoped
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object
+	Name string `json:"name"`
+
+	// Managers lists the field managers that changed the object
+	// +listType=atomic
+	Managers []string `json:"managers"`
+
+	// Fields lists the paths of the fields changed
+	// +listType=atomic
+	Fields []string `json:"fields"`
+
+	// Reverted is true if the operator applied the object again, reverting the changes
+	Reverted bool `json:"reverted"`
+}
+
+// DriftStatus summarizes the changes made by other actors to the objects
+// managed by the operator
+type DriftStatus struct {
+	// LastDetectionTime is the last time drift was detected
+	LastDetectionTime metav1.Time `json:"lastDetectionTime"`
+
+	// Resources lists the objects found drifted; objects left as is are
+	// removed from the list once their drift is gone
+	// +listType=atomic
+	Resources []DriftedResource `json:"resources"`
+}
+
 // PowerMonitorStatus defines the observed state of Power Monitor
 type PowerMonitorStatus struct {
 	Kepler PowerMonitorKeplerStatus `json:"kepler,omitempty"`
@@ -313,6 +850,15 @@ type PowerMonitorStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:com.tectonic.ui:conditions"
 	// +listType=atomic
 	Conditions []Condition `json:"conditions"`
+
+	// ConsecutiveRequeues is the number of reconciles of power-monitor in a row that
+	// were requeued; a growing value indicates that the reconcile is stuck
+	// +optional
+	ConsecutiveRequeues int32 `json:"consecutiveRequeues,omitempty"`
+
+	// Drift reports the changes made by other actors to the objects of power-monitor
+	// +optional
+	Drift *DriftStatus `json:"drift,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -8,11 +8,102 @@
 package v1alpha1
 
 import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSpec) DeepCopyInto(out *AlertSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSpec.
+func (in *AlertSpec) DeepCopy() *AlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryPolicy) DeepCopyInto(out *CanaryPolicy) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BakeTime != nil {
+		in, out := &in.BakeTime, &out.BakeTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryPolicy.
+func (in *CanaryPolicy) DeepCopy() *CanaryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CarbonSpec) DeepCopyInto(out *CarbonSpec) {
+	*out = *in
+	if in.Intensities != nil {
+		in, out := &in.Intensities, &out.Intensities
+		*out = make(map[string]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapRef)
+		**out = **in
+	}
+	if in.DefaultIntensity != nil {
+		in, out := &in.DefaultIntensity, &out.DefaultIntensity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CarbonSpec.
+func (in *CarbonSpec) DeepCopy() *CarbonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CarbonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -29,6 +120,26 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionsAlertSpec) DeepCopyInto(out *ConditionsAlertSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionsAlertSpec.
+func (in *ConditionsAlertSpec) DeepCopy() *ConditionsAlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionsAlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
 	*out = *in
@@ -44,6 +155,116 @@ func (in *ConfigMapRef) DeepCopy() *ConfigMapRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftStatus) DeepCopyInto(out *DriftStatus) {
+	*out = *in
+	in.LastDetectionTime.DeepCopyInto(&out.LastDetectionTime)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]DriftedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftStatus.
+func (in *DriftStatus) DeepCopy() *DriftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftedResource) DeepCopyInto(out *DriftedResource) {
+	*out = *in
+	if in.Managers != nil {
+		in, out := &in.Managers, &out.Managers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftedResource.
+func (in *DriftedResource) DeepCopy() *DriftedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
+	in.PrometheusRule.DeepCopyInto(&out.PrometheusRule)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCoverageStatus) DeepCopyInto(out *NodeCoverageStatus) {
+	*out = *in
+	if in.UnmonitoredNodes != nil {
+		in, out := &in.UnmonitoredNodes, &out.UnmonitoredNodes
+		*out = make([]UnmonitoredNode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCoverageStatus.
+func (in *NodeCoverageStatus) DeepCopy() *NodeCoverageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCoverageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePowerAlertSpec) DeepCopyInto(out *NodePowerAlertSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ThresholdWatts != nil {
+		in, out := &in.ThresholdWatts, &out.ThresholdWatts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePowerAlertSpec.
+func (in *NodePowerAlertSpec) DeepCopy() *NodePowerAlertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePowerAlertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PowerMonitor) DeepCopyInto(out *PowerMonitor) {
 	*out = *in
@@ -143,6 +364,26 @@ func (in *PowerMonitorInternalDashboardSpec) DeepCopy() *PowerMonitorInternalDas
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerMonitorInternalDeletionStatus) DeepCopyInto(out *PowerMonitorInternalDeletionStatus) {
+	*out = *in
+	if in.FailedSteps != nil {
+		in, out := &in.FailedSteps, &out.FailedSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMonitorInternalDeletionStatus.
+func (in *PowerMonitorInternalDeletionStatus) DeepCopy() *PowerMonitorInternalDeletionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerMonitorInternalDeletionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PowerMonitorInternalKeplerConfigSpec) DeepCopyInto(out *PowerMonitorInternalKeplerConfigSpec) {
 	*out = *in
@@ -171,6 +412,11 @@ func (in *PowerMonitorInternalKeplerConfigSpec) DeepCopyInto(out *PowerMonitorIn
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMonitorInternalKeplerConfigSpec.
@@ -219,6 +465,21 @@ func (in *PowerMonitorInternalKeplerSpec) DeepCopy() *PowerMonitorInternalKepler
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PowerMonitorInternalKeplerStatus) DeepCopyInto(out *PowerMonitorInternalKeplerStatus) {
 	*out = *in
+	if in.Coverage != nil {
+		in, out := &in.Coverage, &out.Coverage
+		*out = new(NodeCoverageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restart != nil {
+		in, out := &in.Restart, &out.Restart
+		*out = new(RestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMonitorInternalKeplerStatus.
@@ -283,6 +544,12 @@ func (in *PowerMonitorInternalOpenShiftSpec) DeepCopy() *PowerMonitorInternalOpe
 func (in *PowerMonitorInternalSpec) DeepCopyInto(out *PowerMonitorInternalSpec) {
 	*out = *in
 	in.Kepler.DeepCopyInto(&out.Kepler)
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	if in.Carbon != nil {
+		in, out := &in.Carbon, &out.Carbon
+		*out = new(CarbonSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	out.OpenShift = in.OpenShift
 }
 
@@ -299,7 +566,7 @@ func (in *PowerMonitorInternalSpec) DeepCopy() *PowerMonitorInternalSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PowerMonitorInternalStatus) DeepCopyInto(out *PowerMonitorInternalStatus) {
 	*out = *in
-	out.Kepler = in.Kepler
+	in.Kepler.DeepCopyInto(&out.Kepler)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]Condition, len(*in))
@@ -307,6 +574,16 @@ func (in *PowerMonitorInternalStatus) DeepCopyInto(out *PowerMonitorInternalStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Deletion != nil {
+		in, out := &in.Deletion, &out.Deletion
+		*out = new(PowerMonitorInternalDeletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMonitorInternalStatus.
@@ -347,6 +624,11 @@ func (in *PowerMonitorKeplerConfigSpec) DeepCopyInto(out *PowerMonitorKeplerConf
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMonitorKeplerConfigSpec.
@@ -404,6 +686,20 @@ func (in *PowerMonitorKeplerDeploymentSpec) DeepCopyInto(out *PowerMonitorKepler
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MinCoveragePercent != nil {
+		in, out := &in.MinCoveragePercent, &out.MinCoveragePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RestartedAt != nil {
+		in, out := &in.RestartedAt, &out.RestartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMonitorKeplerDeploymentSpec.
@@ -436,6 +732,21 @@ func (in *PowerMonitorKeplerSpec) DeepCopy() *PowerMonitorKeplerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PowerMonitorKeplerStatus) DeepCopyInto(out *PowerMonitorKeplerStatus) {
 	*out = *in
+	if in.Coverage != nil {
+		in, out := &in.Coverage, &out.Coverage
+		*out = new(NodeCoverageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restart != nil {
+		in, out := &in.Restart, &out.Restart
+		*out = new(RestartStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMonitorKeplerStatus.
@@ -484,6 +795,12 @@ func (in *PowerMonitorList) DeepCopyObject() runtime.Object {
 func (in *PowerMonitorSpec) DeepCopyInto(out *PowerMonitorSpec) {
 	*out = *in
 	in.Kepler.DeepCopyInto(&out.Kepler)
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	if in.Carbon != nil {
+		in, out := &in.Carbon, &out.Carbon
+		*out = new(CarbonSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMonitorSpec.
@@ -499,7 +816,7 @@ func (in *PowerMonitorSpec) DeepCopy() *PowerMonitorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PowerMonitorStatus) DeepCopyInto(out *PowerMonitorStatus) {
 	*out = *in
-	out.Kepler = in.Kepler
+	in.Kepler.DeepCopyInto(&out.Kepler)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]Condition, len(*in))
@@ -507,6 +824,11 @@ func (in *PowerMonitorStatus) DeepCopyInto(out *PowerMonitorStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerMonitorStatus.
@@ -519,6 +841,146 @@ func (in *PowerMonitorStatus) DeepCopy() *PowerMonitorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAlertsSpec) DeepCopyInto(out *PrometheusAlertsSpec) {
+	*out = *in
+	in.TargetDown.DeepCopyInto(&out.TargetDown)
+	in.StaleMetrics.DeepCopyInto(&out.StaleMetrics)
+	in.NodePower.DeepCopyInto(&out.NodePower)
+	in.Conditions.DeepCopyInto(&out.Conditions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAlertsSpec.
+func (in *PrometheusAlertsSpec) DeepCopy() *PrometheusAlertsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAlertsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRecordingRulesSpec) DeepCopyInto(out *PrometheusRecordingRulesSpec) {
+	*out = *in
+	in.Namespace.DeepCopyInto(&out.Namespace)
+	in.Workload.DeepCopyInto(&out.Workload)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRecordingRulesSpec.
+func (in *PrometheusRecordingRulesSpec) DeepCopy() *PrometheusRecordingRulesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRecordingRulesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRuleSpec) DeepCopyInto(out *PrometheusRuleSpec) {
+	*out = *in
+	in.Alerts.DeepCopyInto(&out.Alerts)
+	in.RecordingRules.DeepCopyInto(&out.RecordingRules)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRuleSpec.
+func (in *PrometheusRuleSpec) DeepCopy() *PrometheusRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRulesSpec) DeepCopyInto(out *RecordingRulesSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRulesSpec.
+func (in *RecordingRulesSpec) DeepCopy() *RecordingRulesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRulesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartStatus) DeepCopyInto(out *RestartStatus) {
+	*out = *in
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartStatus.
+func (in *RestartStatus) DeepCopy() *RestartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicy) DeepCopyInto(out *RolloutPolicy) {
+	*out = *in
+	in.Canary.DeepCopyInto(&out.Canary)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicy.
+func (in *RolloutPolicy) DeepCopy() *RolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.CanaryNodes != nil {
+		in, out := &in.CanaryNodes, &out.CanaryNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretRef) DeepCopyInto(out *SecretRef) {
 	*out = *in
@@ -558,3 +1020,59 @@ func (in *SecurityConfig) DeepCopy() *SecurityConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMonitorSpec) DeepCopyInto(out *ServiceMonitorSpec) {
+	*out = *in
+	if in.MetricRelabelings != nil {
+		in, out := &in.MetricRelabelings, &out.MetricRelabelings
+		*out = make([]monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SampleLimit != nil {
+		in, out := &in.SampleLimit, &out.SampleLimit
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorSpec.
+func (in *ServiceMonitorSpec) DeepCopy() *ServiceMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnmonitoredNode) DeepCopyInto(out *UnmonitoredNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnmonitoredNode.
+func (in *UnmonitoredNode) DeepCopy() *UnmonitoredNode {
+	if in == nil {
+		return nil
+	}
+	out := new(UnmonitoredNode)
+	in.DeepCopyInto(out)
+	return out
+}
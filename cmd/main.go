@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -18,12 +19,17 @@ import (
 	"k8s.io/client-go/rest"
 
 	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -34,7 +40,11 @@ import (
 
 	keplersystemv1alpha1 "github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
 	"github.com/sustainable.computing.io/kepler-operator/internal/controller"
+	"github.com/sustainable.computing.io/kepler-operator/internal/nscache"
+	"github.com/sustainable.computing.io/kepler-operator/internal/tracing"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
 	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
 	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
 	"github.com/sustainable.computing.io/kepler-operator/pkg/version"
 	//+kubebuilder:scaffold:imports
@@ -79,6 +89,13 @@ func main() {
 	var tokenTTL time.Duration
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
+	var tracingOpts tracing.Options
+	var requeueBaseDelay, requeueMaxDelay time.Duration
+	var requeueJitter float64
+	var reconcileParallelism int
+	var deletionTimeout time.Duration
+	var pruneDryRun bool
+	var driftReportOnly bool
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to."+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -102,12 +119,34 @@ func main() {
 	flag.CommandLine.Var(flag.Value(&additionalNamespaces), "watch-namespaces",
 		"Namespaces other than deployment-namespace where power-monitor-internal may be deployed.")
 	flag.BoolVar(&openshift, "openshift", false,
-		"Indicate if the operator is running on an OpenShift cluster.")
+		"Indicate if the operator is running on an OpenShift cluster; overrides the detection of OpenShift.")
 	flag.DurationVar(&tokenRefreshInterval, "exp.reconciler.token.refresh-interval", controller.Config.TokenRefreshInterval,
 		"Interval at which the token expiry reconciler requeues for reconciliation.")
 	flag.DurationVar(&tokenTTL, "exp.uwm.token.ttl", controller.Config.TokenTTL,
 		"Time-to-live duration for user workload monitoring tokens.")
 
+	flag.DurationVar(&requeueBaseDelay, "requeue.base-delay", reconciler.DefaultRequeueBaseDelay,
+		"Delay before an object is reconciled again after its first requeue; doubles with each consecutive requeue.")
+	flag.DurationVar(&requeueMaxDelay, "requeue.max-delay", reconciler.DefaultRequeueMaxDelay,
+		"Maximum delay before an object that is requeued repeatedly is reconciled again.")
+	flag.Float64Var(&requeueJitter, "requeue.jitter", reconciler.DefaultRequeueJitter,
+		"Maximum fraction of the requeue delay randomly added to it.")
+	flag.IntVar(&reconcileParallelism, "reconcile.parallelism", reconciler.DefaultParallelism,
+		"Maximum number of independent steps of a power-monitor reconcile run concurrently.")
+	flag.DurationVar(&deletionTimeout, "deletion.timeout", controller.DefaultDeletionTimeout,
+		"Time given to delete the objects of a deleted power-monitor before its finalizer is removed regardless.")
+	flag.BoolVar(&pruneDryRun, "prune.dry-run", false,
+		"If set, stale objects of a power-monitor are only reported instead of being deleted.")
+	flag.BoolVar(&driftReportOnly, "drift.report-only", false,
+		"If set, fields of the objects of a power-monitor changed by other actors are only reported instead of being reverted.")
+
+	flag.StringVar(&tracingOpts.Endpoint, "tracing.otlp-endpoint", "",
+		"The host:port of the OTLP gRPC collector to export traces to; tracing is disabled if empty.")
+	flag.BoolVar(&tracingOpts.Insecure, "tracing.otlp-insecure", false,
+		"If set, traces are exported to the OTLP collector without TLS.")
+	flag.Float64Var(&tracingOpts.SamplingRatio, "tracing.sampling-ratio", 1.0,
+		"Fraction of reconciles that are traced, between 0 and 1.")
+
 	// NOTE: RELATED_IMAGE_KEPLER can be set as env or flag, flag takes precedence over env
 	keplerImage := os.Getenv("RELATED_IMAGE_KEPLER")
 	flag.StringVar(&controller.Config.Image, "kepler.image", keplerImage, "kepler image")
@@ -173,6 +212,20 @@ func main() {
 		TLSOpts: webhookTLSOpts,
 	})
 
+	restConfig := ctrl.GetConfigOrDie()
+	caps, err := detectCapabilities(restConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to detect cluster capabilities; falling back to defaults")
+		caps = controller.Config.Capabilities
+	}
+	if flagSet("openshift") {
+		caps.OpenShift = openshift
+	}
+	openshift = caps.OpenShift
+	setupLog.Info("cluster capabilities", "openshift", caps.OpenShift,
+		"prometheusOperator", caps.PrometheusOperator)
+	controller.Config.Capabilities = caps
+
 	if openshift {
 		controller.Config.Cluster = k8s.OpenShift
 		keplersystemv1alpha1.DefaultSecurityConfig.Mode = keplersystemv1alpha1.SecurityModeRBAC
@@ -236,24 +289,26 @@ func main() {
 		})
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	var nsCache *nscache.Cache
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:        scheme,
 		Metrics:       metricsServerOptions,
 		WebhookServer: webhookServer,
-		// TODO: add new introduced namespace from KeplerInternal.Spec.Deployment.Namespace
+		// NOTE: namespaces power-monitor-internals are deployed to are added
+		// to the cache by the power-monitor-internal controller
 		NewCache: func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
-			cacheNs := map[string]cache.Config{
-				controller.PowerMonitorDeploymentNS: {},
-			}
+			cacheNs := []string{controller.PowerMonitorDeploymentNS}
 			if openshift {
-				cacheNs[powermonitor.DashboardNs] = cache.Config{}
-				cacheNs[powermonitor.UWMNamespace] = cache.Config{}
+				cacheNs = append(cacheNs, powermonitor.DashboardNs, powermonitor.UWMNamespace)
+				opts.ByObject = openshiftCacheByObject()
 			}
-			for _, ns := range additionalNamespaces {
-				cacheNs[ns] = cache.Config{}
+			cacheNs = append(cacheNs, additionalNamespaces...)
+			c, err := nscache.New(config, opts, cacheNs)
+			if err != nil {
+				return nil, err
 			}
-			opts.DefaultNamespaces = cacheNs
-			return cache.New(config, opts)
+			nsCache = c
+			return c, nil
 		},
 
 		HealthProbeBindAddress: probeAddr,
@@ -278,22 +333,32 @@ func main() {
 	}
 
 	if err = (&controller.TokenExpiryReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("token-expiry"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "token-expiry")
 		os.Exit(1)
 	}
 	if err = (&controller.PowerMonitorReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("power-monitor"),
+		Backoff:  reconciler.NewBackoff(requeueBaseDelay, requeueMaxDelay, requeueJitter),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "power-monitor")
 		os.Exit(1)
 	}
 	if err = (&controller.PowerMonitorInternalReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Recorder:        mgr.GetEventRecorderFor("power-monitor-internal"),
+		Backoff:         reconciler.NewBackoff(requeueBaseDelay, requeueMaxDelay, requeueJitter),
+		Parallelism:     reconcileParallelism,
+		DeletionTimeout: deletionTimeout,
+		PruneDryRun:     pruneDryRun,
+		DriftReportOnly: driftReportOnly,
+		Namespaces:      nsCache,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "power-monitor-internal")
 		os.Exit(1)
@@ -325,6 +390,22 @@ func main() {
 		}
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			setupLog.Error(err, "failed to flush traces")
+		}
+	}()
+	if tracingOpts.Enabled() {
+		setupLog.Info("exporting traces", "endpoint", tracingOpts.Endpoint, "sampling-ratio", tracingOpts.SamplingRatio)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -347,3 +428,50 @@ func setupWebhooks(mgr ctrl.Manager) error {
 	}
 	return nil
 }
+
+// detectCapabilities detects the capabilities of the cluster the operator runs on
+func detectCapabilities(config *rest.Config) (k8s.Capabilities, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return k8s.Capabilities{}, fmt.Errorf("error creating discovery client: %w", err)
+	}
+	return k8s.DetectCapabilities(dc)
+}
+
+// flagSet reports whether the flag name was set on the command line
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// openshiftCacheByObject restricts the objects cached in the OpenShift
+// namespaces the operator shares with the platform to the ones it reads
+func openshiftCacheByObject() map[client.Object]cache.ByObject {
+	managed := cache.Config{LabelSelector: labels.SelectorFromSet(labels.Set(components.CommonLabels))}
+	return map[client.Object]cache.ByObject{
+		// only the dashboards created by the operator are read
+		&corev1.ConfigMap{}: {Namespaces: map[string]cache.Config{
+			powermonitor.DashboardNs:  managed,
+			powermonitor.UWMNamespace: managed,
+		}},
+		// no secret of the platform is read; the deploy namespaces cache all
+		// secrets since the ones referenced by users and the ones created by
+		// the service CA aren't labelled by the operator
+		&corev1.Secret{}: {Namespaces: map[string]cache.Config{
+			powermonitor.DashboardNs:  managed,
+			powermonitor.UWMNamespace: managed,
+		}},
+		// only the service account of the user workload prometheus is read
+		&corev1.ServiceAccount{}: {Namespaces: map[string]cache.Config{
+			powermonitor.DashboardNs: managed,
+			powermonitor.UWMNamespace: {
+				FieldSelector: fields.OneTermEqualSelector("metadata.name", powermonitor.UWMServiceAccountName),
+			},
+		}},
+	}
+}
@@ -0,0 +1,286 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+)
+
+// rbacEnabled returns true if access to kepler metrics is protected by kube-rbac-proxy
+func rbacEnabled(pmi *v1alpha1.PowerMonitorInternal) bool {
+	return pmi.Spec.Kepler.Deployment.Security.Mode == v1alpha1.SecurityModeRBAC
+}
+
+// uwmEnabled returns true if user workload monitoring is allowed to scrape kepler metrics
+func uwmEnabled(pmi *v1alpha1.PowerMonitorInternal) bool {
+	return slices.Contains(
+		pmi.Spec.Kepler.Deployment.Security.AllowedSANames,
+		fmt.Sprintf("%s:%s", powermonitor.UWMNamespace, powermonitor.UWMServiceAccountName),
+	)
+}
+
+// errorReason maps the typed errors returned by the reconcilers to a condition reason
+func errorReason(err error) v1alpha1.ConditionReason {
+	var secretErr *reconciler.SecretNotFoundError
+	var cfmErr *reconciler.ConfigMapNotFoundError
+	var cfgErr *reconciler.InvalidConfigError
+	var rolledBackErr *reconciler.ConfigRolledBackError
+	var revisionErr *reconciler.ConfigRevisionNotFoundError
+	var waitErr *reconciler.WaitingError
+	var nsErr *NamespaceNotWatchedError
+
+	switch {
+	case errors.As(err, &secretErr):
+		return v1alpha1.SecretNotFound
+	case errors.As(err, &cfmErr):
+		return v1alpha1.ConfigMapNotFound
+	case errors.As(err, &cfgErr):
+		return v1alpha1.ConfigInvalid
+	case errors.As(err, &rolledBackErr):
+		return v1alpha1.ConfigRolledBack
+	case errors.As(err, &revisionErr):
+		return v1alpha1.ConfigRevisionNotFound
+	case errors.As(err, &waitErr):
+		return v1alpha1.WaitingForDependency
+	case errors.As(err, &nsErr):
+		return v1alpha1.NamespaceNotWatched
+	default:
+		return v1alpha1.ReconcileError
+	}
+}
+
+// errorMessage returns a message that lists every failing step of a reconcile
+func errorMessage(errs ...error) string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func degradedPowerMonitorCondition(recErr error) v1alpha1.Condition {
+	if recErr == nil {
+		return v1alpha1.Condition{
+			Type:    v1alpha1.Degraded,
+			Status:  v1alpha1.ConditionFalse,
+			Reason:  v1alpha1.AsExpected,
+			Message: "power-monitor is reconciled as expected",
+		}
+	}
+
+	return v1alpha1.Condition{
+		Type:    v1alpha1.Degraded,
+		Status:  v1alpha1.ConditionTrue,
+		Reason:  errorReason(recErr),
+		Message: errorMessage(reconciler.Errors(recErr)...),
+	}
+}
+
+func configValidPowerMonitorCondition(recErr error) v1alpha1.Condition {
+	c := v1alpha1.Condition{
+		Type:    v1alpha1.ConfigValid,
+		Status:  v1alpha1.ConditionTrue,
+		Reason:  v1alpha1.ConfigRendered,
+		Message: "kepler config rendered successfully",
+	}
+
+	var configErrs []error
+	for _, err := range reconciler.Errors(recErr) {
+		if reason := errorReason(err); reason == v1alpha1.ConfigMapNotFound || reason == v1alpha1.ConfigInvalid ||
+			reason == v1alpha1.ConfigRevisionNotFound {
+			if len(configErrs) == 0 {
+				c.Reason = reason
+			}
+			configErrs = append(configErrs, err)
+		}
+	}
+	if len(configErrs) > 0 {
+		c.Status = v1alpha1.ConditionFalse
+		c.Message = errorMessage(configErrs...)
+	}
+	return c
+}
+
+func progressingPowerMonitorConditionForGetError(err error) v1alpha1.Condition {
+	c := v1alpha1.Condition{
+		Type:    v1alpha1.Progressing,
+		Status:  v1alpha1.ConditionUnknown,
+		Reason:  v1alpha1.DaemonSetError,
+		Message: err.Error(),
+	}
+	if apierrors.IsNotFound(err) {
+		c.Reason = v1alpha1.DaemonSetNotFound
+	}
+	return c
+}
+
+func progressingPowerMonitorCondition(dset *appsv1.DaemonSet) v1alpha1.Condition {
+	ds := dset.Status
+	dsName := dset.Namespace + "/" + dset.Name
+
+	c := v1alpha1.Condition{Type: v1alpha1.Progressing, Status: v1alpha1.ConditionTrue}
+
+	switch {
+	case dset.Generation > ds.ObservedGeneration:
+		c.Reason = v1alpha1.DaemonSetOutOfSync
+		c.Message = fmt.Sprintf("Waiting for power-monitor daemonset %q to observe generation %d", dsName, dset.Generation)
+
+	case ds.UpdatedNumberScheduled < ds.DesiredNumberScheduled:
+		c.Reason = v1alpha1.DaemonSetRolloutInProgress
+		c.Message = fmt.Sprintf("Rollout of power-monitor daemonset %q is in progress: %d out of %d new pods have been updated",
+			dsName, ds.UpdatedNumberScheduled, ds.DesiredNumberScheduled)
+
+	case ds.NumberAvailable < ds.DesiredNumberScheduled:
+		c.Reason = v1alpha1.DaemonSetPartiallyAvailable
+		c.Message = fmt.Sprintf("Rollout of power-monitor daemonset %q is in progress: %d of %d updated pods are available",
+			dsName, ds.NumberAvailable, ds.DesiredNumberScheduled)
+
+	default:
+		c.Status = v1alpha1.ConditionFalse
+		c.Reason = v1alpha1.RolloutComplete
+		c.Message = fmt.Sprintf("Rollout of power-monitor daemonset %q is complete", dsName)
+	}
+	return c
+}
+
+// requiredObject is an object that must exist for a condition to be true
+type requiredObject struct {
+	kind string
+	obj  client.Object
+}
+
+// missingObjects returns the "kind/name" of the objects that do not exist
+func missingObjects(ctx context.Context, c client.Reader, objs ...requiredObject) ([]string, error) {
+	missing := []string{}
+	for _, o := range objs {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(o.obj), o.obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			missing = append(missing, o.kind+"/"+o.obj.GetName())
+		}
+	}
+	return missing, nil
+}
+
+// waitingErrors returns the errors of recErr that report objects reconcilers are waiting for
+func waitingErrors(recErr error) []error {
+	var waiting []error
+	for _, err := range reconciler.Errors(recErr) {
+		var waitErr *reconciler.WaitingError
+		if errors.As(err, &waitErr) {
+			waiting = append(waiting, err)
+		}
+	}
+	return waiting
+}
+
+func securityReadyPowerMonitorCondition(ctx context.Context, c client.Reader, pmi *v1alpha1.PowerMonitorInternal, recErr error) v1alpha1.Condition {
+	cond := v1alpha1.Condition{Type: v1alpha1.SecurityReady}
+
+	if !rbacEnabled(pmi) {
+		cond.Status = v1alpha1.ConditionTrue
+		cond.Reason = v1alpha1.SecurityNotRequired
+		cond.Message = fmt.Sprintf("security mode %q requires no additional objects", pmi.Spec.Kepler.Deployment.Security.Mode)
+		return cond
+	}
+
+	ns := pmi.Namespace()
+	required := []requiredObject{
+		{"secret", &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: powermonitor.SecretKubeRBACProxyConfigName, Namespace: ns}}},
+		{"secret", &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: powermonitor.SecretTLSCertName, Namespace: ns}}},
+	}
+	if uwmEnabled(pmi) {
+		required = append(required, requiredObject{
+			"configmap", &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: powermonitor.PowerMonitorCertsCABundleName, Namespace: ns}},
+		})
+	}
+
+	missing, err := missingObjects(ctx, c, required...)
+	waiting := waitingErrors(recErr)
+	switch {
+	case err != nil:
+		cond.Status = v1alpha1.ConditionUnknown
+		cond.Reason = v1alpha1.SecurityError
+		cond.Message = err.Error()
+	case len(missing) > 0:
+		cond.Status = v1alpha1.ConditionFalse
+		cond.Reason = v1alpha1.SecurityObjectsMissing
+		cond.Message = fmt.Sprintf("waiting for %s in %q namespace", strings.Join(missing, ", "), ns)
+	case len(waiting) > 0:
+		cond.Status = v1alpha1.ConditionFalse
+		cond.Reason = v1alpha1.WaitingForDependency
+		cond.Message = errorMessage(waiting...)
+	default:
+		cond.Status = v1alpha1.ConditionTrue
+		cond.Reason = v1alpha1.SecurityObjectsReady
+		cond.Message = "all objects required by kube-rbac-proxy are present"
+	}
+	return cond
+}
+
+// monitoringIntegratedPowerMonitorCondition returns the MonitoringIntegrated
+// condition of pmi; crdsInstalled is true if the prometheus-operator CRDs are installed
+func monitoringIntegratedPowerMonitorCondition(ctx context.Context, c client.Reader, pmi *v1alpha1.PowerMonitorInternal, crdsInstalled bool) v1alpha1.Condition {
+	cond := v1alpha1.Condition{Type: v1alpha1.MonitoringIntegrated}
+	enableRBAC, enableUWM := rbacEnabled(pmi), uwmEnabled(pmi)
+
+	if !crdsInstalled {
+		cond.Status = v1alpha1.ConditionFalse
+		cond.Reason = v1alpha1.CRDNotInstalled
+		cond.Message = "prometheus-operator CRDs (monitoring.coreos.com) are not installed; no ServiceMonitor is created until they are"
+		return cond
+	}
+
+	if enableRBAC && !enableUWM {
+		cond.Status = v1alpha1.ConditionFalse
+		cond.Reason = v1alpha1.ServiceMonitorNotFound
+		cond.Message = "no ServiceMonitor is created since user workload monitoring is not in spec.kepler.deployment.security.allowedSANames"
+		return cond
+	}
+
+	monitor := requiredObject{"servicemonitor", powermonitor.NewPowerMonitorServiceMonitor(components.Metadata, pmi)}
+	if powermonitor.UsePodMonitor(pmi) {
+		monitor = requiredObject{"podmonitor", powermonitor.NewPowerMonitorPodMonitor(components.Metadata, pmi)}
+	}
+	missing, err := missingObjects(ctx, c, monitor)
+	if err == nil && len(missing) == 0 && enableRBAC {
+		cond.Reason = v1alpha1.UWMTokenNotFound
+		missing, err = missingObjects(ctx, c, requiredObject{
+			"secret", &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: powermonitor.SecretUWMTokenName, Namespace: pmi.Namespace()}},
+		})
+	} else {
+		cond.Reason = v1alpha1.ServiceMonitorNotFound
+	}
+
+	switch {
+	case err != nil:
+		cond.Status = v1alpha1.ConditionUnknown
+		cond.Reason = v1alpha1.MonitoringError
+		cond.Message = err.Error()
+	case len(missing) > 0:
+		cond.Status = v1alpha1.ConditionFalse
+		cond.Message = fmt.Sprintf("waiting for %s in %q namespace", strings.Join(missing, ", "), pmi.Namespace())
+	default:
+		cond.Status = v1alpha1.ConditionTrue
+		cond.Reason = v1alpha1.MonitoringReady
+		cond.Message = fmt.Sprintf("kepler metrics are scraped using the %s", monitor.obj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	return cond
+}
@@ -0,0 +1,456 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+)
+
+func testScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1alpha1.AddToScheme(scheme)
+	_ = monv1.AddToScheme(scheme)
+	return scheme
+}
+
+func testPowerMonitorInternal(mode v1alpha1.SecurityMode, allowedSANames ...string) *v1alpha1.PowerMonitorInternal {
+	return &v1alpha1.PowerMonitorInternal{
+		ObjectMeta: metav1.ObjectMeta{Name: "power-monitor", Generation: 2},
+		Spec: v1alpha1.PowerMonitorInternalSpec{
+			Kepler: v1alpha1.PowerMonitorInternalKeplerSpec{
+				Deployment: v1alpha1.PowerMonitorInternalKeplerDeploymentSpec{
+					Namespace: "power-monitor",
+					PowerMonitorKeplerDeploymentSpec: v1alpha1.PowerMonitorKeplerDeploymentSpec{
+						Security: v1alpha1.PowerMonitorKeplerDeploymentSecuritySpec{
+							Mode:           mode,
+							AllowedSANames: allowedSANames,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSanitizePowerMonitorConditions(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		conditions := sanitizePowerMonitorConditions(nil)
+		assert.Len(t, conditions, len(powerMonitorConditionTypes))
+		for i, c := range conditions {
+			assert.Equal(t, powerMonitorConditionTypes[i], c.Type)
+			assert.Equal(t, v1alpha1.ConditionFalse, c.Status)
+		}
+	})
+
+	t.Run("preserves existing", func(t *testing.T) {
+		existing := []v1alpha1.Condition{{
+			Type:   v1alpha1.Available,
+			Status: v1alpha1.ConditionTrue,
+			Reason: v1alpha1.DaemonSetReady,
+		}}
+		conditions := sanitizePowerMonitorConditions(existing)
+		assert.Len(t, conditions, len(powerMonitorConditionTypes))
+		assert.Equal(t, existing[0], conditions[0])
+	})
+}
+
+func TestUpdatePowerMonitorConditionTransitions(t *testing.T) {
+	conditions := sanitizePowerMonitorConditions(nil)
+	t1 := metav1.NewTime(time.Now().Add(-time.Minute))
+	t2 := metav1.Now()
+
+	degraded := degradedPowerMonitorCondition(&reconciler.SecretNotFoundError{
+		MissingSecrets: []string{"missing"}, Namespace: "power-monitor",
+	})
+	assert.True(t, updatePowerMonitorCondition(conditions, degraded, t1))
+
+	// no transition when nothing has changed
+	assert.False(t, updatePowerMonitorCondition(conditions, degraded, t2))
+	c := findPowerMonitorCondition(conditions, v1alpha1.Degraded)
+	assert.Equal(t, v1alpha1.ConditionTrue, c.Status)
+	assert.Equal(t, v1alpha1.SecretNotFound, c.Reason)
+	assert.Equal(t, t1, c.LastTransitionTime)
+
+	// recovers once the error is resolved
+	assert.True(t, updatePowerMonitorCondition(conditions, degradedPowerMonitorCondition(nil), t2))
+	c = findPowerMonitorCondition(conditions, v1alpha1.Degraded)
+	assert.Equal(t, v1alpha1.ConditionFalse, c.Status)
+	assert.Equal(t, v1alpha1.AsExpected, c.Reason)
+	assert.Equal(t, t2, c.LastTransitionTime)
+}
+
+func TestDegradedAndConfigValidConditions(t *testing.T) {
+	tt := []struct {
+		scenario       string
+		err            error
+		degraded       v1alpha1.ConditionStatus
+		degradedReason v1alpha1.ConditionReason
+		configValid    v1alpha1.ConditionStatus
+		configReason   v1alpha1.ConditionReason
+	}{
+		{
+			scenario:       "no error",
+			degraded:       v1alpha1.ConditionFalse,
+			degradedReason: v1alpha1.AsExpected,
+			configValid:    v1alpha1.ConditionTrue,
+			configReason:   v1alpha1.ConfigRendered,
+		},
+		{
+			scenario:       "generic error",
+			err:            fmt.Errorf("boom"),
+			degraded:       v1alpha1.ConditionTrue,
+			degradedReason: v1alpha1.ReconcileError,
+			configValid:    v1alpha1.ConditionTrue,
+			configReason:   v1alpha1.ConfigRendered,
+		},
+		{
+			scenario:       "missing secret",
+			err:            &reconciler.SecretNotFoundError{MissingSecrets: []string{"s"}, Namespace: "ns"},
+			degraded:       v1alpha1.ConditionTrue,
+			degradedReason: v1alpha1.SecretNotFound,
+			configValid:    v1alpha1.ConditionTrue,
+			configReason:   v1alpha1.ConfigRendered,
+		},
+		{
+			scenario:       "missing configmap",
+			err:            fmt.Errorf("error creating config: %w", &reconciler.ConfigMapNotFoundError{Name: "c", Namespace: "ns"}),
+			degraded:       v1alpha1.ConditionTrue,
+			degradedReason: v1alpha1.ConfigMapNotFound,
+			configValid:    v1alpha1.ConditionFalse,
+			configReason:   v1alpha1.ConfigMapNotFound,
+		},
+		{
+			scenario:       "invalid config",
+			err:            fmt.Errorf("error creating configmap: %w", &reconciler.InvalidConfigError{Err: fmt.Errorf("invalid log format")}),
+			degraded:       v1alpha1.ConditionTrue,
+			degradedReason: v1alpha1.ConfigInvalid,
+			configValid:    v1alpha1.ConditionFalse,
+			configReason:   v1alpha1.ConfigInvalid,
+		},
+		{
+			scenario:       "crash-looping config rolled back",
+			err:            &reconciler.ConfigRolledBackError{Revision: "bad", RolledBackTo: "good"},
+			degraded:       v1alpha1.ConditionTrue,
+			degradedReason: v1alpha1.ConfigRolledBack,
+			configValid:    v1alpha1.ConditionTrue,
+			configReason:   v1alpha1.ConfigRendered,
+		},
+		{
+			scenario:       "pinned config revision not found",
+			err:            &reconciler.ConfigRevisionNotFoundError{Revision: "pinned", Namespace: "ns"},
+			degraded:       v1alpha1.ConditionTrue,
+			degradedReason: v1alpha1.ConfigRevisionNotFound,
+			configValid:    v1alpha1.ConditionFalse,
+			configReason:   v1alpha1.ConfigRevisionNotFound,
+		},
+		{
+			scenario:       "namespace not watched",
+			err:            &NamespaceNotWatchedError{Namespace: "ns", Err: fmt.Errorf("forbidden")},
+			degraded:       v1alpha1.ConditionTrue,
+			degradedReason: v1alpha1.NamespaceNotWatched,
+			configValid:    v1alpha1.ConditionTrue,
+			configReason:   v1alpha1.ConfigRendered,
+		},
+		{
+			scenario: "aggregated errors",
+			err: errors.Join(
+				&reconciler.ReconcilerError{Reconciler: "reconciler.Updater", Err: fmt.Errorf("boom")},
+				&reconciler.ReconcilerError{
+					Reconciler: "reconciler.SecretMounter",
+					Err:        &reconciler.SecretNotFoundError{MissingSecrets: []string{"s"}, Namespace: "ns"},
+				},
+			),
+			degraded:       v1alpha1.ConditionTrue,
+			degradedReason: v1alpha1.SecretNotFound,
+			configValid:    v1alpha1.ConditionTrue,
+			configReason:   v1alpha1.ConfigRendered,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			degraded := degradedPowerMonitorCondition(tc.err)
+			assert.Equal(t, v1alpha1.Degraded, degraded.Type)
+			assert.Equal(t, tc.degraded, degraded.Status)
+			assert.Equal(t, tc.degradedReason, degraded.Reason)
+
+			configValid := configValidPowerMonitorCondition(tc.err)
+			assert.Equal(t, v1alpha1.ConfigValid, configValid.Type)
+			assert.Equal(t, tc.configValid, configValid.Status)
+			assert.Equal(t, tc.configReason, configValid.Reason)
+		})
+	}
+}
+
+func TestConditionMessagesListFailingSteps(t *testing.T) {
+	err := errors.Join(
+		&reconciler.ReconcilerError{
+			Reconciler: "reconciler.PowerMonitorDeployer", GVK: schema.GroupVersionKind{Kind: "PowerMonitorInternal"}, Name: "power-monitor",
+			Err: &reconciler.InvalidConfigError{Err: fmt.Errorf("invalid log format")},
+		},
+		&reconciler.ReconcilerError{Reconciler: "reconciler.Updater", Err: fmt.Errorf("patch failed")},
+	)
+
+	degraded := degradedPowerMonitorCondition(err)
+	assert.Equal(t, v1alpha1.ConfigInvalid, degraded.Reason)
+	assert.Equal(t,
+		"reconciler.PowerMonitorDeployer (PowerMonitorInternal power-monitor): invalid kepler config: invalid log format; "+
+			"reconciler.Updater: patch failed",
+		degraded.Message)
+
+	// ConfigValid lists only the steps that failed due to the config
+	configValid := configValidPowerMonitorCondition(err)
+	assert.Equal(t, v1alpha1.ConditionFalse, configValid.Status)
+	assert.Equal(t,
+		"reconciler.PowerMonitorDeployer (PowerMonitorInternal power-monitor): invalid kepler config: invalid log format",
+		configValid.Message)
+}
+
+func TestProgressingPowerMonitorCondition(t *testing.T) {
+	tt := []struct {
+		scenario string
+		gen      int64
+		status   appsv1.DaemonSetStatus
+		expected v1alpha1.ConditionStatus
+		reason   v1alpha1.ConditionReason
+	}{
+		{
+			scenario: "generation not observed",
+			gen:      2,
+			status:   appsv1.DaemonSetStatus{ObservedGeneration: 1},
+			expected: v1alpha1.ConditionTrue,
+			reason:   v1alpha1.DaemonSetOutOfSync,
+		},
+		{
+			scenario: "pods being updated",
+			gen:      1,
+			status:   appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 1},
+			expected: v1alpha1.ConditionTrue,
+			reason:   v1alpha1.DaemonSetRolloutInProgress,
+		},
+		{
+			scenario: "pods not available yet",
+			gen:      1,
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2,
+			},
+			expected: v1alpha1.ConditionTrue,
+			reason:   v1alpha1.DaemonSetPartiallyAvailable,
+		},
+		{
+			scenario: "rollout complete",
+			gen:      1,
+			status: appsv1.DaemonSetStatus{
+				ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3,
+			},
+			expected: v1alpha1.ConditionFalse,
+			reason:   v1alpha1.RolloutComplete,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			dset := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "power-monitor", Namespace: "power-monitor", Generation: tc.gen},
+				Status:     tc.status,
+			}
+			c := progressingPowerMonitorCondition(dset)
+			assert.Equal(t, v1alpha1.Progressing, c.Type)
+			assert.Equal(t, tc.expected, c.Status)
+			assert.Equal(t, tc.reason, c.Reason)
+		})
+	}
+
+	t.Run("daemonset not found", func(t *testing.T) {
+		err := apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "daemonsets"}, "power-monitor")
+		c := progressingPowerMonitorConditionForGetError(err)
+		assert.Equal(t, v1alpha1.ConditionUnknown, c.Status)
+		assert.Equal(t, v1alpha1.DaemonSetNotFound, c.Reason)
+	})
+}
+
+func TestSecurityAndMonitoringConditions(t *testing.T) {
+	uwmSA := fmt.Sprintf("%s:%s", powermonitor.UWMNamespace, powermonitor.UWMServiceAccountName)
+	ns := "power-monitor"
+
+	secret := func(name string) client.Object {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+	}
+	caBundle := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: powermonitor.PowerMonitorCertsCABundleName, Namespace: ns}}
+	podMonitored := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+	podMonitored.Spec.Monitoring.ServiceMonitor.Kind = v1alpha1.MonitorKindPodMonitor
+
+	tt := []struct {
+		scenario         string
+		pmi              *v1alpha1.PowerMonitorInternal
+		objects          []client.Object
+		recErr           error
+		noCRDs           bool
+		security         v1alpha1.ConditionStatus
+		securityReason   v1alpha1.ConditionReason
+		monitoring       v1alpha1.ConditionStatus
+		monitoringReason v1alpha1.ConditionReason
+	}{
+		{
+			scenario:         "security mode none without service monitor",
+			pmi:              testPowerMonitorInternal(v1alpha1.SecurityModeNone),
+			security:         v1alpha1.ConditionTrue,
+			securityReason:   v1alpha1.SecurityNotRequired,
+			monitoring:       v1alpha1.ConditionFalse,
+			monitoringReason: v1alpha1.ServiceMonitorNotFound,
+		},
+		{
+			scenario: "security mode none with service monitor",
+			pmi:      testPowerMonitorInternal(v1alpha1.SecurityModeNone),
+			objects: []client.Object{
+				powermonitor.NewPowerMonitorServiceMonitor(components.Metadata, testPowerMonitorInternal(v1alpha1.SecurityModeNone)),
+			},
+			security:         v1alpha1.ConditionTrue,
+			securityReason:   v1alpha1.SecurityNotRequired,
+			monitoring:       v1alpha1.ConditionTrue,
+			monitoringReason: v1alpha1.MonitoringReady,
+		},
+		{
+			scenario:         "rbac without uwm",
+			pmi:              testPowerMonitorInternal(v1alpha1.SecurityModeRBAC),
+			objects:          []client.Object{secret(powermonitor.SecretKubeRBACProxyConfigName)},
+			security:         v1alpha1.ConditionFalse,
+			securityReason:   v1alpha1.SecurityObjectsMissing,
+			monitoring:       v1alpha1.ConditionFalse,
+			monitoringReason: v1alpha1.ServiceMonitorNotFound,
+		},
+		{
+			scenario: "rbac with uwm and missing token",
+			pmi:      testPowerMonitorInternal(v1alpha1.SecurityModeRBAC, uwmSA),
+			objects: []client.Object{
+				secret(powermonitor.SecretKubeRBACProxyConfigName),
+				secret(powermonitor.SecretTLSCertName),
+				caBundle,
+				powermonitor.NewPowerMonitorServiceMonitor(components.Metadata, testPowerMonitorInternal(v1alpha1.SecurityModeRBAC, uwmSA)),
+			},
+			security:         v1alpha1.ConditionTrue,
+			securityReason:   v1alpha1.SecurityObjectsReady,
+			monitoring:       v1alpha1.ConditionFalse,
+			monitoringReason: v1alpha1.UWMTokenNotFound,
+		},
+		{
+			scenario: "rbac with uwm and all objects present",
+			pmi:      testPowerMonitorInternal(v1alpha1.SecurityModeRBAC, uwmSA),
+			objects: []client.Object{
+				secret(powermonitor.SecretKubeRBACProxyConfigName),
+				secret(powermonitor.SecretTLSCertName),
+				secret(powermonitor.SecretUWMTokenName),
+				caBundle,
+				powermonitor.NewPowerMonitorServiceMonitor(components.Metadata, testPowerMonitorInternal(v1alpha1.SecurityModeRBAC, uwmSA)),
+			},
+			security:         v1alpha1.ConditionTrue,
+			securityReason:   v1alpha1.SecurityObjectsReady,
+			monitoring:       v1alpha1.ConditionTrue,
+			monitoringReason: v1alpha1.MonitoringReady,
+		},
+		{
+			scenario: "rbac with uwm waiting for the uwm service account",
+			pmi:      testPowerMonitorInternal(v1alpha1.SecurityModeRBAC, uwmSA),
+			objects: []client.Object{
+				secret(powermonitor.SecretKubeRBACProxyConfigName),
+				secret(powermonitor.SecretTLSCertName),
+				caBundle,
+			},
+			recErr: errors.Join(
+				&reconciler.ReconcilerError{Reconciler: "reconciler.UWMSecretTokenReconciler", Err: &reconciler.WaitingError{
+					Kind: "serviceaccount", Name: powermonitor.UWMServiceAccountName, Namespace: powermonitor.UWMNamespace,
+				}},
+			),
+			security:         v1alpha1.ConditionFalse,
+			securityReason:   v1alpha1.WaitingForDependency,
+			monitoring:       v1alpha1.ConditionFalse,
+			monitoringReason: v1alpha1.ServiceMonitorNotFound,
+		},
+		{
+			scenario: "pod monitor kind with service monitor",
+			pmi:      podMonitored,
+			objects: []client.Object{
+				powermonitor.NewPowerMonitorServiceMonitor(components.Metadata, podMonitored),
+			},
+			security:         v1alpha1.ConditionTrue,
+			securityReason:   v1alpha1.SecurityNotRequired,
+			monitoring:       v1alpha1.ConditionFalse,
+			monitoringReason: v1alpha1.ServiceMonitorNotFound,
+		},
+		{
+			scenario: "pod monitor kind with pod monitor",
+			pmi:      podMonitored,
+			objects: []client.Object{
+				powermonitor.NewPowerMonitorPodMonitor(components.Metadata, podMonitored),
+			},
+			security:         v1alpha1.ConditionTrue,
+			securityReason:   v1alpha1.SecurityNotRequired,
+			monitoring:       v1alpha1.ConditionTrue,
+			monitoringReason: v1alpha1.MonitoringReady,
+		},
+		{
+			scenario:         "prometheus-operator CRDs not installed",
+			pmi:              testPowerMonitorInternal(v1alpha1.SecurityModeNone),
+			noCRDs:           true,
+			security:         v1alpha1.ConditionTrue,
+			securityReason:   v1alpha1.SecurityNotRequired,
+			monitoring:       v1alpha1.ConditionFalse,
+			monitoringReason: v1alpha1.CRDNotInstalled,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(tc.objects...).Build()
+			ctx := context.Background()
+
+			security := securityReadyPowerMonitorCondition(ctx, c, tc.pmi, tc.recErr)
+			assert.Equal(t, v1alpha1.SecurityReady, security.Type)
+			assert.Equal(t, tc.security, security.Status)
+			assert.Equal(t, tc.securityReason, security.Reason)
+
+			monitoring := monitoringIntegratedPowerMonitorCondition(ctx, c, tc.pmi, !tc.noCRDs)
+			assert.Equal(t, v1alpha1.MonitoringIntegrated, monitoring.Type)
+			assert.Equal(t, tc.monitoring, monitoring.Status)
+			assert.Equal(t, tc.monitoringReason, monitoring.Reason)
+		})
+	}
+}
+
+func TestInvalidPowerMonitorConditions(t *testing.T) {
+	now := metav1.Now()
+	conditions := invalidPowerMonitorConditions(3, now)
+	assert.Len(t, conditions, len(powerMonitorConditionTypes))
+	for _, c := range conditions {
+		assert.Equal(t, v1alpha1.InvalidPowerMonitorResource, c.Reason)
+		assert.Equal(t, int64(3), c.ObservedGeneration)
+		if c.Type == v1alpha1.Reconciled {
+			assert.Equal(t, v1alpha1.ConditionFalse, c.Status)
+		} else {
+			assert.Equal(t, v1alpha1.ConditionUnknown, c.Status)
+		}
+	}
+}
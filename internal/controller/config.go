@@ -16,12 +16,14 @@ var Config = struct {
 	KubeRbacProxyImage   string
 	Image                string
 	Cluster              k8s.Cluster
+	Capabilities         k8s.Capabilities
 	TokenRefreshInterval time.Duration
 	TokenTTL             time.Duration
 }{
 	KubeRbacProxyImage:   "quay.io/brancz/kube-rbac-proxy:v0.19.0",
 	Image:                "",
 	Cluster:              k8s.Kubernetes,
+	Capabilities:         k8s.Capabilities{PrometheusOperator: true}, // until detected in main
 	TokenRefreshInterval: 24 * time.Hour,
 	TokenTTL:             168 * time.Hour,
 }
@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+// daemonSetTolerations are added to all DaemonSet pods by the DaemonSet controller
+// and hence never prevent a node from running a power-monitor pod
+var daemonSetTolerations = []corev1.Toleration{
+	{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeUnreachable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeDiskPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeMemoryPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodePIDPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+}
+
+// coverageRequeueAfter is the delay before the coverage of a power-monitor is
+// computed again while some of its pods aren't ready
+const coverageRequeueAfter = time.Minute
+
+// nodeCoverage computes which of the nodes are monitored by the power-monitor
+// daemonset and the reason for every node that isn't
+func nodeCoverage(dset *appsv1.DaemonSet, nodes []corev1.Node, pods []corev1.Pod) v1alpha1.NodeCoverageStatus {
+	podsByNode := map[string][]corev1.Pod{}
+	for _, p := range pods {
+		if p.Spec.NodeName == "" || !p.DeletionTimestamp.IsZero() {
+			continue
+		}
+		podsByNode[p.Spec.NodeName] = append(podsByNode[p.Spec.NodeName], p)
+	}
+
+	sorted := make([]corev1.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	cov := v1alpha1.NodeCoverageStatus{TotalNodes: int32(len(sorted))}
+	for i := range sorted {
+		node := &sorted[i]
+		nodePods := podsByNode[node.Name]
+		if hasReadyPod(nodePods) {
+			cov.MonitoredNodes++
+			continue
+		}
+		cov.UnmonitoredNodes = append(cov.UnmonitoredNodes, unmonitoredNode(dset, node, nodePods))
+	}
+	return cov
+}
+
+// unmonitoredNode returns the reason why node isn't monitored; the checks are
+// ordered so that the most fundamental reason is reported first
+func unmonitoredNode(dset *appsv1.DaemonSet, node *corev1.Node, pods []corev1.Pod) v1alpha1.UnmonitoredNode {
+	un := v1alpha1.UnmonitoredNode{Name: node.Name}
+	podSpec := dset.Spec.Template.Spec
+
+	if os := node.Labels[corev1.LabelOSStable]; os != "" && os != "linux" {
+		un.Reason = v1alpha1.NonLinuxOS
+		un.Message = fmt.Sprintf("node runs %q; power-monitor runs only on linux nodes", os)
+		return un
+	}
+
+	if mismatch := nodeSelectorMismatch(podSpec.NodeSelector, node.Labels); len(mismatch) > 0 {
+		un.Reason = v1alpha1.NodeSelectorMismatch
+		un.Message = fmt.Sprintf("node labels do not match nodeSelector %s", strings.Join(mismatch, ", "))
+		return un
+	}
+
+	if taint := untoleratedTaint(podSpec.Tolerations, node.Spec.Taints); taint != nil {
+		un.Reason = v1alpha1.UntoleratedTaint
+		un.Message = fmt.Sprintf("taint %s is not tolerated", taint.ToString())
+		return un
+	}
+
+	un.Reason = v1alpha1.PodPending
+	if len(pods) == 0 {
+		un.Message = "no power-monitor pod is scheduled on the node yet"
+		return un
+	}
+	un.Message = fmt.Sprintf("power-monitor pod %q is %s and not ready", pods[0].Name, pods[0].Status.Phase)
+	return un
+}
+
+// nodeSelectorMismatch returns the "key=value" pairs of selector that are not
+// satisfied by labels
+func nodeSelectorMismatch(selector, labels map[string]string) []string {
+	mismatch := []string{}
+	for k, v := range selector {
+		if labels[k] != v {
+			mismatch = append(mismatch, k+"="+v)
+		}
+	}
+	sort.Strings(mismatch)
+	return mismatch
+}
+
+// untoleratedTaint returns the first NoSchedule or NoExecute taint that isn't
+// tolerated by tolerations
+func untoleratedTaint(tolerations []corev1.Toleration, taints []corev1.Taint) *corev1.Taint {
+	all := slices.Concat(daemonSetTolerations, tolerations)
+
+	for i := range taints {
+		taint := &taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range all {
+			if all[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return taint
+		}
+	}
+	return nil
+}
+
+func hasReadyPod(pods []corev1.Pod) bool {
+	for _, p := range pods {
+		if p.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, c := range p.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// coverageRequeue returns the delay before cov must be computed again; 0 if all
+// unmonitored nodes are so for a reason that changes only with a watched object.
+//
+// NOTE: pods aren't watched and the daemonset status reports only counts, so
+// a pod becoming ready on one node while another one becomes unready triggers
+// no reconcile
+func coverageRequeue(cov *v1alpha1.NodeCoverageStatus) time.Duration {
+	if cov == nil {
+		return 0
+	}
+	for _, un := range cov.UnmonitoredNodes {
+		if un.Reason == v1alpha1.PodPending {
+			return coverageRequeueAfter
+		}
+	}
+	return 0
+}
+
+// coveragePercent returns the percentage of monitored nodes; an empty cluster
+// is considered to be fully covered
+func coveragePercent(cov v1alpha1.NodeCoverageStatus) int32 {
+	if cov.TotalNodes == 0 {
+		return 100
+	}
+	return cov.MonitoredNodes * 100 / cov.TotalNodes
+}
+
+func coveragePowerMonitorCondition(cov v1alpha1.NodeCoverageStatus, minPercent *int32) v1alpha1.Condition {
+	threshold := ptr.Deref(minPercent, 100)
+	percent := coveragePercent(cov)
+
+	c := v1alpha1.Condition{
+		Type:   v1alpha1.Coverage,
+		Status: v1alpha1.ConditionTrue,
+		Reason: v1alpha1.CoverageSufficient,
+		Message: fmt.Sprintf("power-monitor is monitoring %d of %d nodes (%d%%); minimum required is %d%%",
+			cov.MonitoredNodes, cov.TotalNodes, percent, threshold),
+	}
+
+	if percent < threshold {
+		c.Status = v1alpha1.ConditionFalse
+		c.Reason = v1alpha1.CoverageBelowThreshold
+		c.Message += "; see status.kepler.coverage.unmonitoredNodes for details"
+	}
+	return c
+}
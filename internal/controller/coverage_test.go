@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+func testNode(name string, labels map[string]string, taints ...corev1.Taint) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       corev1.NodeSpec{Taints: taints},
+	}
+}
+
+func testPod(name, node string, phase corev1.PodPhase, ready bool) corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase:      phase,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+		},
+	}
+}
+
+func testDaemonSet(selector map[string]string, tolerations ...corev1.Toleration) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{NodeSelector: selector, Tolerations: tolerations},
+			},
+		},
+	}
+}
+
+func TestNodeCoverage(t *testing.T) {
+	linux := map[string]string{corev1.LabelOSStable: "linux"}
+	gpuTaint := corev1.Taint{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}
+
+	tt := []struct {
+		scenario  string
+		dset      *appsv1.DaemonSet
+		nodes     []corev1.Node
+		pods      []corev1.Pod
+		monitored int32
+		reasons   map[string]v1alpha1.UnmonitoredReason
+	}{
+		{
+			scenario:  "all nodes monitored",
+			dset:      testDaemonSet(linux),
+			nodes:     []corev1.Node{testNode("a", linux), testNode("b", linux)},
+			pods:      []corev1.Pod{testPod("p-a", "a", corev1.PodRunning, true), testPod("p-b", "b", corev1.PodRunning, true)},
+			monitored: 2,
+			reasons:   map[string]v1alpha1.UnmonitoredReason{},
+		},
+		{
+			scenario: "windows node",
+			dset:     testDaemonSet(linux),
+			nodes: []corev1.Node{
+				testNode("a", linux),
+				testNode("win", map[string]string{corev1.LabelOSStable: "windows"}),
+			},
+			pods:      []corev1.Pod{testPod("p-a", "a", corev1.PodRunning, true)},
+			monitored: 1,
+			reasons:   map[string]v1alpha1.UnmonitoredReason{"win": v1alpha1.NonLinuxOS},
+		},
+		{
+			scenario: "node selector mismatch",
+			dset:     testDaemonSet(map[string]string{"kepler": "true"}),
+			nodes: []corev1.Node{
+				testNode("a", map[string]string{"kepler": "true"}),
+				testNode("b", linux),
+			},
+			pods:      []corev1.Pod{testPod("p-a", "a", corev1.PodRunning, true)},
+			monitored: 1,
+			reasons:   map[string]v1alpha1.UnmonitoredReason{"b": v1alpha1.NodeSelectorMismatch},
+		},
+		{
+			scenario:  "untolerated taint",
+			dset:      testDaemonSet(linux),
+			nodes:     []corev1.Node{testNode("gpu", linux, gpuTaint)},
+			monitored: 0,
+			reasons:   map[string]v1alpha1.UnmonitoredReason{"gpu": v1alpha1.UntoleratedTaint},
+		},
+		{
+			scenario:  "tolerated taint but pod not ready",
+			dset:      testDaemonSet(linux, corev1.Toleration{Operator: corev1.TolerationOpExists}),
+			nodes:     []corev1.Node{testNode("gpu", linux, gpuTaint)},
+			pods:      []corev1.Pod{testPod("p-gpu", "gpu", corev1.PodRunning, false)},
+			monitored: 0,
+			reasons:   map[string]v1alpha1.UnmonitoredReason{"gpu": v1alpha1.PodPending},
+		},
+		{
+			scenario: "taints added by the daemonset controller are ignored",
+			dset:     testDaemonSet(linux),
+			nodes: []corev1.Node{testNode("a", linux, corev1.Taint{
+				Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule,
+			})},
+			monitored: 0,
+			reasons:   map[string]v1alpha1.UnmonitoredReason{"a": v1alpha1.PodPending},
+		},
+		{
+			scenario: "prefer no schedule taints are ignored",
+			dset:     testDaemonSet(linux),
+			nodes: []corev1.Node{testNode("a", linux, corev1.Taint{
+				Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule,
+			})},
+			pods:      []corev1.Pod{testPod("p-a", "a", corev1.PodRunning, true)},
+			monitored: 1,
+			reasons:   map[string]v1alpha1.UnmonitoredReason{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			cov := nodeCoverage(tc.dset, tc.nodes, tc.pods)
+			assert.Equal(t, int32(len(tc.nodes)), cov.TotalNodes)
+			assert.Equal(t, tc.monitored, cov.MonitoredNodes)
+
+			actual := map[string]v1alpha1.UnmonitoredReason{}
+			for _, un := range cov.UnmonitoredNodes {
+				actual[un.Name] = un.Reason
+				assert.NotEmpty(t, un.Message)
+			}
+			assert.Equal(t, tc.reasons, actual)
+		})
+	}
+}
+
+func TestCoveragePowerMonitorCondition(t *testing.T) {
+	tt := []struct {
+		scenario   string
+		cov        v1alpha1.NodeCoverageStatus
+		minPercent *int32
+		status     v1alpha1.ConditionStatus
+		reason     v1alpha1.ConditionReason
+	}{
+		{
+			scenario: "empty cluster",
+			cov:      v1alpha1.NodeCoverageStatus{},
+			status:   v1alpha1.ConditionTrue,
+			reason:   v1alpha1.CoverageSufficient,
+		},
+		{
+			scenario: "full coverage by default",
+			cov:      v1alpha1.NodeCoverageStatus{TotalNodes: 3, MonitoredNodes: 3},
+			status:   v1alpha1.ConditionTrue,
+			reason:   v1alpha1.CoverageSufficient,
+		},
+		{
+			scenario: "default threshold requires all nodes",
+			cov:      v1alpha1.NodeCoverageStatus{TotalNodes: 3, MonitoredNodes: 2},
+			status:   v1alpha1.ConditionFalse,
+			reason:   v1alpha1.CoverageBelowThreshold,
+		},
+		{
+			scenario:   "above custom threshold",
+			cov:        v1alpha1.NodeCoverageStatus{TotalNodes: 4, MonitoredNodes: 3},
+			minPercent: ptr.To(int32(75)),
+			status:     v1alpha1.ConditionTrue,
+			reason:     v1alpha1.CoverageSufficient,
+		},
+		{
+			scenario:   "below custom threshold",
+			cov:        v1alpha1.NodeCoverageStatus{TotalNodes: 4, MonitoredNodes: 2},
+			minPercent: ptr.To(int32(75)),
+			status:     v1alpha1.ConditionFalse,
+			reason:     v1alpha1.CoverageBelowThreshold,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			c := coveragePowerMonitorCondition(tc.cov, tc.minPercent)
+			assert.Equal(t, v1alpha1.Coverage, c.Type)
+			assert.Equal(t, tc.status, c.Status)
+			assert.Equal(t, tc.reason, c.Reason)
+		})
+	}
+}
+
+func TestCoverageRequeue(t *testing.T) {
+	tt := []struct {
+		scenario string
+		cov      *v1alpha1.NodeCoverageStatus
+		after    time.Duration
+	}{
+		{scenario: "no coverage", cov: nil, after: 0},
+		{
+			scenario: "full coverage",
+			cov:      &v1alpha1.NodeCoverageStatus{TotalNodes: 2, MonitoredNodes: 2},
+			after:    0,
+		},
+		{
+			scenario: "nodes excluded by the spec",
+			cov: &v1alpha1.NodeCoverageStatus{
+				TotalNodes: 2, MonitoredNodes: 0,
+				UnmonitoredNodes: []v1alpha1.UnmonitoredNode{
+					{Name: "win", Reason: v1alpha1.NonLinuxOS},
+					{Name: "gpu", Reason: v1alpha1.UntoleratedTaint},
+				},
+			},
+			after: 0,
+		},
+		{
+			scenario: "pod not ready",
+			cov: &v1alpha1.NodeCoverageStatus{
+				TotalNodes: 2, MonitoredNodes: 0,
+				UnmonitoredNodes: []v1alpha1.UnmonitoredNode{
+					{Name: "win", Reason: v1alpha1.NonLinuxOS},
+					{Name: "node-1", Reason: v1alpha1.PodPending},
+				},
+			},
+			after: coverageRequeueAfter,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			assert.Equal(t, tc.after, coverageRequeue(tc.cov))
+		})
+	}
+}
+
+func TestUpdateCoverageStatusWithoutSelector(t *testing.T) {
+	pmi := &v1alpha1.PowerMonitorInternal{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor"}}
+	pmi.Spec.Kepler.Deployment.Namespace = "power-monitor"
+	pmi.Status.Conditions = sanitizePowerMonitorConditions(nil)
+	dset := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: pmi.DaemonsetName(), Namespace: pmi.Namespace()}}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(dset).Build()
+	r := PowerMonitorInternalReconciler{Client: c}
+
+	assert.NotPanics(t, func() {
+		assert.True(t, r.updatePowerMonitorCoverageStatus(context.TODO(), pmi, metav1.Now()))
+	})
+	coverage := findPowerMonitorCondition(pmi.Status.Conditions, v1alpha1.Coverage)
+	assert.Equal(t, v1alpha1.CoverageError, coverage.Reason)
+	assert.Contains(t, coverage.Message, "has no selector")
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+)
+
+// reconcileDeletion deletes the objects created for pmi. The finalizer is
+// removed only once every cleanup step succeeds unless the deletion times out
+// or is forced; the phase is then recorded in the status before the objects
+// that can't be deleted are left behind.
+func (r *PowerMonitorInternalReconciler) reconcileDeletion(ctx context.Context, req ctrl.Request, pmi *v1alpha1.PowerMonitorInternal) (ctrl.Result, error) {
+	phase := r.deletionPhase(ctx, pmi, time.Now())
+	if phase != currentDeletionPhase(pmi) {
+		r.logger.Info("giving up on deleting objects", "phase", phase)
+		err := r.updatePowerMonitorDeletionStatus(ctx, req, phase, nil)
+		if err == nil {
+			r.recordDeletionEvent(ctx, pmi, phase)
+		}
+		// the objects are deleted on a best-effort basis in the next reconcile
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	r.logger.V(6).Info("Running cleanup reconcilers", "power-monitor-internal", pmi.Name, "phase", phase)
+	result, recErr := r.runPowerMonitorReconcilers(ctx, pmi, nil)
+
+	var updateErr error
+	if phase == v1alpha1.DeletionInProgress {
+		updateErr = r.updatePowerMonitorDeletionStatus(ctx, req, phase, recErr)
+	}
+	if recErr != nil && result.RequeueAfter == 0 {
+		return result, recErr
+	}
+	return result, updateErr
+}
+
+// currentDeletionPhase returns the deletion phase recorded in the status of pmi
+func currentDeletionPhase(pmi *v1alpha1.PowerMonitorInternal) v1alpha1.DeletionPhase {
+	if pmi.Status.Deletion == nil {
+		return v1alpha1.DeletionInProgress
+	}
+	return pmi.Status.Deletion.Phase
+}
+
+// deletionPhase returns the phase the deletion of pmi is in at now; a deletion
+// that timed out or is forced stays so
+func (r PowerMonitorInternalReconciler) deletionPhase(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal, now time.Time) v1alpha1.DeletionPhase {
+	if phase := currentDeletionPhase(pmi); phase != v1alpha1.DeletionInProgress {
+		return phase
+	}
+
+	switch {
+	case r.deletionForced(ctx, pmi):
+		return v1alpha1.DeletionForced
+	case now.Sub(pmi.DeletionTimestamp.Time) > r.deletionTimeout():
+		return v1alpha1.DeletionTimedOut
+	default:
+		return v1alpha1.DeletionInProgress
+	}
+}
+
+func (r PowerMonitorInternalReconciler) deletionTimeout() time.Duration {
+	if r.DeletionTimeout == 0 {
+		return DefaultDeletionTimeout
+	}
+	return r.DeletionTimeout
+}
+
+// deletionForced returns true if pmi or the PowerMonitor of the same name has
+// the force-delete annotation
+func (r PowerMonitorInternalReconciler) deletionForced(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal) bool {
+	if pmi.Annotations[v1alpha1.ForceDeleteAnnotation] == "true" {
+		return true
+	}
+	// NOTE: the PowerMonitor no longer updates power-monitor-internal once it is deleted
+	pm := v1alpha1.PowerMonitor{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: pmi.Name}, &pm); err != nil {
+		return false
+	}
+	return pm.Annotations[v1alpha1.ForceDeleteAnnotation] == "true"
+}
+
+func (r PowerMonitorInternalReconciler) recordDeletionEvent(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal, phase v1alpha1.DeletionPhase) {
+	recorder := eventRecorderForPowerMonitorInternal(ctx, r.Client, r.Recorder, pmi)
+	if recorder == nil {
+		return
+	}
+
+	failed := "none"
+	if d := pmi.Status.Deletion; d != nil && len(d.FailedSteps) > 0 {
+		failed = strings.Join(d.FailedSteps, ", ")
+	}
+	switch phase {
+	case v1alpha1.DeletionTimedOut:
+		recorder.Eventf(pmi, corev1.EventTypeWarning, reconciler.EventDeletionTimedOut,
+			"Objects were not deleted within %s; removing finalizer regardless, failed steps: %s", r.deletionTimeout(), failed)
+	case v1alpha1.DeletionForced:
+		recorder.Eventf(pmi, corev1.EventTypeWarning, reconciler.EventDeletionForced,
+			"Deletion forced by %s annotation; removing finalizer regardless, failed steps: %s", v1alpha1.ForceDeleteAnnotation, failed)
+	}
+}
+
+// updatePowerMonitorDeletionStatus records the deletion phase of pmi along
+// with the cleanup steps that failed in recErr
+func (r PowerMonitorInternalReconciler) updatePowerMonitorDeletionStatus(ctx context.Context, req ctrl.Request, phase v1alpha1.DeletionPhase, recErr error) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		pmi, _ := r.getPowerMonitorInternal(ctx, req)
+		if pmi == nil {
+			return nil
+		}
+
+		deletion := deletionStatus(pmi.Status.Deletion, phase, recErr)
+		if equality.Semantic.DeepEqual(pmi.Status.Deletion, deletion) {
+			return nil
+		}
+		pmi.Status.Deletion = deletion
+		return r.Client.Status().Update(ctx, pmi)
+	})
+}
+
+// deletionStatus returns the deletion status in phase given the previous
+// status and the errors of the cleanup steps
+func deletionStatus(prev *v1alpha1.PowerMonitorInternalDeletionStatus, phase v1alpha1.DeletionPhase, recErr error) *v1alpha1.PowerMonitorInternalDeletionStatus {
+	status := &v1alpha1.PowerMonitorInternalDeletionStatus{Phase: phase}
+
+	if phase != v1alpha1.DeletionInProgress {
+		// the steps that failed last are the ones that may leave objects behind
+		if prev != nil {
+			status.FailedSteps = prev.FailedSteps
+		}
+		if phase == v1alpha1.DeletionTimedOut {
+			status.Message = "objects were not deleted in time and may be orphaned"
+		} else {
+			status.Message = fmt.Sprintf("deletion forced by %s annotation; objects may be orphaned", v1alpha1.ForceDeleteAnnotation)
+		}
+		return status
+	}
+
+	errs := reconciler.Errors(recErr)
+	if len(errs) == 0 {
+		status.Message = "deleting objects"
+		return status
+	}
+	for _, err := range errs {
+		var stepErr *reconciler.ReconcilerError
+		if errors.As(err, &stepErr) && stepErr.Step != "" {
+			status.FailedSteps = append(status.FailedSteps, stepErr.Step)
+		}
+	}
+	status.Message = errorMessage(errs...)
+	return status
+}
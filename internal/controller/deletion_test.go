@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+)
+
+// deletedPowerMonitorInternal returns a power-monitor-internal that was deleted at deletedAt
+func deletedPowerMonitorInternal(deletedAt time.Time, annotations map[string]string) *v1alpha1.PowerMonitorInternal {
+	pmi := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+	pmi.Finalizers = []string{Finalizer}
+	pmi.Annotations = annotations
+	pmi.DeletionTimestamp = &metav1.Time{Time: deletedAt}
+	return pmi
+}
+
+// failingDeleteClient returns a client that fails to delete ClusterRoleBindings
+func failingDeleteClient(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().
+		WithScheme(testScheme()).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1.PowerMonitorInternal{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if _, ok := obj.(*rbacv1.ClusterRoleBinding); ok {
+					return errors.New("forbidden")
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+}
+
+func TestDeletionPhase(t *testing.T) {
+	now := time.Now()
+	forced := map[string]string{v1alpha1.ForceDeleteAnnotation: "true"}
+
+	tt := []struct {
+		scenario string
+		pmi      *v1alpha1.PowerMonitorInternal
+		pm       *v1alpha1.PowerMonitor
+		phase    v1alpha1.DeletionPhase
+	}{
+		{
+			scenario: "recently deleted",
+			pmi:      deletedPowerMonitorInternal(now.Add(-time.Minute), nil),
+			phase:    v1alpha1.DeletionInProgress,
+		},
+		{
+			scenario: "deletion timed out",
+			pmi:      deletedPowerMonitorInternal(now.Add(-DefaultDeletionTimeout-time.Second), nil),
+			phase:    v1alpha1.DeletionTimedOut,
+		},
+		{
+			scenario: "forced by power-monitor-internal annotation",
+			pmi:      deletedPowerMonitorInternal(now, forced),
+			phase:    v1alpha1.DeletionForced,
+		},
+		{
+			scenario: "forced by power-monitor annotation",
+			pmi:      deletedPowerMonitorInternal(now, nil),
+			pm:       &v1alpha1.PowerMonitor{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor", Annotations: forced}},
+			phase:    v1alpha1.DeletionForced,
+		},
+		{
+			scenario: "annotation set to false",
+			pmi:      deletedPowerMonitorInternal(now, map[string]string{v1alpha1.ForceDeleteAnnotation: "false"}),
+			phase:    v1alpha1.DeletionInProgress,
+		},
+		{
+			scenario: "timed out deletion stays so",
+			pmi: func() *v1alpha1.PowerMonitorInternal {
+				pmi := deletedPowerMonitorInternal(now, nil)
+				pmi.Status.Deletion = &v1alpha1.PowerMonitorInternalDeletionStatus{Phase: v1alpha1.DeletionTimedOut}
+				return pmi
+			}(),
+			phase: v1alpha1.DeletionTimedOut,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			b := fake.NewClientBuilder().WithScheme(testScheme())
+			if tc.pm != nil {
+				b = b.WithObjects(tc.pm)
+			}
+			r := PowerMonitorInternalReconciler{Client: b.Build()}
+			assert.Equal(t, tc.phase, r.deletionPhase(context.TODO(), tc.pmi, now))
+		})
+	}
+}
+
+func TestDeletionStatus(t *testing.T) {
+	stepErr := &reconciler.ReconcilerError{
+		Reconciler: "reconciler.Deleter", Step: stepClusterRoleBinding, Err: errors.New("forbidden"),
+	}
+
+	inProgress := deletionStatus(nil, v1alpha1.DeletionInProgress, errors.Join(stepErr))
+	assert.Equal(t, v1alpha1.DeletionInProgress, inProgress.Phase)
+	assert.Equal(t, []string{stepClusterRoleBinding}, inProgress.FailedSteps)
+	assert.Equal(t, "step cluster-role-binding: reconciler.Deleter: forbidden", inProgress.Message)
+
+	// the failed steps are kept once the deletion gives up
+	timedOut := deletionStatus(inProgress, v1alpha1.DeletionTimedOut, nil)
+	assert.Equal(t, v1alpha1.DeletionTimedOut, timedOut.Phase)
+	assert.Equal(t, []string{stepClusterRoleBinding}, timedOut.FailedSteps)
+	assert.Contains(t, timedOut.Message, "orphaned")
+
+	forced := deletionStatus(inProgress, v1alpha1.DeletionForced, nil)
+	assert.Equal(t, v1alpha1.DeletionForced, forced.Phase)
+	assert.Contains(t, forced.Message, v1alpha1.ForceDeleteAnnotation)
+
+	done := deletionStatus(inProgress, v1alpha1.DeletionInProgress, nil)
+	assert.Empty(t, done.FailedSteps)
+}
+
+func TestReconcileDeletion(t *testing.T) {
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "power-monitor"}}
+	getPmi := func(t *testing.T, c client.Client) *v1alpha1.PowerMonitorInternal {
+		pmi := &v1alpha1.PowerMonitorInternal{}
+		require.NoError(t, c.Get(context.TODO(), req.NamespacedName, pmi))
+		return pmi
+	}
+
+	t.Run("failed cleanup step keeps the finalizer", func(t *testing.T) {
+		c := failingDeleteClient(deletedPowerMonitorInternal(time.Now(), nil))
+		r := &PowerMonitorInternalReconciler{Client: c, Scheme: testScheme()}
+
+		for range 2 {
+			result, err := r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err, "errors of a requeue are reported in the status")
+			assert.NotZero(t, result.RequeueAfter)
+		}
+
+		pmi := getPmi(t, c)
+		assert.Contains(t, pmi.Finalizers, Finalizer)
+		require.NotNil(t, pmi.Status.Deletion)
+		assert.Equal(t, v1alpha1.DeletionInProgress, pmi.Status.Deletion.Phase)
+		assert.Equal(t, []string{stepClusterRoleBinding}, pmi.Status.Deletion.FailedSteps)
+		assert.Contains(t, pmi.Status.Deletion.Message, "forbidden")
+	})
+
+	t.Run("successful cleanup removes the finalizer", func(t *testing.T) {
+		c := fake.NewClientBuilder().
+			WithScheme(testScheme()).
+			WithObjects(deletedPowerMonitorInternal(time.Now(), nil)).
+			WithStatusSubresource(&v1alpha1.PowerMonitorInternal{}).
+			Build()
+		r := &PowerMonitorInternalReconciler{Client: c, Scheme: testScheme()}
+
+		_, err := r.Reconcile(context.TODO(), req)
+		assert.NoError(t, err)
+
+		err = c.Get(context.TODO(), req.NamespacedName, &v1alpha1.PowerMonitorInternal{})
+		assert.True(t, apierrors.IsNotFound(err), "power-monitor-internal must be deleted: %v", err)
+	})
+
+	for _, tc := range []struct {
+		scenario    string
+		deletedAt   time.Time
+		annotations map[string]string
+		phase       v1alpha1.DeletionPhase
+		reason      string
+	}{
+		{
+			scenario:  "timed out cleanup records the phase then removes the finalizer",
+			deletedAt: time.Now().Add(-time.Hour),
+			phase:     v1alpha1.DeletionTimedOut,
+			reason:    reconciler.EventDeletionTimedOut,
+		},
+		{
+			scenario:    "forced cleanup records the phase then removes the finalizer",
+			deletedAt:   time.Now(),
+			annotations: map[string]string{v1alpha1.ForceDeleteAnnotation: "true"},
+			phase:       v1alpha1.DeletionForced,
+			reason:      reconciler.EventDeletionForced,
+		},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			pmi := deletedPowerMonitorInternal(tc.deletedAt, tc.annotations)
+			pmi.Status.Deletion = &v1alpha1.PowerMonitorInternalDeletionStatus{
+				Phase:       v1alpha1.DeletionInProgress,
+				FailedSteps: []string{stepClusterRoleBinding},
+			}
+			c := failingDeleteClient(pmi)
+			recorder := record.NewFakeRecorder(10)
+			r := &PowerMonitorInternalReconciler{Client: c, Scheme: testScheme(), Recorder: recorder}
+
+			// the phase is recorded before the finalizer is removed
+			result, err := r.Reconcile(context.TODO(), req)
+			assert.NoError(t, err)
+			assert.True(t, result.Requeue)
+
+			pmi = getPmi(t, c)
+			assert.Contains(t, pmi.Finalizers, Finalizer)
+			require.NotNil(t, pmi.Status.Deletion)
+			assert.Equal(t, tc.phase, pmi.Status.Deletion.Phase)
+			assert.Equal(t, []string{stepClusterRoleBinding}, pmi.Status.Deletion.FailedSteps)
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, tc.reason)
+
+			// cleanup steps are best-effort now and the finalizer is removed
+			// even though the cluster role binding still fails to delete
+			_, err = r.Reconcile(context.TODO(), req)
+			assert.ErrorContains(t, err, "forbidden")
+			err = c.Get(context.TODO(), req.NamespacedName, &v1alpha1.PowerMonitorInternal{})
+			assert.True(t, apierrors.IsNotFound(err), "power-monitor-internal must be deleted: %v", err)
+		})
+	}
+}
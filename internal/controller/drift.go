@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+)
+
+// recordDriftEvents records an event on pmi for each object found drifted
+func recordDriftEvents(recorder record.EventRecorder, pmi *v1alpha1.PowerMonitorInternal, drifts []reconciler.Drift) {
+	if recorder == nil {
+		return
+	}
+	for _, d := range drifts {
+		action := "left as is"
+		if d.Reverted {
+			action = "reverted"
+		}
+		recorder.Eventf(pmi, corev1.EventTypeWarning, reconciler.EventDriftDetected,
+			"%s %s changed by %s (%s); %s",
+			d.GVK.Kind, driftObjectName(d), strings.Join(d.Managers, ", "), strings.Join(d.Fields, ", "), action)
+	}
+}
+
+func driftObjectName(d reconciler.Drift) string {
+	if d.Namespace == "" {
+		return d.Name
+	}
+	return d.Namespace + "/" + d.Name
+}
+
+// updatePowerMonitorDriftStatus updates the drift status of pmi with the
+// drifts found by the last reconcile; returns true if it changed
+func updatePowerMonitorDriftStatus(pmi *v1alpha1.PowerMonitorInternal, drifts []reconciler.Drift, now metav1.Time) bool {
+	status := driftStatus(pmi.Status.Drift, drifts, now)
+	if equality.Semantic.DeepEqual(status, pmi.Status.Drift) {
+		return false
+	}
+	pmi.Status.Drift = status
+	return true
+}
+
+// driftStatus returns the drift status given the current one and the drifts
+// found by the last reconcile. Reverted objects are kept as a record of the
+// last drift detected while objects left as is are removed once no longer
+// found drifted, i.e. once someone reverted them.
+func driftStatus(current *v1alpha1.DriftStatus, drifts []reconciler.Drift, now metav1.Time) *v1alpha1.DriftStatus {
+	if len(drifts) > 0 {
+		resources := make([]v1alpha1.DriftedResource, 0, len(drifts))
+		for _, d := range drifts {
+			resources = append(resources, v1alpha1.DriftedResource{
+				Kind:      d.GVK.Kind,
+				Namespace: d.Namespace,
+				Name:      d.Name,
+				Managers:  d.Managers,
+				Fields:    d.Fields,
+				Reverted:  d.Reverted,
+			})
+		}
+		if current != nil && equality.Semantic.DeepEqual(current.Resources, resources) {
+			// drift left as is is found again by every reconcile
+			return current
+		}
+		return &v1alpha1.DriftStatus{LastDetectionTime: now, Resources: resources}
+	}
+
+	if current == nil {
+		return nil
+	}
+	var reverted []v1alpha1.DriftedResource
+	for _, res := range current.Resources {
+		if res.Reverted {
+			reverted = append(reverted, res)
+		}
+	}
+	if len(reverted) == 0 {
+		return nil
+	}
+	return &v1alpha1.DriftStatus{LastDetectionTime: current.LastDetectionTime, Resources: reverted}
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+)
+
+func TestDriftStatus(t *testing.T) {
+	earlier := metav1.NewTime(time.Now().Add(-time.Hour))
+	now := metav1.Now()
+
+	reverted := reconciler.Drift{
+		GVK:  appsv1.SchemeGroupVersion.WithKind("DaemonSet"),
+		Name: "power-monitor", Namespace: "power-monitor",
+		Managers: []string{"kubectl-edit"}, Fields: []string{".spec.template.spec.nodeSelector"},
+		Reverted: true,
+	}
+	leftAsIs := reconciler.Drift{
+		GVK:  corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+		Name: "power-monitor", Namespace: "power-monitor",
+		Managers: []string{"policy-engine"}, Fields: []string{".data.config.yaml"},
+	}
+
+	t.Run("no drift", func(t *testing.T) {
+		assert.Nil(t, driftStatus(nil, nil, now))
+	})
+
+	t.Run("drift is recorded", func(t *testing.T) {
+		status := driftStatus(nil, []reconciler.Drift{reverted, leftAsIs}, now)
+		require.NotNil(t, status)
+		assert.Equal(t, now, status.LastDetectionTime)
+		require.Len(t, status.Resources, 2)
+		assert.Equal(t, v1alpha1.DriftedResource{
+			Kind: "DaemonSet", Namespace: "power-monitor", Name: "power-monitor",
+			Managers: []string{"kubectl-edit"}, Fields: []string{".spec.template.spec.nodeSelector"},
+			Reverted: true,
+		}, status.Resources[0])
+	})
+
+	t.Run("drift found again keeps its detection time", func(t *testing.T) {
+		current := driftStatus(nil, []reconciler.Drift{leftAsIs}, earlier)
+		status := driftStatus(current, []reconciler.Drift{leftAsIs}, now)
+		assert.Equal(t, earlier, status.LastDetectionTime)
+	})
+
+	t.Run("reverted drift is kept once gone", func(t *testing.T) {
+		current := driftStatus(nil, []reconciler.Drift{reverted, leftAsIs}, earlier)
+		status := driftStatus(current, nil, now)
+		require.NotNil(t, status)
+		assert.Equal(t, earlier, status.LastDetectionTime)
+		require.Len(t, status.Resources, 1)
+		assert.True(t, status.Resources[0].Reverted)
+	})
+
+	t.Run("drift left as is is removed once gone", func(t *testing.T) {
+		current := driftStatus(nil, []reconciler.Drift{leftAsIs}, earlier)
+		assert.Nil(t, driftStatus(current, nil, now))
+	})
+}
+
+func TestRecordDriftEvents(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	pmi := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+
+	recordDriftEvents(recorder, pmi, []reconciler.Drift{{
+		GVK:      rbacv1.SchemeGroupVersion.WithKind("ClusterRole"),
+		Name:     "power-monitor",
+		Managers: []string{"kubectl-edit"},
+		Fields:   []string{".rules"},
+		Reverted: true,
+	}})
+
+	require.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, reconciler.EventDriftDetected)
+	assert.Contains(t, event, "ClusterRole power-monitor changed by kubectl-edit (.rules); reverted")
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+// powerMonitorEventRecorder records events on the object and mirrors them on
+// the PowerMonitor so that they show up in `kubectl describe powermonitor`
+type powerMonitorEventRecorder struct {
+	record.EventRecorder
+	pm *v1alpha1.PowerMonitor
+}
+
+func (r powerMonitorEventRecorder) Event(obj runtime.Object, eventType, reason, message string) {
+	r.EventRecorder.Event(obj, eventType, reason, message)
+	r.EventRecorder.Event(r.pm, eventType, reason, message)
+}
+
+func (r powerMonitorEventRecorder) Eventf(obj runtime.Object, eventType, reason, msgFmt string, args ...any) {
+	r.EventRecorder.Eventf(obj, eventType, reason, msgFmt, args...)
+	r.EventRecorder.Eventf(r.pm, eventType, reason, msgFmt, args...)
+}
+
+func (r powerMonitorEventRecorder) AnnotatedEventf(obj runtime.Object, annotations map[string]string, eventType, reason, msgFmt string, args ...any) {
+	r.EventRecorder.AnnotatedEventf(obj, annotations, eventType, reason, msgFmt, args...)
+	r.EventRecorder.AnnotatedEventf(r.pm, annotations, eventType, reason, msgFmt, args...)
+}
+
+// eventRecorderForPowerMonitorInternal returns a recorder that mirrors events
+// recorded on pmi on the PowerMonitor that owns it; events are recorded only on
+// pmi if it isn't owned by a PowerMonitor
+func eventRecorderForPowerMonitorInternal(ctx context.Context, c client.Reader, recorder record.EventRecorder, pmi *v1alpha1.PowerMonitorInternal) record.EventRecorder {
+	if recorder == nil {
+		return nil
+	}
+
+	owner := metav1.GetControllerOf(pmi)
+	if owner == nil || owner.Kind != "PowerMonitor" {
+		return recorder
+	}
+
+	pm := &v1alpha1.PowerMonitor{}
+	if err := c.Get(ctx, client.ObjectKey{Name: owner.Name}, pm); err != nil {
+		return recorder
+	}
+	return powerMonitorEventRecorder{EventRecorder: recorder, pm: pm}
+}
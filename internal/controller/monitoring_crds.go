@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+// monitoringCRDNames are the names of the prometheus-operator CRDs the
+// operator creates objects of
+var monitoringCRDNames = []string{
+	"servicemonitors." + monv1.SchemeGroupVersion.Group,
+	"podmonitors." + monv1.SchemeGroupVersion.Group,
+	"prometheusrules." + monv1.SchemeGroupVersion.Group,
+}
+
+// monitoringKinds are the kinds of the monitoring CRDs; all must be served
+// for the CRDs to be considered installed
+var monitoringKinds = []schema.GroupKind{
+	{Group: monv1.SchemeGroupVersion.Group, Kind: monv1.ServiceMonitorsKind},
+	{Group: monv1.SchemeGroupVersion.Group, Kind: monv1.PodMonitorsKind},
+	{Group: monv1.SchemeGroupVersion.Group, Kind: monv1.PrometheusRuleKind},
+}
+
+// monitoringCRDs tracks whether the prometheus-operator CRDs are installed
+// while the operator runs; the monitoring objects of power-monitor-internals
+// are reconciled and watched only once they are
+type monitoringCRDs struct {
+	installed atomic.Bool
+	mapper    apimeta.RESTMapper
+	// watch starts watching the monitoring objects; it is called once
+	watch func() error
+
+	mu      sync.Mutex
+	watched bool
+}
+
+// setInstalled records whether the CRDs are installed and starts watching the
+// monitoring objects the first time they are; it returns true if the CRDs
+// were installed or removed
+func (m *monitoringCRDs) setInstalled(installed bool) (bool, error) {
+	changed := m.installed.Swap(installed) != installed
+	if !installed {
+		return changed, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.watched || m.watch == nil {
+		return changed, nil
+	}
+	if err := m.watch(); err != nil {
+		return changed, err
+	}
+	m.watched = true
+	return changed, nil
+}
+
+// served reports whether the API server serves all monitoring kinds; CRDs are
+// served only once they are established
+func (m *monitoringCRDs) served() bool {
+	for _, gk := range monitoringKinds {
+		if _, err := m.mapper.RESTMapping(gk, monv1.SchemeGroupVersion.Version); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// isMonitoringCRD triggers only for the prometheus-operator CRDs
+var isMonitoringCRD = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	for _, name := range monitoringCRDNames {
+		if obj.GetName() == name {
+			return true
+		}
+	}
+	return false
+})
+
+// setupMonitoringCRDs watches the prometheus-operator CRDs with c and watches
+// the ServiceMonitors, PodMonitors and PrometheusRules owned by
+// power-monitor-internals once the CRDs are installed
+func (r *PowerMonitorInternalReconciler) setupMonitoringCRDs(ctl controller.Controller, c cache.Cache, scheme *runtime.Scheme, mapper apimeta.RESTMapper) error {
+	r.monitoring = &monitoringCRDs{
+		mapper: mapper,
+		watch: func() error {
+			if err := ctl.Watch(source.Kind(c, &monv1.ServiceMonitor{},
+				handler.TypedEnqueueRequestForOwner[*monv1.ServiceMonitor](scheme, mapper,
+					&v1alpha1.PowerMonitorInternal{}, handler.OnlyControllerOwner()),
+				predicate.TypedGenerationChangedPredicate[*monv1.ServiceMonitor]{},
+			)); err != nil {
+				return err
+			}
+			if err := ctl.Watch(source.Kind(c, &monv1.PodMonitor{},
+				handler.TypedEnqueueRequestForOwner[*monv1.PodMonitor](scheme, mapper,
+					&v1alpha1.PowerMonitorInternal{}, handler.OnlyControllerOwner()),
+				predicate.TypedGenerationChangedPredicate[*monv1.PodMonitor]{},
+			)); err != nil {
+				return err
+			}
+			return ctl.Watch(source.Kind(c, &monv1.PrometheusRule{},
+				handler.TypedEnqueueRequestForOwner[*monv1.PrometheusRule](scheme, mapper,
+					&v1alpha1.PowerMonitorInternal{}, handler.OnlyControllerOwner()),
+				predicate.TypedGenerationChangedPredicate[*monv1.PrometheusRule]{},
+			))
+		},
+	}
+	if _, err := r.monitoring.setInstalled(Config.Capabilities.PrometheusOperator); err != nil {
+		return err
+	}
+
+	// NOTE: the spec of CRDs is large and not needed; only their metadata is cached
+	crd := &metav1.PartialObjectMetadata{TypeMeta: metav1.TypeMeta{
+		APIVersion: "apiextensions.k8s.io/v1",
+		Kind:       "CustomResourceDefinition",
+	}}
+	return ctl.Watch(source.Kind[client.Object](c, crd,
+		handler.Funcs{
+			CreateFunc: func(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				r.monitoringCRDChanged(ctx, e.Object, q)
+			},
+			UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				r.monitoringCRDChanged(ctx, e.ObjectNew, q)
+			},
+			DeleteFunc: func(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				r.monitoringCRDChanged(ctx, nil, q)
+			},
+		},
+		isMonitoringCRD,
+	))
+}
+
+// monitoringCRDChanged updates whether the prometheus-operator CRDs are
+// installed given a change to crd, nil if it was deleted, and reconciles all
+// power-monitor-internals if they were installed or removed
+func (r *PowerMonitorInternalReconciler) monitoringCRDChanged(ctx context.Context, crd client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	installed := crd != nil && crd.GetDeletionTimestamp().IsZero() && r.monitoring.served()
+	changed, err := r.monitoring.setInstalled(installed)
+	if err != nil {
+		r.logger.Error(err, "failed to watch monitoring objects")
+	}
+	if !changed {
+		return
+	}
+	r.logger.Info("prometheus-operator CRDs changed", "installed", installed)
+	pmis := &v1alpha1.PowerMonitorInternalList{}
+	if err := r.List(ctx, pmis); err != nil {
+		r.logger.Error(err, "failed to list power-monitor-internal objects")
+		return
+	}
+	for _, pmi := range pmis.Items {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: pmi.Name}})
+	}
+}
+
+// prometheusOperatorInstalled reports whether the prometheus-operator CRDs are installed
+func (r PowerMonitorInternalReconciler) prometheusOperatorInstalled() bool {
+	if r.monitoring == nil {
+		return Config.Capabilities.PrometheusOperator
+	}
+	return r.monitoring.installed.Load()
+}
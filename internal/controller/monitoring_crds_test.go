@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+func TestMonitoringCRDChanged(t *testing.T) {
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	watches := 0
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(
+		&v1alpha1.PowerMonitorInternal{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		&v1alpha1.PowerMonitorInternal{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+	).Build()
+	r := &PowerMonitorInternalReconciler{Client: c, logger: logr.Discard()}
+	r.monitoring = &monitoringCRDs{mapper: mapper, watch: func() error {
+		watches++
+		return nil
+	}}
+	ctx := context.TODO()
+	crd := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: monitoringCRDNames[0]}}
+
+	// changed reconciles all power-monitor-internals if the CRDs were installed or removed
+	changed := func(crd *metav1.PartialObjectMetadata) int {
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer q.ShutDown()
+		if crd == nil {
+			r.monitoringCRDChanged(ctx, nil, q)
+		} else {
+			r.monitoringCRDChanged(ctx, crd, q)
+		}
+		return q.Len()
+	}
+
+	assert.Equal(t, 0, changed(crd), "CRDs not established yet")
+	assert.False(t, r.prometheusOperatorInstalled())
+
+	mapper.Add(monv1.SchemeGroupVersion.WithKind(monv1.ServiceMonitorsKind), apimeta.RESTScopeNamespace)
+	mapper.Add(monv1.SchemeGroupVersion.WithKind(monv1.PodMonitorsKind), apimeta.RESTScopeNamespace)
+	assert.Equal(t, 0, changed(crd), "all CRDs are required")
+
+	mapper.Add(monv1.SchemeGroupVersion.WithKind(monv1.PrometheusRuleKind), apimeta.RESTScopeNamespace)
+	assert.Equal(t, 2, changed(crd))
+	assert.True(t, r.prometheusOperatorInstalled())
+	assert.Equal(t, 1, watches)
+
+	assert.Equal(t, 0, changed(crd), "no change")
+
+	assert.Equal(t, 2, changed(nil), "CRD deleted")
+	assert.False(t, r.prometheusOperatorInstalled())
+
+	assert.Equal(t, 2, changed(crd), "CRD installed again")
+	assert.Equal(t, 1, watches, "monitoring objects are watched once")
+
+	now := metav1.Now()
+	crd.DeletionTimestamp = &now
+	assert.Equal(t, 2, changed(crd), "CRD being deleted")
+	assert.False(t, r.prometheusOperatorInstalled())
+}
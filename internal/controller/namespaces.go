@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+// NamespaceCache is a cache of the objects in the namespaces it watches;
+// namespaces are added and removed while the operator runs
+type NamespaceCache interface {
+	// AddNamespace starts watching namespace and returns whether its objects
+	// are cached yet; it fails if the operator can't watch it
+	AddNamespace(ctx context.Context, namespace string) (bool, error)
+	// RemoveNamespace stops watching namespace unless the operator always watches it
+	RemoveNamespace(namespace string)
+	// Namespaces returns the namespaces watched
+	Namespaces() []string
+}
+
+// NamespaceNotWatchedError represents an error when a power-monitor-internal
+// is deployed to a namespace the operator can't watch
+type NamespaceNotWatchedError struct {
+	Namespace string
+	Err       error
+}
+
+func (e *NamespaceNotWatchedError) Error() string {
+	return fmt.Sprintf("namespace %s can't be watched by the operator: %v", e.Namespace, e.Err)
+}
+
+func (e *NamespaceNotWatchedError) Unwrap() error {
+	return e.Err
+}
+
+// watchNamespace adds the namespace pmi is deployed to to the namespaces
+// watched and stops watching the namespaces no power-monitor-internal is
+// deployed to anymore; it returns false until the objects of the namespace
+// are cached
+func (r PowerMonitorInternalReconciler) watchNamespace(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal) (bool, error) {
+	if r.Namespaces == nil {
+		return true, nil
+	}
+	ns := pmi.Namespace()
+	synced, err := r.Namespaces.AddNamespace(ctx, ns)
+	if err != nil {
+		return false, &NamespaceNotWatchedError{Namespace: ns, Err: err}
+	}
+	return synced, r.unwatchNamespaces(ctx)
+}
+
+// unwatchNamespaces stops watching the namespaces no power-monitor-internal is
+// deployed to; a namespace is watched until the power-monitor-internal
+// deployed to it is deleted since its objects are deleted along with it
+func (r PowerMonitorInternalReconciler) unwatchNamespaces(ctx context.Context) error {
+	if r.Namespaces == nil {
+		return nil
+	}
+	pmis := &v1alpha1.PowerMonitorInternalList{}
+	if err := r.Client.List(ctx, pmis); err != nil {
+		return fmt.Errorf("error listing power-monitor-internals: %w", err)
+	}
+	deployed := map[string]bool{}
+	for i := range pmis.Items {
+		deployed[pmis.Items[i].Namespace()] = true
+	}
+	for _, ns := range r.Namespaces.Namespaces() {
+		if !deployed[ns] {
+			r.Namespaces.RemoveNamespace(ns)
+		}
+	}
+	return nil
+}
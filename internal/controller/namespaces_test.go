@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+// fakeNamespaceCache watches namespaces unless they are forbidden; the
+// objects of syncing namespaces are never cached
+type fakeNamespaceCache struct {
+	static    []string
+	watched   []string
+	forbidden map[string]bool
+	syncing   map[string]bool
+}
+
+func (c *fakeNamespaceCache) AddNamespace(_ context.Context, ns string) (bool, error) {
+	if c.forbidden[ns] {
+		return false, errors.New("forbidden")
+	}
+	if !slices.Contains(c.watched, ns) {
+		c.watched = append(c.watched, ns)
+	}
+	return !c.syncing[ns], nil
+}
+
+func (c *fakeNamespaceCache) RemoveNamespace(ns string) {
+	if slices.Contains(c.static, ns) {
+		return
+	}
+	c.watched = slices.DeleteFunc(c.watched, func(w string) bool { return w == ns })
+}
+
+func (c *fakeNamespaceCache) Namespaces() []string {
+	return slices.Clone(c.watched)
+}
+
+func namespacedPowerMonitorInternal(name, ns string) *v1alpha1.PowerMonitorInternal {
+	pmi := &v1alpha1.PowerMonitorInternal{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	pmi.Spec.Kepler.Deployment.Namespace = ns
+	return pmi
+}
+
+func TestWatchNamespace(t *testing.T) {
+	tt := []struct {
+		scenario string
+		pmi      *v1alpha1.PowerMonitorInternal
+		watched  []string
+		want     []string
+		notWatch bool
+		syncing  bool
+	}{
+		{
+			scenario: "namespace of pmi is added",
+			pmi:      namespacedPowerMonitorInternal("a", "ns-a"),
+			watched:  []string{"static"},
+			want:     []string{"static", "ns-a"},
+		},
+		{
+			scenario: "namespaces no pmi is deployed to are removed",
+			pmi:      namespacedPowerMonitorInternal("a", "ns-a"),
+			watched:  []string{"static", "ns-a", "stale"},
+			want:     []string{"static", "ns-a"},
+		},
+		{
+			scenario: "forbidden namespace",
+			pmi:      namespacedPowerMonitorInternal("a", "forbidden"),
+			watched:  []string{"static"},
+			want:     []string{"static"},
+			notWatch: true,
+		},
+		{
+			scenario: "namespace not synced yet",
+			pmi:      namespacedPowerMonitorInternal("a", "syncing"),
+			watched:  []string{"static"},
+			want:     []string{"static", "syncing"},
+			syncing:  true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			nsCache := &fakeNamespaceCache{
+				static:    []string{"static"},
+				watched:   tc.watched,
+				forbidden: map[string]bool{"forbidden": true},
+				syncing:   map[string]bool{"syncing": true},
+			}
+			c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(tc.pmi).Build()
+			r := PowerMonitorInternalReconciler{Client: c, Namespaces: nsCache}
+
+			synced, err := r.watchNamespace(context.TODO(), tc.pmi)
+			if tc.notWatch {
+				var nsErr *NamespaceNotWatchedError
+				require.ErrorAs(t, err, &nsErr)
+				assert.Equal(t, tc.pmi.Namespace(), nsErr.Namespace)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, !tc.syncing, synced)
+			}
+			assert.Equal(t, tc.want, nsCache.Namespaces())
+		})
+	}
+}
+
+func TestUnwatchNamespacesOfDeletedPowerMonitorInternal(t *testing.T) {
+	nsCache := &fakeNamespaceCache{static: []string{"static"}, watched: []string{"static", "ns-a", "ns-b"}}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).
+		WithObjects(namespacedPowerMonitorInternal("b", "ns-b")).
+		Build()
+	r := PowerMonitorInternalReconciler{Client: c, Namespaces: nsCache}
+
+	require.NoError(t, r.unwatchNamespaces(context.TODO()))
+	assert.Equal(t, []string{"static", "ns-b"}, nsCache.Namespaces())
+
+	r.Namespaces = nil
+	assert.NoError(t, r.unwatchNamespaces(context.TODO()), "namespaces watched are fixed without a namespace cache")
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+)
+
+// pause is the state of the pause of the reconcile of an object set by the
+// paused annotations
+type pause struct {
+	paused bool
+	// until is the time the pause expires; zero if it doesn't
+	until   time.Time
+	message string
+}
+
+// pauseOf returns the pause set by annotations at now
+func pauseOf(annotations map[string]string, now time.Time) pause {
+	if annotations[v1alpha1.PausedAnnotation] != "true" {
+		return pause{message: "Reconcile is not paused"}
+	}
+
+	value, ok := annotations[v1alpha1.PausedUntilAnnotation]
+	if !ok {
+		return pause{paused: true, message: fmt.Sprintf("Reconcile paused by the %s annotation", v1alpha1.PausedAnnotation)}
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		// NOTE: an invalid deadline must not resume the reconcile of an object paused explicitly
+		return pause{paused: true, message: fmt.Sprintf("Reconcile paused by the %s annotation; ignoring invalid %s: %v",
+			v1alpha1.PausedAnnotation, v1alpha1.PausedUntilAnnotation, err)}
+	}
+	if !now.Before(until) {
+		return pause{until: until, message: fmt.Sprintf("Reconcile resumed; the pause expired at %s", value)}
+	}
+	return pause{paused: true, until: until, message: fmt.Sprintf("Reconcile paused until %s", value)}
+}
+
+// requeueAfter returns the delay before the pause expires; 0 if it doesn't
+func (p pause) requeueAfter(now time.Time) time.Duration {
+	if !p.paused || p.until.IsZero() {
+		return 0
+	}
+	return p.until.Sub(now)
+}
+
+// condition returns the Paused condition of an object of generation
+func (p pause) condition(generation int64) v1alpha1.Condition {
+	c := v1alpha1.Condition{
+		Type:               v1alpha1.Paused,
+		Status:             v1alpha1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             v1alpha1.ReconcileActive,
+		Message:            p.message,
+	}
+	switch {
+	case p.paused:
+		c.Status = v1alpha1.ConditionTrue
+		c.Reason = v1alpha1.ReconcilePaused
+	case !p.until.IsZero():
+		c.Reason = v1alpha1.PauseExpired
+	}
+	return c
+}
+
+// pause returns the pause of pmi; the reconcile of pmi is paused if either
+// pmi or the PowerMonitor of the same name is paused
+func (r PowerMonitorInternalReconciler) pause(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal, now time.Time) pause {
+	p := pauseOf(pmi.Annotations, now)
+	if p.paused {
+		return p
+	}
+	// NOTE: a paused PowerMonitor doesn't update power-monitor-internal, so
+	// its annotations are read here
+	pm := v1alpha1.PowerMonitor{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: pmi.Name}, &pm); err != nil {
+		return p
+	}
+	if pmPause := pauseOf(pm.Annotations, now); pmPause.paused || pmi.Annotations[v1alpha1.PausedAnnotation] == "" {
+		return pmPause
+	}
+	return p
+}
+
+// recordPauseEvent records an event on pmi if its reconcile was paused or
+// resumed since the last status update
+func (r PowerMonitorInternalReconciler) recordPauseEvent(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal, p pause) {
+	wasPaused := false
+	if c := findPowerMonitorCondition(pmi.Status.Conditions, v1alpha1.Paused); c != nil {
+		wasPaused = c.Status == v1alpha1.ConditionTrue
+	}
+	if wasPaused == p.paused {
+		return
+	}
+
+	recorder := eventRecorderForPowerMonitorInternal(ctx, r.Client, r.Recorder, pmi)
+	if recorder == nil {
+		return
+	}
+	if p.paused {
+		recorder.Event(pmi, corev1.EventTypeNormal, reconciler.EventReconcilePaused, p.message)
+		return
+	}
+	recorder.Event(pmi, corev1.EventTypeNormal, reconciler.EventReconcileResumed, p.message)
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+)
+
+func TestPauseOf(t *testing.T) {
+	now := time.Now()
+	paused := map[string]string{v1alpha1.PausedAnnotation: "true"}
+	pausedUntil := func(until string) map[string]string {
+		return map[string]string{v1alpha1.PausedAnnotation: "true", v1alpha1.PausedUntilAnnotation: until}
+	}
+
+	tt := []struct {
+		scenario     string
+		annotations  map[string]string
+		paused       bool
+		reason       v1alpha1.ConditionReason
+		requeueAfter time.Duration
+	}{
+		{
+			scenario: "not annotated",
+			reason:   v1alpha1.ReconcileActive,
+		},
+		{
+			scenario:    "annotation set to false",
+			annotations: map[string]string{v1alpha1.PausedAnnotation: "false"},
+			reason:      v1alpha1.ReconcileActive,
+		},
+		{
+			scenario:    "paused",
+			annotations: paused,
+			paused:      true,
+			reason:      v1alpha1.ReconcilePaused,
+		},
+		{
+			scenario:     "paused until a deadline",
+			annotations:  pausedUntil(now.Add(time.Hour).Format(time.RFC3339)),
+			paused:       true,
+			reason:       v1alpha1.ReconcilePaused,
+			requeueAfter: time.Hour,
+		},
+		{
+			scenario:    "pause expired",
+			annotations: pausedUntil(now.Add(-time.Hour).Format(time.RFC3339)),
+			reason:      v1alpha1.PauseExpired,
+		},
+		{
+			scenario:    "invalid deadline keeps the reconcile paused",
+			annotations: pausedUntil("tomorrow"),
+			paused:      true,
+			reason:      v1alpha1.ReconcilePaused,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			p := pauseOf(tc.annotations, now)
+			assert.Equal(t, tc.paused, p.paused)
+			assert.InDelta(t, tc.requeueAfter, p.requeueAfter(now), float64(time.Second))
+
+			c := p.condition(2)
+			assert.Equal(t, v1alpha1.Paused, c.Type)
+			assert.Equal(t, tc.reason, c.Reason)
+			assert.Equal(t, int64(2), c.ObservedGeneration)
+			if tc.paused {
+				assert.Equal(t, v1alpha1.ConditionTrue, c.Status)
+			} else {
+				assert.Equal(t, v1alpha1.ConditionFalse, c.Status)
+			}
+		})
+	}
+}
+
+func TestPausedPowerMonitorInternal(t *testing.T) {
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "power-monitor"}}
+	paused := map[string]string{v1alpha1.PausedAnnotation: "true"}
+
+	for _, tc := range []struct {
+		scenario string
+		pmi      map[string]string
+		pm       map[string]string
+	}{
+		{scenario: "paused by power-monitor-internal annotation", pmi: paused},
+		{scenario: "paused by power-monitor annotation", pm: paused},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			pmi := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+			pmi.Annotations = tc.pmi
+			pm := &v1alpha1.PowerMonitor{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor", Annotations: tc.pm}}
+
+			c := fake.NewClientBuilder().
+				WithScheme(testScheme()).
+				WithObjects(pmi, pm).
+				WithStatusSubresource(&v1alpha1.PowerMonitorInternal{}).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &PowerMonitorInternalReconciler{Client: c, Scheme: testScheme(), Recorder: recorder}
+
+			result, err := r.Reconcile(context.TODO(), req)
+			require.NoError(t, err)
+			assert.Zero(t, result.RequeueAfter, "a pause without deadline doesn't expire")
+
+			// no object is reconciled while paused
+			err = c.Get(context.TODO(), client.ObjectKey{Name: pmi.Name, Namespace: pmi.Namespace()}, &appsv1.DaemonSet{})
+			assert.True(t, apierrors.IsNotFound(err), "daemonset must not be created while paused: %v", err)
+
+			updated := &v1alpha1.PowerMonitorInternal{}
+			require.NoError(t, c.Get(context.TODO(), req.NamespacedName, updated))
+			cond := findPowerMonitorCondition(updated.Status.Conditions, v1alpha1.Paused)
+			require.NotNil(t, cond)
+			assert.Equal(t, v1alpha1.ConditionTrue, cond.Status)
+			assert.Equal(t, v1alpha1.ReconcilePaused, cond.Reason)
+
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, reconciler.EventReconcilePaused)
+
+			// the event is recorded only when the reconcile is paused
+			_, err = r.Reconcile(context.TODO(), req)
+			require.NoError(t, err)
+			assert.Empty(t, recorder.Events)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+// podFailure is a known cause of Kepler pods failing to run
+type podFailure struct {
+	reason v1alpha1.ConditionReason
+	// patterns match the lower-cased reason and message of a failing container
+	patterns []string
+	// hint suggests a fix for the failure
+	hint func(pmi *v1alpha1.PowerMonitorInternal, cs corev1.ContainerStatus) string
+}
+
+// podFailures are ordered so that the most specific failure is reported first
+var podFailures = []podFailure{{
+	reason:   v1alpha1.ImagePullFailed,
+	patterns: []string{"errimagepull", "imagepullbackoff", "invalidimagename"},
+	hint: func(_ *v1alpha1.PowerMonitorInternal, cs corev1.ContainerStatus) string {
+		return fmt.Sprintf("image %s can't be pulled; check the image reference and the pull secrets", cs.Image)
+	},
+}, {
+	reason:   v1alpha1.PodOOMKilled,
+	patterns: []string{"oomkilled"},
+	hint: func(*v1alpha1.PowerMonitorInternal, corev1.ContainerStatus) string {
+		return "container ran out of memory; consider lowering maxTerminated or the metric levels"
+	},
+}, {
+	reason:   v1alpha1.HostPathUnreadable,
+	patterns: []string{"invalid sysfs path", "invalid procfs path", "/sys: permission denied", "/proc: permission denied"},
+	hint: func(*v1alpha1.PowerMonitorInternal, corev1.ContainerStatus) string {
+		return "host sysfs or procfs is unreadable; check that /sys and /proc are mounted and readable by the container"
+	},
+}, {
+	reason:   v1alpha1.NoPowerMeter,
+	patterns: []string{"no rapl zones", "rapl zones not found", "no power meter", "powercap", "failed to create cpu power meter"},
+	hint: func(*v1alpha1.PowerMonitorInternal, corev1.ContainerStatus) string {
+		return "no power meter found; consider hwmon or fake meter, or exclude the node with nodeSelector"
+	},
+}, {
+	reason:   v1alpha1.ConfigParseError,
+	patterns: []string{"yaml:", "invalid configuration", "failed to parse config", "error parsing config"},
+	hint: func(pmi *v1alpha1.PowerMonitorInternal, _ corev1.ContainerStatus) string {
+		refs := pmi.Spec.Kepler.Config.AdditionalConfigMaps
+		if len(refs) == 0 {
+			return "config parse error; check spec.kepler.config"
+		}
+		names := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			names = append(names, ref.Name)
+		}
+		return fmt.Sprintf("config parse error in additional ConfigMap %s", strings.Join(names, ", "))
+	},
+}}
+
+// podFailureCondition returns the Available condition with the reason and
+// suggested fix of the first known failure of the Kepler pods; ok is false if
+// no container of the pods is failing
+func podFailureCondition(pmi *v1alpha1.PowerMonitorInternal, available v1alpha1.Condition, pods []corev1.Pod) (v1alpha1.Condition, bool) {
+	sorted := make([]corev1.Pod, len(pods))
+	copy(sorted, pods)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var crashLooping *corev1.Pod
+	var crashLoopStatus corev1.ContainerStatus
+	for i := range sorted {
+		pod := &sorted[i]
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				continue
+			}
+			text := strings.ToLower(containerFailure(cs))
+			if text == "" {
+				continue
+			}
+			if f := matchPodFailure(text); f != nil {
+				available.Reason = f.reason
+				available.Message = podFailureMessage(pod, cs, f.hint(pmi, cs))
+				return available, true
+			}
+			if crashLooping == nil && cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+				crashLooping, crashLoopStatus = pod, cs
+			}
+		}
+	}
+
+	if crashLooping == nil {
+		return available, false
+	}
+	available.Reason = v1alpha1.PodCrashLooping
+	available.Message = podFailureMessage(crashLooping, crashLoopStatus, "container is crash-looping; check the pod logs")
+	return available, true
+}
+
+// containerFailure returns the reasons and messages of the current and last
+// state of a container that isn't running
+func containerFailure(cs corev1.ContainerStatus) string {
+	var parts []string
+	if w := cs.State.Waiting; w != nil && w.Reason != "ContainerCreating" && w.Reason != "PodInitializing" {
+		parts = append(parts, w.Reason, w.Message)
+	}
+	if t := cs.State.Terminated; t != nil && t.ExitCode != 0 {
+		parts = append(parts, t.Reason, t.Message)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	if t := cs.LastTerminationState.Terminated; t != nil {
+		parts = append(parts, t.Reason, t.Message)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func matchPodFailure(text string) *podFailure {
+	for i := range podFailures {
+		for _, p := range podFailures[i].patterns {
+			if strings.Contains(text, p) {
+				return &podFailures[i]
+			}
+		}
+	}
+	return nil
+}
+
+// podFailureMessage returns the hint along with the pod, its node and the
+// last line the container terminated with
+func podFailureMessage(pod *corev1.Pod, cs corev1.ContainerStatus, hint string) string {
+	msg := fmt.Sprintf("kepler pod %s/%s on node %q: %s", pod.Namespace, pod.Name, pod.Spec.NodeName, hint)
+	if t := cs.LastTerminationState.Terminated; t != nil {
+		if line := lastLine(t.Message); line != "" {
+			msg += ": " + line
+		}
+	}
+	return msg
+}
+
+// lastLine returns the last non-empty line of a termination message, which
+// holds the error Kepler exited with
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+func TestPodFailureCondition(t *testing.T) {
+	crashLoop := func(message string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  "kepler",
+			Image: "quay.io/sustainable_computing_io/kepler:latest",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 1, Reason: "Error", Message: message,
+			}},
+		}
+	}
+	pod := func(name string, statuses ...corev1.ContainerStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "power-monitor"},
+			Spec:       corev1.PodSpec{NodeName: "node-" + name},
+			Status:     corev1.PodStatus{ContainerStatuses: statuses},
+		}
+	}
+
+	tt := []struct {
+		scenario   string
+		additional []string
+		pods       []corev1.Pod
+		reason     v1alpha1.ConditionReason
+		message    string
+	}{
+		{
+			scenario: "no failing container",
+			pods: []corev1.Pod{
+				pod("a", corev1.ContainerStatus{Name: "kepler", Ready: true}),
+				pod("b", corev1.ContainerStatus{Name: "kepler", State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"},
+				}}),
+			},
+		},
+		{
+			scenario: "no RAPL zones on a virtual machine",
+			pods:     []corev1.Pod{pod("a", crashLoop("starting kepler\nfailed to start: no RAPL zones found"))},
+			reason:   v1alpha1.NoPowerMeter,
+			message: `kepler pod power-monitor/a on node "node-a": no power meter found; consider hwmon or fake meter, ` +
+				"or exclude the node with nodeSelector: failed to start: no RAPL zones found",
+		},
+		{
+			scenario: "unreadable sysfs",
+			pods:     []corev1.Pod{pod("a", crashLoop("invalid configuration: invalid sysfs path: /host/sys: permission denied"))},
+			reason:   v1alpha1.HostPathUnreadable,
+			message: `kepler pod power-monitor/a on node "node-a": host sysfs or procfs is unreadable; ` +
+				"check that /sys and /proc are mounted and readable by the container: " +
+				"invalid configuration: invalid sysfs path: /host/sys: permission denied",
+		},
+		{
+			scenario:   "config parse error names the additional configmaps",
+			additional: []string{"custom-kepler-config"},
+			pods:       []corev1.Pod{pod("a", crashLoop("yaml: unmarshal errors:\n  line 3: cannot unmarshal !!str"))},
+			reason:     v1alpha1.ConfigParseError,
+			message: `kepler pod power-monitor/a on node "node-a": config parse error in additional ConfigMap custom-kepler-config: ` +
+				"line 3: cannot unmarshal !!str",
+		},
+		{
+			scenario: "config parse error without additional configmaps",
+			pods:     []corev1.Pod{pod("a", crashLoop("invalid configuration: invalid log level: verbose"))},
+			reason:   v1alpha1.ConfigParseError,
+			message: `kepler pod power-monitor/a on node "node-a": config parse error; check spec.kepler.config: ` +
+				"invalid configuration: invalid log level: verbose",
+		},
+		{
+			scenario: "out of memory",
+			pods: []corev1.Pod{pod("a", corev1.ContainerStatus{
+				Name:  "kepler",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 137, Reason: "OOMKilled",
+				}},
+			})},
+			reason:  v1alpha1.PodOOMKilled,
+			message: `kepler pod power-monitor/a on node "node-a": container ran out of memory; consider lowering maxTerminated or the metric levels`,
+		},
+		{
+			scenario: "image can't be pulled",
+			pods: []corev1.Pod{pod("a", corev1.ContainerStatus{
+				Name:  "kepler",
+				Image: "kepler:missing",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			})},
+			reason:  v1alpha1.ImagePullFailed,
+			message: `kepler pod power-monitor/a on node "node-a": image kepler:missing can't be pulled; check the image reference and the pull secrets`,
+		},
+		{
+			scenario: "unclassified crash loop",
+			pods:     []corev1.Pod{pod("a", crashLoop("panic: runtime error"))},
+			reason:   v1alpha1.PodCrashLooping,
+			message:  `kepler pod power-monitor/a on node "node-a": container is crash-looping; check the pod logs: panic: runtime error`,
+		},
+		{
+			scenario: "known failure is reported over an unclassified crash loop",
+			pods: []corev1.Pod{
+				pod("a", crashLoop("panic: runtime error")),
+				pod("b", crashLoop("no RAPL zones found")),
+			},
+			reason: v1alpha1.NoPowerMeter,
+			message: `kepler pod power-monitor/b on node "node-b": no power meter found; consider hwmon or fake meter, ` +
+				"or exclude the node with nodeSelector: no RAPL zones found",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			pmi := &v1alpha1.PowerMonitorInternal{}
+			for _, name := range tc.additional {
+				pmi.Spec.Kepler.Config.AdditionalConfigMaps = append(pmi.Spec.Kepler.Config.AdditionalConfigMaps,
+					v1alpha1.ConfigMapRef{Name: name})
+			}
+			available := v1alpha1.Condition{
+				Type:   v1alpha1.Available,
+				Status: v1alpha1.ConditionFalse,
+				Reason: v1alpha1.DaemonSetPodsNotRunning,
+			}
+
+			c, ok := podFailureCondition(pmi, available, tc.pods)
+			if tc.reason == "" {
+				assert.False(t, ok)
+				assert.Equal(t, available, c)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, v1alpha1.ConditionFalse, c.Status)
+			assert.Equal(t, tc.reason, c.Reason)
+			assert.Equal(t, tc.message, c.Message)
+		})
+	}
+}
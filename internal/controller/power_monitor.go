@@ -5,6 +5,7 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -19,8 +20,10 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -35,7 +38,9 @@ var PowerMonitorDeploymentNS = "power-monitor"
 // PowerMonitorReconciler reconciles a Kepler object
 type PowerMonitorReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Backoff  *reconciler.Backoff
 
 	logger logr.Logger
 }
@@ -91,12 +96,21 @@ func (r *PowerMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return r.setInvalidStatus(ctx, req)
 	}
 
+	// NOTE: a paused PowerMonitor is still deleted along with its objects
+	now := time.Now()
+	if p := pauseOf(pm.Annotations, now); p.paused && pm.DeletionTimestamp.IsZero() {
+		logger.Info("reconcile paused; updating status only", "until", p.until)
+		return ctrl.Result{RequeueAfter: p.requeueAfter(now)}, r.updatePowerMonitorStatus(ctx, req, nil)
+	}
+
 	logger.V(6).Info("Running sub reconcilers", "power-monitor", pm.Spec)
 
 	result, recErr := r.runPowerMonitorReconcilers(ctx, pm)
 	updateErr := r.updatePowerMonitorStatus(ctx, req, recErr)
 
-	if recErr != nil {
+	// NOTE: errors of a requeue are not returned so that the requeue is
+	// delayed by the backoff of the runner
+	if recErr != nil && result.RequeueAfter == 0 {
 		return result, recErr
 	}
 	return result, updateErr
@@ -111,6 +125,8 @@ func (r PowerMonitorReconciler) runPowerMonitorReconcilers(ctx context.Context,
 		Client:      r.Client,
 		Scheme:      r.Scheme,
 		Logger:      r.logger,
+		Backoff:     r.Backoff,
+		Key:         client.ObjectKeyFromObject(pm),
 	}.Run(ctx)
 }
 
@@ -140,8 +156,10 @@ func (r PowerMonitorReconciler) updatePowerMonitorStatus(ctx context.Context, re
 		// should be set to kepler's current generation to indicate that the
 		// current generation has been "observed"
 		pm.Status = v1alpha1.PowerMonitorStatus{
-			Kepler:     v1alpha1.PowerMonitorKeplerStatus(internal.Status.Kepler), // this may fail
-			Conditions: internal.Status.Conditions,
+			Kepler:              v1alpha1.PowerMonitorKeplerStatus(internal.Status.Kepler), // this may fail
+			Conditions:          sanitizePowerMonitorConditions(internal.Status.Conditions),
+			ConsecutiveRequeues: internal.Status.ConsecutiveRequeues,
+			Drift:               internal.Status.Drift,
 		}
 		for i := range pm.Status.Conditions {
 			pm.Status.Conditions[i].ObservedGeneration = pm.Generation
@@ -194,7 +212,23 @@ func (r PowerMonitorReconciler) getInternalForPowerMonitor(ctx context.Context,
 }
 
 func (r PowerMonitorReconciler) reconcilersForPowerMonitor(pm *v1alpha1.PowerMonitor) []reconciler.Reconciler {
-	op := deleteResource
+	// NOTE: the finalizer is removed only once power-monitor-internal, which
+	// cleans up the objects of power-monitor, is deleted. Its cleanup may take
+	// minutes, so the deleter requeues instead of waiting for it; the deletion
+	// of power-monitor-internal triggers a reconcile since it is owned
+	propagation := metav1.DeletionPropagation("")
+	if pm.Spec.DeletionPolicy == v1alpha1.DeletionPolicyRetain {
+		// keep the objects owned by power-monitor-internal
+		propagation = metav1.DeletePropagationOrphan
+	}
+	op := func(obj client.Object) reconciler.Reconciler {
+		return &reconciler.Deleter{
+			Resource:          obj,
+			OnError:           reconciler.Requeue,
+			RequeueAfter:      deletionRequeueAfter,
+			PropagationPolicy: propagation,
+		}
+	}
 	detail := components.Metadata
 
 	if update := pm.DeletionTimestamp.IsZero(); update {
@@ -205,7 +239,7 @@ func (r PowerMonitorReconciler) reconcilersForPowerMonitor(pm *v1alpha1.PowerMon
 	rs := []reconciler.Reconciler{
 		op(newPowerMonitorInternal(detail, pm)),
 		reconciler.Finalizer{
-			Resource: pm, Finalizer: Finalizer, Logger: r.logger,
+			Resource: pm, Finalizer: Finalizer, Logger: r.logger, Recorder: r.Recorder,
 		},
 	}
 	return rs
@@ -220,21 +254,11 @@ func (r PowerMonitorReconciler) setInvalidStatus(ctx context.Context, req ctrl.R
 		}
 
 		now := metav1.Now()
-		invalidpm.Status.Conditions = []v1alpha1.Condition{{
-			Type:               v1alpha1.Reconciled,
-			Status:             v1alpha1.ConditionFalse,
-			ObservedGeneration: invalidpm.Generation,
-			LastTransitionTime: now,
-			Reason:             v1alpha1.InvalidPowerMonitorResource,
-			Message:            "Only a single instance of PowerMonitor named powermonitor is reconciled",
-		}, {
-			Type:               v1alpha1.Available,
-			Status:             v1alpha1.ConditionUnknown,
-			ObservedGeneration: invalidpm.Generation,
-			LastTransitionTime: now,
-			Reason:             v1alpha1.InvalidPowerMonitorResource,
-			Message:            "This instance of PowerMonitor is invalid",
-		}}
+		invalidpm.Status.Conditions = invalidPowerMonitorConditions(invalidpm.Generation, now)
+		if r.Recorder != nil {
+			r.Recorder.Event(invalidpm, corev1.EventTypeWarning, string(v1alpha1.InvalidPowerMonitorResource),
+				"Only a single instance of PowerMonitor named powermonitor is reconciled")
+		}
 		return r.Client.Status().Update(ctx, invalidpm)
 	})
 
@@ -242,6 +266,28 @@ func (r PowerMonitorReconciler) setInvalidStatus(ctx context.Context, req ctrl.R
 	return ctrl.Result{}, err
 }
 
+// invalidPowerMonitorConditions returns the conditions of a PowerMonitor that
+// isn't reconciled since it isn't the singleton instance
+func invalidPowerMonitorConditions(generation int64, now metav1.Time) []v1alpha1.Condition {
+	conditions := make([]v1alpha1.Condition, 0, len(powerMonitorConditionTypes))
+	for _, t := range powerMonitorConditionTypes {
+		c := v1alpha1.Condition{
+			Type:               t,
+			Status:             v1alpha1.ConditionUnknown,
+			ObservedGeneration: generation,
+			LastTransitionTime: now,
+			Reason:             v1alpha1.InvalidPowerMonitorResource,
+			Message:            "This instance of PowerMonitor is invalid",
+		}
+		if t == v1alpha1.Reconciled {
+			c.Status = v1alpha1.ConditionFalse
+			c.Message = "Only a single instance of PowerMonitor named powermonitor is reconciled"
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions
+}
+
 func newPowerMonitorInternal(d components.Detail, pm *v1alpha1.PowerMonitor) *v1alpha1.PowerMonitorInternal {
 	if d == components.Metadata {
 		return &v1alpha1.PowerMonitorInternal{
@@ -277,8 +323,13 @@ func newPowerMonitorInternal(d components.Detail, pm *v1alpha1.PowerMonitor) *v1
 					Staleness:            pm.Spec.Kepler.Config.Staleness,
 					SampleRate:           pm.Spec.Kepler.Config.SampleRate,
 					MaxTerminated:        pm.Spec.Kepler.Config.MaxTerminated,
+					RevisionHistoryLimit: pm.Spec.Kepler.Config.RevisionHistoryLimit,
+					PinnedRevision:       pm.Spec.Kepler.Config.PinnedRevision,
 				},
 			},
+			DeletionPolicy: pm.Spec.DeletionPolicy,
+			Monitoring:     pm.Spec.Monitoring,
+			Carbon:         pm.Spec.Carbon,
 			OpenShift: v1alpha1.PowerMonitorInternalOpenShiftSpec{
 				Enabled: isOpenShift,
 				Dashboard: v1alpha1.PowerMonitorInternalDashboardSpec{
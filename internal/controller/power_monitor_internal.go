@@ -6,32 +6,33 @@ package controller
 import (
 	"context"
 	"fmt"
-
-	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
 	secv1 "github.com/openshift/api/security/v1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/internal/metrics"
 	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
 	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
 	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
 	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 
-	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -42,10 +43,42 @@ import (
 // KeplerInternalReconciler reconciles a Kepler object
 type PowerMonitorInternalReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	logger logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Backoff  *reconciler.Backoff
+	// Parallelism is the number of independent reconcile steps run concurrently
+	Parallelism int
+	// DeletionTimeout is the time given to delete the objects of a
+	// power-monitor-internal before its finalizer is removed regardless
+	DeletionTimeout time.Duration
+	// PruneDryRun reports the stale objects of a power-monitor-internal
+	// instead of deleting them
+	PruneDryRun bool
+	// DriftReportOnly reports the fields of objects changed by other actors
+	// instead of reverting them
+	DriftReportOnly bool
+	// Namespaces, if set, watches the namespaces power-monitor-internals are
+	// deployed to; the namespaces watched are fixed otherwise
+	Namespaces NamespaceCache
+	logger     logr.Logger
+	// monitoring tracks whether the prometheus-operator CRDs are installed
+	monitoring *monitoringCRDs
+	// pruneHistory remembers the objects last pruned and the stale objects
+	// reported in PruneDryRun mode
+	pruneHistory *reconciler.PruneHistory
 }
 
+// DefaultDeletionTimeout is the default time given to delete the objects of a power-monitor-internal
+const DefaultDeletionTimeout = 5 * time.Minute
+
+// deletionRequeueAfter is the delay before checking again whether an object
+// being deleted is gone
+const deletionRequeueAfter = 10 * time.Second
+
+// namespaceSyncRequeueAfter is the delay before checking again whether the
+// cache of the namespace a power-monitor-internal is deployed to has synced
+const namespaceSyncRequeueAfter = time.Second
+
 const (
 	configMapField         = ".spec.kepler.config.additionalConfigMaps.name"
 	deploymentSecretsField = ".spec.kepler.deployment.secrets.name"
@@ -53,6 +86,7 @@ const (
 
 // common to all components deployed by operator
 //+kubebuilder:rbac:groups=core,resources=namespaces,verbs=list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=core,resources=services;configmaps;serviceaccounts;persistentvolumeclaims,verbs=list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=serviceaccounts/token,verbs=create
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=*,verbs=*
@@ -61,11 +95,15 @@ const (
 //+kubebuilder:rbac:groups=apps,resources=daemonsets;deployments,verbs=list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,verbs=list;watch;create;update;patch;delete;use
-//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;prometheusrules,verbs=list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors;prometheusrules,verbs=list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 // RBAC required by Kepler exporter
 //+kubebuilder:rbac:groups=core,resources=nodes;nodes/metrics;nodes/proxy;nodes/stats,verbs=get;list;watch
 
+// RBAC for computing node coverage
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
 // indexAdditonalConfigmaps sets up indexer for PowerMonitorInternal based on referenced ConfigMaps
 func indexAdditonalConfigmaps(mgr ctrl.Manager, logger logr.Logger) error {
 	return mgr.GetFieldIndexer().IndexField(context.Background(),
@@ -82,6 +120,10 @@ func indexAdditonalConfigmaps(mgr ctrl.Manager, logger logr.Logger) error {
 			for _, cm := range pmi.Spec.Kepler.Config.AdditionalConfigMaps {
 				keys = append(keys, cm.Name)
 			}
+			// NOTE: the carbon emission rules are regenerated when their intensities change
+			if carbon := pmi.Spec.Carbon; carbon != nil && carbon.ConfigMap != nil {
+				keys = append(keys, carbon.ConfigMap.Name)
+			}
 			return keys
 		})
 }
@@ -108,6 +150,8 @@ func indexDeploymentSecrets(mgr ctrl.Manager, logger logr.Logger) error {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PowerMonitorInternalReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.pruneHistory = &reconciler.PruneHistory{}
+
 	if err := indexAdditonalConfigmaps(mgr, r.logger); err != nil {
 		r.logger.Error(err, "failed to set up index for PowerMonitorInternal additionalConfigMaps")
 		return err
@@ -136,34 +180,49 @@ func (r *PowerMonitorInternalReconciler) SetupWithManager(mgr ctrl.Manager) erro
 		Owns(&corev1.ServiceAccount{}, genChanged).
 		Owns(&corev1.Service{}, genChanged).
 		Owns(&appsv1.DaemonSet{}, resVerChanged).
-		Owns(&monv1.ServiceMonitor{}, genChanged).
 		Owns(&rbacv1.ClusterRoleBinding{}, genChanged).
 		Owns(&rbacv1.ClusterRole{}, genChanged).
 		// NOTE: requires resVerChanged for ConfigMap & Secret since
 		// they don't have metadata.generation
 		Watches(&corev1.ConfigMap{}, configMapHandler, resVerChanged).
-		Watches(&corev1.Secret{}, secretHandler, resVerChanged)
-
-	if Config.Cluster == k8s.OpenShift {
-		c = c.Owns(&secv1.SecurityContextConstraints{}, genChanged)
-		c = c.Owns(&corev1.Secret{}, genChanged)
+		Watches(&corev1.Secret{}, secretHandler, resVerChanged).
+		// the force-delete and paused annotations of a PowerMonitor apply to
+		// the power-monitor-internal of the same name
+		Watches(&v1alpha1.PowerMonitor{},
+			handler.EnqueueRequestsFromMapFunc(mapPowerMonitorToRequests),
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{}),
+		).
+		// node coverage and the carbon emission rules depend on the labels
+		// and taints of all nodes
+		Watches(&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNodeToRequests),
+			builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, nodeTaintsChanged)),
+		).
+		// NOTE: reconcilers don't poll for the objects required by kube-rbac-proxy;
+		// the reconcile is triggered by the watches below once they are created.
+		// The kube-rbac-proxy config and the uwm token are owned by power-monitor-internal
+		Owns(&corev1.Secret{}, genChanged).
 		// GenerationChangedPredicate triggers when Spec has changed for the following resources.
 		// AnnotationChangedPredicate triggers when Annotations have changed for the following resources.
 		// These predicates are used to avoid unnecessary reconciliations from ResourceVersionChangedPredicate.
-		c = c.Watches(&corev1.Secret{},
+		Watches(&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.mapSecretToPowerMonitorRequests),
 			builder.WithPredicates(
 				predicate.GenerationChangedPredicate{},
 				predicate.AnnotationChangedPredicate{},
 			),
-		)
-		c = c.Watches(&corev1.ConfigMap{},
+		).
+		Watches(&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(r.mapCABundleConfigMapToPowerMonitorRequests),
 			builder.WithPredicates(
 				predicate.GenerationChangedPredicate{},
 				predicate.AnnotationChangedPredicate{},
 			),
 		)
+
+	if Config.Cluster == k8s.OpenShift {
+		c = c.Owns(&secv1.SecurityContextConstraints{}, genChanged)
+		// NOTE: the user workload monitoring namespace is cached only on OpenShift
 		c = c.Watches(&corev1.ServiceAccount{},
 			handler.EnqueueRequestsFromMapFunc(r.mapServiceAccountToPowerMonitorRequests),
 			builder.WithPredicates(
@@ -172,7 +231,17 @@ func (r *PowerMonitorInternalReconciler) SetupWithManager(mgr ctrl.Manager) erro
 			),
 		)
 	}
-	return c.Complete(r)
+	ctl, err := c.Build(r)
+	if err != nil {
+		return err
+	}
+	// NOTE: ServiceMonitors are watched once the prometheus-operator CRDs are installed
+	return r.setupMonitoringCRDs(ctl, mgr.GetCache(), mgr.GetScheme(), mgr.GetRESTMapper())
+}
+
+// mapPowerMonitorToRequests returns the reconcile request for the power-monitor-internal of a PowerMonitor
+func mapPowerMonitorToRequests(ctx context.Context, object client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: object.GetName()}}}
 }
 
 // mapConfigMapToRequests returns the reconcile requests for power-monitor-internal objects for which an associated ConfigMap has changed
@@ -199,23 +268,17 @@ func (r *PowerMonitorInternalReconciler) mapConfigMapToRequests(ctx context.Cont
 	return requests
 }
 
-// mapDeploymentSecretsToRequests returns the reconcile requests for power-monitor-internal objects for which an associated ConfigMap has changed
+// mapDeploymentSecretsToRequests returns the reconcile requests for power-monitor-internal objects for which an associated Secret has changed
 func (r *PowerMonitorInternalReconciler) mapDeploymentSecretsToRequests(ctx context.Context, object client.Object) []reconcile.Request {
-	secret, ok := object.(*corev1.Secret)
-	if !ok {
-		r.logger.Info("failed to cast object to Secret", "object", object.GetName())
-		return nil
-	}
-
 	pmis := &v1alpha1.PowerMonitorInternalList{}
-	err := r.Client.List(ctx, pmis, client.MatchingFields{deploymentSecretsField: secret.Name})
+	err := r.Client.List(ctx, pmis, client.MatchingFields{deploymentSecretsField: object.GetName()})
 	if err != nil {
-		r.logger.Error(err, "failed to list objects using index", "indexKey", secret.Name)
+		r.logger.Error(err, "failed to list objects using index", "indexKey", object.GetName())
 		return nil
 	}
 
 	requests := []reconcile.Request{}
-	r.logger.V(6).Info("pmis found for secret ", "secret", secret.Name, "pmis", len(pmis.Items))
+	r.logger.V(6).Info("pmis found for secret ", "secret", object.GetName(), "pmis", len(pmis.Items))
 	for _, pmi := range pmis.Items {
 		requests = append(requests, reconcile.Request{
 			NamespacedName: types.NamespacedName{Name: pmi.Name},
@@ -224,22 +287,49 @@ func (r *PowerMonitorInternalReconciler) mapDeploymentSecretsToRequests(ctx cont
 	return requests
 }
 
-func (r *PowerMonitorInternalReconciler) mapSecretToPowerMonitorRequests(ctx context.Context, object client.Object) []reconcile.Request {
-	secret, ok := object.(*corev1.Secret)
-	if !ok {
-		r.logger.Info("failed to cast object to Secret", "object", object.GetName())
+// nodeTaintsChanged triggers only when the taints of a node has changed
+var nodeTaintsChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, ok := e.ObjectOld.(*corev1.Node)
+		if !ok {
+			return false
+		}
+		newNode, ok := e.ObjectNew.(*corev1.Node)
+		if !ok {
+			return false
+		}
+		return !equality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints)
+	},
+}
+
+// mapNodeToRequests returns the reconcile requests for all power-monitor-internal objects
+// since a change to any node may change their coverage
+func (r *PowerMonitorInternalReconciler) mapNodeToRequests(ctx context.Context, object client.Object) []reconcile.Request {
+	pmis := &v1alpha1.PowerMonitorInternalList{}
+	if err := r.List(ctx, pmis); err != nil {
+		r.logger.Error(err, "failed to list power-monitor-internal objects", "node", object.GetName())
 		return nil
 	}
 
-	if secret.GetName() != powermonitor.SecretTLSCertName {
-		r.logger.V(6).Info("ignoring secret", "name", secret.GetName())
+	requests := []reconcile.Request{}
+	for _, pmi := range pmis.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: pmi.Name},
+		})
+	}
+	return requests
+}
+
+func (r *PowerMonitorInternalReconciler) mapSecretToPowerMonitorRequests(ctx context.Context, object client.Object) []reconcile.Request {
+	if object.GetName() != powermonitor.SecretTLSCertName {
+		r.logger.V(6).Info("ignoring secret", "name", object.GetName())
 		return nil
 	}
 
 	pmis := &v1alpha1.PowerMonitorInternalList{}
 	err := r.List(ctx, pmis)
 	if err != nil {
-		r.logger.Error(err, "failed to list objects using index", "indexKey", secret.Name)
+		r.logger.Error(err, "failed to list objects using index", "indexKey", object.GetName())
 		return nil
 	}
 
@@ -250,7 +340,7 @@ func (r *PowerMonitorInternalReconciler) mapSecretToPowerMonitorRequests(ctx con
 			continue
 		}
 		ns := pmi.Spec.Kepler.Deployment.Namespace
-		if ns == secret.GetNamespace() {
+		if ns == object.GetNamespace() {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      pmi.Name,
@@ -362,19 +452,71 @@ func (r *PowerMonitorInternalReconciler) Reconcile(ctx context.Context, req ctrl
 	if pmi == nil {
 		// no kepler-x found , so stop here
 		logger.V(6).Info("power-monitor-internal Nil")
-		return ctrl.Result{}, nil
+		metrics.DeletePowerMonitor(req.Name)
+		metrics.DeleteDaemonSet(PowerMonitorDeploymentNS, req.Name)
+		return ctrl.Result{}, r.unwatchNamespaces(ctx)
+	}
+
+	// NOTE: objects are read from the cache, so the namespace of pmi must be
+	// watched to reconcile or delete them
+	synced, err := r.watchNamespace(ctx, pmi)
+	if err != nil {
+		logger.Error(err, "failed to watch namespace of power-monitor-internal", "namespace", pmi.Namespace())
+		if updateErr := r.updatePowerMonitorStatus(ctx, req, err, nil, nil, r.pause(ctx, pmi, time.Now())); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{}, err
+	}
+	if !synced {
+		logger.Info("namespace of power-monitor-internal is not watched yet; waiting for its cache to sync", "namespace", pmi.Namespace())
+		return ctrl.Result{RequeueAfter: namespaceSyncRequeueAfter}, nil
+	}
+
+	if !pmi.DeletionTimestamp.IsZero() {
+		return r.reconcileDeletion(ctx, req, pmi)
+	}
+
+	now := time.Now()
+	p := r.pause(ctx, pmi, now)
+	r.recordPauseEvent(ctx, pmi, p)
+	if p.paused {
+		// NOTE: objects may be edited by hand while paused, e.g. during an
+		// incident, so none of them is reconciled; only the status is updated
+		logger.Info("reconcile paused; updating status only", "until", p.until)
+		updateErr := r.updatePowerMonitorStatus(ctx, req, nil, nil, nil, p)
+		return ctrl.Result{RequeueAfter: minRequeueAfter(p.requeueAfter(now), coverageRequeue(pmi.Status.Kepler.Coverage))}, updateErr
 	}
 
 	logger.V(6).Info("Running sub reconcilers", "power-monitor-internal", pmi.Spec)
 
-	result, recErr := r.runPowerMonitorReconcilers(ctx, pmi)
-	updateErr := r.updatePowerMonitorStatus(ctx, req, recErr)
-	if recErr != nil {
+	drift := &reconciler.DriftDetector{ReportOnly: r.DriftReportOnly}
+	rollout := newRollout(pmi, now)
+	result, recErr := r.runPowerMonitorReconcilers(reconciler.WithDriftDetector(ctx, drift), pmi, rollout)
+	drifts := drift.Drifts()
+	recordDriftEvents(eventRecorderForPowerMonitorInternal(ctx, r.Client, r.Recorder, pmi), pmi, drifts)
+	updateErr := r.updatePowerMonitorStatus(ctx, req, recErr, drifts, rollout, p)
+	// NOTE: readiness of the canary pods triggers a reconcile but the end of the bake time doesn't
+	result.RequeueAfter = minRequeueAfter(result.RequeueAfter, rolloutRequeueAfter(rollout, now))
+	// NOTE: updating the status triggers a reconcile, so the coverage of pmi is
+	// the one computed by the last status update
+	result.RequeueAfter = minRequeueAfter(result.RequeueAfter, coverageRequeue(pmi.Status.Kepler.Coverage))
+	// NOTE: errors of a requeue are reported in the status and not returned so
+	// that the requeue is delayed by the backoff of the runner. Objects waited
+	// for are watched, so creating them triggers the reconcile instead of a requeue.
+	if recErr != nil && result.RequeueAfter == 0 && !reconciler.IsWaiting(recErr) {
 		return result, recErr
 	}
 	return result, updateErr
 }
 
+// minRequeueAfter returns the shortest of the non-zero delays a and b
+func minRequeueAfter(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
 func (r PowerMonitorInternalReconciler) getPowerMonitorInternal(ctx context.Context, req ctrl.Request) (*v1alpha1.PowerMonitorInternal, error) {
 	logger := r.logger.WithValues("power-monitor-internal", req.Name)
 	pmi := v1alpha1.PowerMonitorInternal{}
@@ -391,18 +533,22 @@ func (r PowerMonitorInternalReconciler) getPowerMonitorInternal(ctx context.Cont
 	return &pmi, nil
 }
 
-func (r PowerMonitorInternalReconciler) runPowerMonitorReconcilers(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal) (ctrl.Result, error) {
-	reconcilers, err := r.reconcilersForPowerMonitor(pmi)
+func (r PowerMonitorInternalReconciler) runPowerMonitorReconcilers(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal, rollout *reconciler.Rollout) (ctrl.Result, error) {
+	recorder := eventRecorderForPowerMonitorInternal(ctx, r.Client, r.Recorder, pmi)
+	steps, err := r.reconcilersForPowerMonitor(pmi, rollout, recorder)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	r.logger.V(6).Info("reconcilers ...", "count", len(reconcilers))
+	r.logger.V(6).Info("reconcilers ...", "count", len(steps))
 
 	return reconciler.Runner{
-		Reconcilers: reconcilers,
+		Steps:       steps,
+		Parallelism: r.Parallelism,
 		Client:      r.Client,
 		Scheme:      r.Scheme,
 		Logger:      r.logger,
+		Backoff:     r.Backoff,
+		Key:         client.ObjectKeyFromObject(pmi),
 	}.Run(ctx)
 }
 
@@ -427,122 +573,247 @@ func openshiftPowerMonitorNamespacedResources(pmi *v1alpha1.PowerMonitorInternal
 	res := []client.Object{}
 	if oshift.Dashboard.Enabled {
 		res = append(res,
-			powermonitor.NewPowerMonitorInfoDashboard(components.Full),
-			powermonitor.NewPowerMonitorNamespaceInfoDashboard(components.Full),
+			powermonitor.NewPowerMonitorInfoDashboard(components.Full, pmi),
+			powermonitor.NewPowerMonitorNamespaceInfoDashboard(components.Full, pmi),
 		)
 	}
 	return res
 }
 
-func securityPowerMonitorReconcilers(pmi *v1alpha1.PowerMonitorInternal, cluster k8s.Cluster, enableRBAC, enableUWM bool) []reconciler.Reconciler {
-	rs := []reconciler.Reconciler{}
-	rs = append(rs,
-		reconciler.KubeRBACProxyConfigReconciler{
+// reconcile steps of power-monitor-internal
+const (
+	stepNamespace            = "namespace"
+	stepSecretMounter        = "secret-mounter"
+	stepClusterRole          = "cluster-role"
+	stepClusterRoleBinding   = "cluster-role-binding"
+	stepKubeRBACProxyConfig  = "kube-rbac-proxy-config"
+	stepCABundleConfig       = "ca-bundle-config"
+	stepUWMSecretToken       = "uwm-secret-token"
+	stepServiceAccount       = "service-account"
+	stepService              = "service"
+	stepDeployer             = "deployer"
+	stepKubeRBACProxyObjects = "kube-rbac-proxy-objects"
+	stepCanary               = "canary"
+	stepDaemonSet            = "daemonset"
+	stepServiceMonitor       = "service-monitor"
+	stepCarbonRules          = "carbon-rules"
+	stepPrometheusRule       = "prometheus-rule"
+	stepFinalizer            = "finalizer"
+)
+
+func securityPowerMonitorSteps(pmi *v1alpha1.PowerMonitorInternal, enableRBAC, enableUWM bool, recorder record.EventRecorder) []reconciler.Step {
+	return []reconciler.Step{{
+		Name: stepKubeRBACProxyConfig,
+		Reconciler: reconciler.KubeRBACProxyConfigReconciler{
 			Pmi:        pmi,
 			EnableRBAC: enableRBAC,
 			EnableUWM:  enableUWM,
 		},
-		reconciler.CABundleConfigReconciler{
+		DependsOn: []string{stepNamespace},
+	}, {
+		Name: stepCABundleConfig,
+		Reconciler: reconciler.CABundleConfigReconciler{
 			Pmi:        pmi,
 			EnableRBAC: enableRBAC,
 			EnableUWM:  enableUWM,
 		},
-		reconciler.UWMSecretTokenReconciler{
+		DependsOn: []string{stepNamespace},
+	}, {
+		Name: stepUWMSecretToken,
+		Reconciler: reconciler.UWMSecretTokenReconciler{
 			Pmi:        pmi,
-			Cluster:    cluster,
 			EnableRBAC: enableRBAC,
 			EnableUWM:  enableUWM,
+			Recorder:   recorder,
 		},
-	)
-	return rs
+		DependsOn: []string{stepNamespace},
+	}}
 }
 
-func powerMonitorExporters(pmi *v1alpha1.PowerMonitorInternal, ds *appsv1.DaemonSet, cluster k8s.Cluster) ([]reconciler.Reconciler, error) {
+func powerMonitorExporters(pmi *v1alpha1.PowerMonitorInternal, ds *appsv1.DaemonSet, rollout *reconciler.Rollout, cluster k8s.Cluster, monitoring bool, recorder record.EventRecorder) ([]reconciler.Step, error) {
 	if cleanup := !pmi.DeletionTimestamp.IsZero(); cleanup {
-		rs := resourceReconcilers(
-			deleteResource,
-			// cluster-scoped
-			// remove cluster role binding first, then remove cluster role
-			powermonitor.NewPowerMonitorClusterRoleBinding(components.Metadata, pmi),
-			powermonitor.NewPowerMonitorClusterRole(components.Metadata, pmi),
-		)
-		rs = append(rs, resourceReconcilers(deleteResource, openshiftPowerMonitorNamespacedResources(pmi, cluster)...)...)
+		if deletionPolicy(pmi) == v1alpha1.DeletionPolicyRetain {
+			// objects are orphaned by the PowerMonitor when it deletes power-monitor-internal
+			return nil, nil
+		}
+		deleteResource := newDeleter(cleanupOnError(pmi))
+		// cluster-scoped
+		// remove cluster role binding first, then remove cluster role
+		rs := []reconciler.Step{{
+			Name:       stepClusterRoleBinding,
+			Reconciler: deleteResource(powermonitor.NewPowerMonitorClusterRoleBinding(components.Metadata, pmi)),
+		}, {
+			Name:       stepClusterRole,
+			Reconciler: deleteResource(powermonitor.NewPowerMonitorClusterRole(components.Metadata, pmi)),
+			DependsOn:  []string{stepClusterRoleBinding},
+		}}
+		rs = append(rs, resourceSteps(deleteResource, nil, openshiftPowerMonitorClusterResources(pmi, cluster)...)...)
+		rs = append(rs, resourceSteps(deleteResource, nil, openshiftPowerMonitorNamespacedResources(pmi, cluster)...)...)
 		return rs, nil
 	}
 
 	updateResource := newUpdaterWithOwner(pmi)
 
 	// flags to check if rbac and uwm are set
-	enableRBAC := pmi.Spec.Kepler.Deployment.Security.Mode == v1alpha1.SecurityModeRBAC
-	enableUWM := slices.Contains(
-		pmi.Spec.Kepler.Deployment.Security.AllowedSANames,
-		fmt.Sprintf("%s:%s", powermonitor.UWMNamespace, powermonitor.UWMServiceAccountName),
-	)
+	enableRBAC := rbacEnabled(pmi)
+	enableUWM := uwmEnabled(pmi)
 
 	sm := powermonitor.NewPowerMonitorServiceMonitor(components.Full, pmi)
+	pm := powermonitor.NewPowerMonitorPodMonitor(components.Full, pmi)
 
-	// cluster-scoped resources first
+	// cluster-scoped resources
 	// update cluster role before cluster role binding
-	rs := resourceReconcilers(updateResource,
-		powermonitor.NewPowerMonitorClusterRole(components.Full, pmi),
-		powermonitor.NewPowerMonitorClusterRoleBinding(components.Full, pmi),
-	)
-	rs = append(rs, resourceReconcilers(updateResource, openshiftPowerMonitorClusterResources(pmi, cluster)...)...)
+	rs := []reconciler.Step{{
+		Name:       stepClusterRole,
+		Reconciler: updateResource(powermonitor.NewPowerMonitorClusterRole(components.Full, pmi)),
+	}, {
+		Name:       stepClusterRoleBinding,
+		Reconciler: updateResource(powermonitor.NewPowerMonitorClusterRoleBinding(components.Full, pmi)),
+		DependsOn:  []string{stepClusterRole},
+	}}
+	openshiftSteps := resourceSteps(updateResource, nil, openshiftPowerMonitorClusterResources(pmi, cluster)...)
+	rs = append(rs, openshiftSteps...)
 
 	// kube rbac proxy resources
-	rs = append(rs, securityPowerMonitorReconcilers(pmi, cluster, enableRBAC, enableUWM)...)
+	securitySteps := securityPowerMonitorSteps(pmi, enableRBAC, enableUWM, recorder)
+	rs = append(rs, securitySteps...)
 
 	// namespace scoped
-	rs = append(rs, resourceReconcilers(updateResource,
-		powermonitor.NewPowerMonitorServiceAccount(pmi),
-		powermonitor.NewPowerMonitorService(pmi),
-		// powermonitor.NewPowerMonitorPrometheusRule(kx), prometheus rule is not necessary at the moment
-	)...)
-
-	// check that all required objects have been created for kube rbac proxy
-	rs = append(rs,
-		reconciler.PowerMonitorDeployer{
-			Pmi: pmi,
-			Ds:  ds,
+	rs = append(rs, reconciler.Step{
+		Name:       stepServiceAccount,
+		Reconciler: updateResource(powermonitor.NewPowerMonitorServiceAccount(pmi)),
+		DependsOn:  []string{stepNamespace},
+	}, reconciler.Step{
+		Name:       stepService,
+		Reconciler: updateResource(powermonitor.NewPowerMonitorService(pmi)),
+		DependsOn:  []string{stepNamespace},
+	})
+
+	// NOTE: the secret mounter, the deployer and the kube rbac proxy checker
+	// annotate the daemonset, so they must not run concurrently
+	rs = append(rs, reconciler.Step{
+		Name: stepDeployer,
+		Reconciler: reconciler.PowerMonitorDeployer{
+			Pmi:      pmi,
+			Ds:       ds,
+			Recorder: recorder,
+			Rollout:  rollout,
 		},
-		reconciler.KubeRBACProxyObjectsChecker{
+		DependsOn: []string{stepSecretMounter},
+	}, reconciler.Step{
+		// check that all required objects have been created for kube rbac proxy
+		Name: stepKubeRBACProxyObjects,
+		Reconciler: reconciler.KubeRBACProxyObjectsChecker{
 			Pmi:        pmi,
-			Cluster:    cluster,
 			Ds:         ds,
 			Sm:         sm,
+			Pm:         pm,
 			EnableRBAC: enableRBAC,
 			EnableUWM:  enableUWM,
 		},
-	)
+		DependsOn: append([]string{stepDeployer}, stepNames(securitySteps)...),
+	})
 
-	// deploy daemonset
-	rs = append(rs, resourceReconcilers(updateResource, ds)...)
+	daemonSetDeps := append([]string{stepKubeRBACProxyObjects, stepServiceAccount, stepClusterRoleBinding}, stepNames(openshiftSteps)...)
+
+	// run a change held back by the rollout on the canary nodes before the
+	// daemonset is updated
+	if rollout != nil {
+		rs = append(rs, reconciler.Step{
+			Name: stepCanary,
+			Reconciler: reconciler.CanaryRollout{
+				Pmi:      pmi,
+				Ds:       ds,
+				Rollout:  rollout,
+				Recorder: recorder,
+			},
+			DependsOn: daemonSetDeps,
+		})
+		daemonSetDeps = []string{stepCanary}
+	}
 
-	// deploy service monitor
-	rs = append(rs,
-		reconciler.PowerMonitorServiceMonitorReconciler{
-			Pmi:        pmi,
-			Sm:         sm,
-			EnableRBAC: enableRBAC,
-			EnableUWM:  enableUWM,
+	// deploy daemonset once everything it needs is in place
+	rs = append(rs, reconciler.Step{
+		Name: stepDaemonSet,
+		Reconciler: reconciler.DaemonSetUpdater{
+			Pmi:      pmi,
+			Ds:       ds,
+			Recorder: recorder,
+			Rollout:  rollout,
 		},
-	)
+		DependsOn: daemonSetDeps,
+	})
 
-	rs = append(rs, resourceReconcilers(updateResource, openshiftPowerMonitorNamespacedResources(pmi, cluster)...)...)
+	// deploy the service or pod monitor and the prometheus rule unless
+	// prometheus-operator isn't installed
+	if monitoring {
+		rs = append(rs, reconciler.Step{
+			Name: stepServiceMonitor,
+			Reconciler: reconciler.PowerMonitorServiceMonitorReconciler{
+				Pmi:        pmi,
+				Sm:         sm,
+				Pm:         pm,
+				EnableRBAC: enableRBAC,
+				EnableUWM:  enableUWM,
+			},
+			DependsOn: []string{stepDaemonSet, stepService},
+		})
+
+		// the prometheus rule is deleted once all its alerts and recording rules are disabled
+		rule := powermonitor.NewPowerMonitorPrometheusRule(components.Full, pmi)
+		prometheusRule := deleteResource(powermonitor.NewPowerMonitorPrometheusRule(components.Metadata, pmi))
+		if powermonitor.HasPrometheusRules(pmi) {
+			prometheusRule = updateResource(rule)
+		}
+		rs = append(rs, reconciler.Step{
+			// the carbon emission rules depend on the nodes and their carbon intensity
+			Name:       stepCarbonRules,
+			Reconciler: reconciler.CarbonRulesReconciler{Pmi: pmi, Rule: rule},
+			DependsOn:  []string{stepNamespace},
+		}, reconciler.Step{
+			Name:       stepPrometheusRule,
+			Reconciler: prometheusRule,
+			DependsOn:  []string{stepCarbonRules},
+		})
+	}
+
+	rs = append(rs, resourceSteps(updateResource, nil, openshiftPowerMonitorNamespacedResources(pmi, cluster)...)...)
 	return rs, nil
 }
 
-func (r PowerMonitorInternalReconciler) reconcilersForPowerMonitor(pmi *v1alpha1.PowerMonitorInternal) ([]reconciler.Reconciler, error) {
-	rs := []reconciler.Reconciler{}
+// deletionPolicy returns the deletion policy of pmi; Delete if unset
+func deletionPolicy(pmi *v1alpha1.PowerMonitorInternal) v1alpha1.DeletionPolicy {
+	if pmi.Spec.DeletionPolicy == "" {
+		return v1alpha1.DeletionPolicyDelete
+	}
+	return pmi.Spec.DeletionPolicy
+}
+
+// cleanupOnError returns the action of the cleanup steps of pmi on error; the
+// objects are deleted on a best-effort basis once the deletion timed out or is forced
+func cleanupOnError(pmi *v1alpha1.PowerMonitorInternal) reconciler.Action {
+	if currentDeletionPhase(pmi) == v1alpha1.DeletionInProgress {
+		return reconciler.Requeue
+	}
+	return reconciler.Continue
+}
+
+// reconcilersForPowerMonitor returns the steps that reconcile pmi; steps that
+// don't depend on each other are run concurrently
+func (r PowerMonitorInternalReconciler) reconcilersForPowerMonitor(pmi *v1alpha1.PowerMonitorInternal, rollout *reconciler.Rollout, recorder record.EventRecorder) ([]reconciler.Step, error) {
+	rs := []reconciler.Step{}
 
 	cleanup := !pmi.DeletionTimestamp.IsZero()
 	// not set for deletion
 	if !cleanup {
-		rs = append(rs, reconciler.Updater{
-			Owner:    pmi,
-			Resource: components.NewNamespace(pmi.Namespace()),
-			OnError:  reconciler.Requeue,
-			Logger:   r.logger,
+		rs = append(rs, reconciler.Step{
+			Name: stepNamespace,
+			Reconciler: reconciler.NamespaceReconciler{
+				Owner:     pmi,
+				Namespace: components.NewNamespace(pmi.Namespace()),
+				OnError:   reconciler.Requeue,
+				Logger:    r.logger,
+			},
 		})
 	}
 
@@ -554,39 +825,64 @@ func (r PowerMonitorInternalReconciler) reconcilersForPowerMonitor(pmi *v1alpha1
 
 	// Mount secrets (validate and annotate DaemonSet) before deploying
 	if !cleanup {
-		rs = append(rs, reconciler.SecretMounter{
-			Pmi:    pmi,
-			Ds:     ds,
-			Logger: r.logger,
+		rs = append(rs, reconciler.Step{
+			Name: stepSecretMounter,
+			Reconciler: reconciler.SecretMounter{
+				Pmi:      pmi,
+				Ds:       ds,
+				Logger:   r.logger,
+				Recorder: recorder,
+			},
+			DependsOn: []string{stepNamespace},
 		})
 	}
 
 	// update with image to be used (initial setup for testing then fix to be top level)
-	exporterReconcilers, err := powerMonitorExporters(pmi, ds, Config.Cluster)
+	exporterReconcilers, err := powerMonitorExporters(pmi, ds, rollout, Config.Cluster, r.prometheusOperatorInstalled(), recorder)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create power monitor exporters: %w", err)
 	}
 	rs = append(rs, exporterReconcilers...)
 
-	if cleanup {
-		rs = append(rs, reconciler.Deleter{
-			OnError:     reconciler.Requeue,
-			Resource:    components.NewNamespace(pmi.Namespace()),
-			WaitTimeout: 2 * time.Minute,
+	// NOTE: only a namespace created by the operator is deleted
+	if cleanup && deletionPolicy(pmi) == v1alpha1.DeletionPolicyDelete {
+		rs = append(rs, reconciler.Step{
+			Name: stepNamespace,
+			// NOTE: the namespace takes a while to terminate; the finalizer is
+			// removed only once it is gone, so requeue instead of waiting for it
+			Reconciler: reconciler.NamespaceDeleter{
+				OnError:      cleanupOnError(pmi),
+				Namespace:    components.NewNamespace(pmi.Namespace()),
+				RequeueAfter: deletionRequeueAfter,
+				Logger:       r.logger,
+			},
 		})
 	}
 
-	// WARN: only run finalizer if theren't any errors
-	// this bug 🐛 must be FIXED
-	rs = append(rs, reconciler.Finalizer{
-		Resource:  pmi,
-		Finalizer: Finalizer,
-		Logger:    r.logger,
+	// NOTE: stale objects are pruned only once all other objects are reconciled
+	if !cleanup {
+		rs = append(rs, r.pruneStep(pmi, Config.Cluster, recorder, stepNames(rs)))
+	}
+
+	// NOTE: cleanup steps requeue on error while the deletion is in progress so
+	// that the finalizer is removed only once all of them succeed
+	rs = append(rs, reconciler.Step{
+		Name: stepFinalizer,
+		Reconciler: reconciler.Finalizer{
+			Resource:  pmi,
+			Finalizer: Finalizer,
+			Logger:    r.logger,
+			Recorder:  recorder,
+		},
+		// the finalizer runs last
+		DependsOn: stepNames(rs),
 	})
 	return rs, nil
 }
 
-func (r PowerMonitorInternalReconciler) updatePowerMonitorStatus(ctx context.Context, req ctrl.Request, recErr error) error {
+// updatePowerMonitorStatus updates the status of power-monitor-internal given the
+// result of its reconcile; only the state of its objects is updated while paused
+func (r PowerMonitorInternalReconciler) updatePowerMonitorStatus(ctx context.Context, req ctrl.Request, recErr error, drifts []reconciler.Drift, rollout *reconciler.Rollout, p pause) error {
 	logger := r.logger.WithValues("power-monitor-internal", req.Name, "action", "update-status")
 	logger.V(3).Info("Start of status update")
 	defer logger.V(3).Info("End of status update")
@@ -602,13 +898,30 @@ func (r PowerMonitorInternalReconciler) updatePowerMonitorStatus(ctx context.Con
 		// sanitize the conditions so that all types are present and the order is predictable
 		pmi.Status.Conditions = sanitizePowerMonitorConditions(pmi.Status.Conditions)
 
+		requeues := r.Backoff.Requeues(req.NamespacedName)
+		requeuesChanged := pmi.Status.ConsecutiveRequeues != requeues
+		pmi.Status.ConsecutiveRequeues = requeues
+
 		{
 			now := metav1.Now()
-			reconciledChanged := r.updatePowerMonitorReconciledStatus(ctx, pmi, recErr, now)
+			pausedChanged := updatePowerMonitorCondition(pmi.Status.Conditions, p.condition(pmi.Generation), now)
 			availableChanged := r.updatePowerMonitorAvailableStatus(ctx, pmi, recErr, now)
-			logger.V(6).Info("conditions updated", "reconciled", reconciledChanged, "available", availableChanged)
+			coverageChanged := r.updatePowerMonitorCoverageStatus(ctx, pmi, now)
+			// the result of the last reconcile is kept while paused
+			reconciledChanged, healthChanged, driftChanged, rolloutChanged := false, false, false, false
+			if !p.paused {
+				reconciledChanged = r.updatePowerMonitorReconciledStatus(ctx, pmi, recErr, now)
+				healthChanged = r.updatePowerMonitorHealthStatus(ctx, pmi, recErr, now)
+				driftChanged = updatePowerMonitorDriftStatus(pmi, drifts, now)
+				rolloutChanged = updatePowerMonitorRolloutStatus(pmi, rollout)
+			}
+			metrics.SetPowerMonitorConditions(pmi.Name, pmi.Status.Conditions)
+			logger.V(6).Info("conditions updated",
+				"reconciled", reconciledChanged, "available", availableChanged,
+				"coverage", coverageChanged, "health", healthChanged, "drift", driftChanged,
+				"rollout", rolloutChanged, "paused", pausedChanged)
 
-			if !reconciledChanged && !availableChanged {
+			if !reconciledChanged && !availableChanged && !coverageChanged && !healthChanged && !requeuesChanged && !driftChanged && !rolloutChanged && !pausedChanged {
 				logger.V(6).Info("no changes to existing status; skipping update")
 				return nil
 			}
@@ -618,32 +931,23 @@ func (r PowerMonitorInternalReconciler) updatePowerMonitorStatus(ctx context.Con
 	})
 }
 
-func sanitizePowerMonitorConditions(conditions []v1alpha1.Condition) []v1alpha1.Condition {
-	required := map[v1alpha1.ConditionType]bool{
-		v1alpha1.Reconciled: false,
-		v1alpha1.Available:  false,
-	}
-
-	if len(conditions) == len(required) {
-		return conditions
-	}
-
-	if len(conditions) == 0 {
-		return []v1alpha1.Condition{{
-			Type:   v1alpha1.Reconciled,
-			Status: v1alpha1.ConditionFalse,
-		}, {
-			Type:   v1alpha1.Available,
-			Status: v1alpha1.ConditionFalse,
-		}}
-	}
-
-	for _, c := range conditions {
-		required[c.Type] = true
-	}
+// powerMonitorConditionTypes lists all condition types of power-monitor objects in
+// the order in which they appear in the status
+var powerMonitorConditionTypes = []v1alpha1.ConditionType{
+	v1alpha1.Reconciled,
+	v1alpha1.Available,
+	v1alpha1.Coverage,
+	v1alpha1.Progressing,
+	v1alpha1.Degraded,
+	v1alpha1.ConfigValid,
+	v1alpha1.SecurityReady,
+	v1alpha1.MonitoringIntegrated,
+	v1alpha1.Paused,
+}
 
-	for t, present := range required {
-		if !present {
+func sanitizePowerMonitorConditions(conditions []v1alpha1.Condition) []v1alpha1.Condition {
+	for _, t := range powerMonitorConditionTypes {
+		if findPowerMonitorCondition(conditions, t) == nil {
 			conditions = append(conditions, v1alpha1.Condition{
 				Type:   t,
 				Status: v1alpha1.ConditionFalse,
@@ -665,7 +969,7 @@ func (r PowerMonitorInternalReconciler) updatePowerMonitorReconciledStatus(ctx c
 	if recErr != nil {
 		reconciled.Status = v1alpha1.ConditionFalse
 		reconciled.Reason = v1alpha1.ReconcileError
-		reconciled.Message = recErr.Error()
+		reconciled.Message = errorMessage(reconciler.Errors(recErr)...)
 	}
 
 	return updatePowerMonitorCondition(pmi.Status.Conditions, reconciled, time)
@@ -708,10 +1012,14 @@ func (r PowerMonitorInternalReconciler) updatePowerMonitorAvailableStatus(ctx co
 	dset := appsv1.DaemonSet{}
 	key := types.NamespacedName{Name: pmi.DaemonsetName(), Namespace: pmi.Namespace()}
 	if err := r.Client.Get(ctx, key, &dset); err != nil {
+		if errors.IsNotFound(err) {
+			metrics.DeleteDaemonSet(key.Namespace, key.Name)
+		}
 		return updatePowerMonitorCondition(pmi.Status.Conditions, availablePowerMonitorConditionForGetError(err), time)
 	}
 
 	ds := dset.Status
+	metrics.SetDaemonSetPods(dset.Namespace, dset.Name, ds.DesiredNumberScheduled, ds.NumberReady)
 	pmi.Status.Kepler.NumberMisscheduled = ds.NumberMisscheduled
 	pmi.Status.Kepler.CurrentNumberScheduled = ds.CurrentNumberScheduled
 	pmi.Status.Kepler.DesiredNumberScheduled = ds.DesiredNumberScheduled
@@ -721,24 +1029,100 @@ func (r PowerMonitorInternalReconciler) updatePowerMonitorAvailableStatus(ctx co
 	pmi.Status.Kepler.NumberUnavailable = ds.NumberUnavailable
 
 	available := availablePowerMonitorCondition(&dset)
+	if available.Status != v1alpha1.ConditionTrue && dset.Spec.Selector != nil {
+		pods := corev1.PodList{}
+		if err := r.Client.List(ctx, &pods,
+			client.InNamespace(pmi.Namespace()),
+			client.MatchingLabels(dset.Spec.Selector.MatchLabels)); err == nil {
+			// NOTE: a known failure of the pods is reported in place of the daemonset status
+			if failure, ok := podFailureCondition(pmi, available, pods.Items); ok {
+				available = failure
+			}
+		}
+	}
+	available.ObservedGeneration = pmi.Generation
+
+	// NOTE: failure to reconcile is reported by the Degraded condition
+	updated := updatePowerMonitorCondition(pmi.Status.Conditions, available, time)
+	restartChanged := updatePowerMonitorRestartStatus(pmi, &dset, time)
+	return updated || restartChanged
+}
 
-	if recErr == nil {
-		available.ObservedGeneration = pmi.Generation
+// updatePowerMonitorHealthStatus updates the Progressing, Degraded, ConfigValid,
+// SecurityReady and MonitoringIntegrated conditions and returns true if any of
+// them changed
+func (r PowerMonitorInternalReconciler) updatePowerMonitorHealthStatus(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal, recErr error, time metav1.Time) bool {
+	var progressing v1alpha1.Condition
+	dset := appsv1.DaemonSet{}
+	key := types.NamespacedName{Name: pmi.DaemonsetName(), Namespace: pmi.Namespace()}
+	if err := r.Client.Get(ctx, key, &dset); err != nil {
+		progressing = progressingPowerMonitorConditionForGetError(err)
 	} else {
-		// failure to reconcile is a Degraded condition
-		available.Status = v1alpha1.ConditionDegraded
-
-		// Check if the error is specifically a SecretNotFoundError
-		if secretErr, ok := recErr.(*reconciler.SecretNotFoundError); ok {
-			available.Reason = v1alpha1.SecretNotFound
-			available.Message = secretErr.Error()
-		} else {
-			available.Reason = v1alpha1.ReconcileError
+		progressing = progressingPowerMonitorCondition(&dset)
+	}
+
+	conditions := []v1alpha1.Condition{
+		progressing,
+		degradedPowerMonitorCondition(recErr),
+		configValidPowerMonitorCondition(recErr),
+		securityReadyPowerMonitorCondition(ctx, r.Client, pmi, recErr),
+		monitoringIntegratedPowerMonitorCondition(ctx, r.Client, pmi, r.prometheusOperatorInstalled()),
+	}
+
+	changed := false
+	for _, c := range conditions {
+		c.ObservedGeneration = pmi.Generation
+		if updatePowerMonitorCondition(pmi.Status.Conditions, c, time) {
+			changed = true
 		}
 	}
+	return changed
+}
+
+// updatePowerMonitorCoverageStatus computes the nodes that aren't monitored by the
+// power-monitor daemonset and returns true if the coverage status or condition changed
+func (r PowerMonitorInternalReconciler) updatePowerMonitorCoverageStatus(ctx context.Context, pmi *v1alpha1.PowerMonitorInternal, time metav1.Time) bool {
+	dset := appsv1.DaemonSet{}
+	key := types.NamespacedName{Name: pmi.DaemonsetName(), Namespace: pmi.Namespace()}
+	if err := r.Client.Get(ctx, key, &dset); err != nil {
+		return updatePowerMonitorCondition(pmi.Status.Conditions, coverageConditionForError(pmi, err), time)
+	}
 
-	updated := updatePowerMonitorCondition(pmi.Status.Conditions, available, time)
-	return updated
+	nodes := corev1.NodeList{}
+	if err := r.Client.List(ctx, &nodes); err != nil {
+		return updatePowerMonitorCondition(pmi.Status.Conditions, coverageConditionForError(pmi, err), time)
+	}
+
+	if dset.Spec.Selector == nil {
+		err := fmt.Errorf("daemonset %s/%s has no selector", dset.Namespace, dset.Name)
+		return updatePowerMonitorCondition(pmi.Status.Conditions, coverageConditionForError(pmi, err), time)
+	}
+
+	pods := corev1.PodList{}
+	if err := r.Client.List(ctx, &pods,
+		client.InNamespace(pmi.Namespace()),
+		client.MatchingLabels(dset.Spec.Selector.MatchLabels)); err != nil {
+		return updatePowerMonitorCondition(pmi.Status.Conditions, coverageConditionForError(pmi, err), time)
+	}
+
+	cov := nodeCoverage(&dset, nodes.Items, pods.Items)
+	statusChanged := !equality.Semantic.DeepEqual(pmi.Status.Kepler.Coverage, &cov)
+	pmi.Status.Kepler.Coverage = &cov
+
+	coverage := coveragePowerMonitorCondition(cov, pmi.Spec.Kepler.Deployment.MinCoveragePercent)
+	coverage.ObservedGeneration = pmi.Generation
+	conditionChanged := updatePowerMonitorCondition(pmi.Status.Conditions, coverage, time)
+	return statusChanged || conditionChanged
+}
+
+func coverageConditionForError(pmi *v1alpha1.PowerMonitorInternal, err error) v1alpha1.Condition {
+	return v1alpha1.Condition{
+		Type:               v1alpha1.Coverage,
+		Status:             v1alpha1.ConditionUnknown,
+		ObservedGeneration: pmi.Generation,
+		Reason:             v1alpha1.CoverageError,
+		Message:            err.Error(),
+	}
 }
 
 func availablePowerMonitorConditionForGetError(err error) v1alpha1.Condition {
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	secv1 "github.com/openshift/api/security/v1"
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
+)
+
+// stepPrune deletes the objects of power-monitor-internal that are no longer desired
+const stepPrune = "prune"
+
+// pruneStep returns the step that prunes the objects created for pmi that
+// are no longer desired once all steps in deps have completed
+func (r PowerMonitorInternalReconciler) pruneStep(pmi *v1alpha1.PowerMonitorInternal, cluster k8s.Cluster, recorder record.EventRecorder, deps []string) reconciler.Step {
+	return reconciler.Step{
+		Name: stepPrune,
+		Reconciler: reconciler.Pruner{
+			Owner:    pmi,
+			Kinds:    prunableKinds(cluster, r.prometheusOperatorInstalled()),
+			Selector: pruneSelector(),
+			// NOTE: objects of a power-monitor-internal that no longer exists,
+			// e.g. after it is renamed, are pruned by any other
+			InstanceLabel: powermonitor.InstanceLabel,
+			Instances:     &v1alpha1.PowerMonitorInternalList{},
+			Desired:       desiredPowerMonitorObjects(pmi, cluster),
+			DryRun:        r.PruneDryRun,
+			History:       r.pruneHistory,
+			OnError:       reconciler.Continue,
+			Logger:        r.logger,
+			Recorder:      recorder,
+		},
+		DependsOn: deps,
+	}
+}
+
+// pruneSelector selects the objects created by the operator, including the
+// ones created before they were labelled with the instance; the kept config
+// revisions are pruned by the deployer as per the revision history limit
+func pruneSelector() labels.Selector {
+	selector := labels.SelectorFromSet(components.CommonLabels.ToMap())
+	notRevision, _ := labels.NewRequirement(powermonitor.ConfigRevisionLabel, selection.DoesNotExist, nil)
+	return selector.Add(*notRevision)
+}
+
+// prunableKinds returns the kinds of the objects created for a power-monitor-internal;
+// monitoring is true if the prometheus-operator CRDs are installed
+func prunableKinds(cluster k8s.Cluster, monitoring bool) []client.ObjectList {
+	kinds := []client.ObjectList{
+		&appsv1.DaemonSetList{},
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&corev1.ServiceList{},
+		&corev1.ServiceAccountList{},
+		&rbacv1.ClusterRoleList{},
+		&rbacv1.ClusterRoleBindingList{},
+	}
+	if monitoring {
+		kinds = append(kinds, &monv1.ServiceMonitorList{}, &monv1.PodMonitorList{}, &monv1.PrometheusRuleList{})
+	}
+	if cluster == k8s.OpenShift {
+		kinds = append(kinds, &secv1.SecurityContextConstraintsList{})
+	}
+	return kinds
+}
+
+// desiredPowerMonitorObjects returns the objects that the reconcile steps of
+// pmi create given its spec; any other object created for pmi is stale
+func desiredPowerMonitorObjects(pmi *v1alpha1.PowerMonitorInternal, cluster k8s.Cluster) []client.Object {
+	enableRBAC := rbacEnabled(pmi)
+	enableUWM := uwmEnabled(pmi)
+
+	cfm, _ := powermonitor.NewPowerMonitorConfigMap(components.Metadata, pmi)
+	objs := []client.Object{
+		powermonitor.NewPowerMonitorDaemonSet(components.Metadata, pmi),
+		cfm,
+		powermonitor.NewPowerMonitorService(pmi),
+		powermonitor.NewPowerMonitorServiceAccount(pmi),
+		powermonitor.NewPowerMonitorClusterRole(components.Metadata, pmi),
+		powermonitor.NewPowerMonitorClusterRoleBinding(components.Metadata, pmi),
+	}
+	// NOTE: keep in sync with the conditions of the reconcilers of these objects
+	if !enableRBAC || enableUWM {
+		if powermonitor.UsePodMonitor(pmi) {
+			objs = append(objs, powermonitor.NewPowerMonitorPodMonitor(components.Metadata, pmi))
+		} else {
+			objs = append(objs, powermonitor.NewPowerMonitorServiceMonitor(components.Metadata, pmi))
+		}
+	}
+	if powermonitor.HasPrometheusRules(pmi) {
+		objs = append(objs, powermonitor.NewPowerMonitorPrometheusRule(components.Metadata, pmi))
+	}
+	if enableRBAC {
+		secret, _ := powermonitor.NewPowerMonitorKubeRBACProxyConfig(components.Metadata, pmi)
+		objs = append(objs, secret)
+	}
+	if enableRBAC && enableUWM {
+		objs = append(objs,
+			powermonitor.NewPowerMonitorCABundleConfigMap(components.Metadata, pmi),
+			powermonitor.NewPowerMonitorUWMTokenSecret(components.Metadata, pmi, ""),
+		)
+	}
+	// NOTE: the canary objects are deleted by the canary rollout once it completes
+	if pmi.Spec.Kepler.Deployment.Rollout != nil {
+		objs = append(objs,
+			powermonitor.NewPowerMonitorCanaryDaemonSet(components.Metadata, pmi, nil, nil),
+			powermonitor.NewPowerMonitorCanaryConfigMap(components.Metadata, pmi, nil),
+		)
+	}
+	objs = append(objs, openshiftPowerMonitorClusterResources(pmi, cluster)...)
+	objs = append(objs, openshiftPowerMonitorNamespacedResources(pmi, cluster)...)
+	return objs
+}
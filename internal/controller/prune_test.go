@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	secv1 "github.com/openshift/api/security/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
+)
+
+func TestPruneStep(t *testing.T) {
+	scheme := testScheme()
+	require.NoError(t, secv1.AddToScheme(scheme))
+
+	dashboardPmi := func() *v1alpha1.PowerMonitorInternal {
+		pmi := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+		pmi.Spec.OpenShift.Enabled = true
+		pmi.Spec.OpenShift.Dashboard.Enabled = true
+		return pmi
+	}
+
+	// another power-monitor-internal whose objects must never be pruned
+	other := dashboardPmi()
+	other.Name = "other"
+	// a power-monitor-internal that was removed, e.g. renamed, whose objects are stale
+	removed := dashboardPmi()
+	removed.Name = "removed"
+
+	// legacy returns a service account created before objects were labelled
+	// with their instance, controlled by owner unless nil
+	legacy := func(name string, owner *v1alpha1.PowerMonitorInternal) *corev1.ServiceAccount {
+		sa := powermonitor.NewPowerMonitorServiceAccount(dashboardPmi())
+		sa.Name = name
+		delete(sa.Labels, powermonitor.InstanceLabel)
+		if owner != nil {
+			require.NoError(t, ctrlutil.SetControllerReference(owner, sa, scheme))
+		}
+		return sa
+	}
+
+	for _, tc := range []struct {
+		scenario string
+		dryRun   bool
+		pruned   bool
+		events   int
+	}{
+		{scenario: "stale objects are deleted", pruned: true, events: 4},
+		{scenario: "stale objects are only reported in dry-run", dryRun: true, events: 1},
+	} {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// objects of a power-monitor-internal with dashboards enabled
+			existing := desiredPowerMonitorObjects(dashboardPmi(), k8s.OpenShift)
+			existing = append(existing,
+				other,
+				powermonitor.NewPowerMonitorServiceAccount(other),
+				powermonitor.NewPowerMonitorServiceAccount(removed),
+				legacy("legacy", dashboardPmi()),
+				legacy("legacy-other", other),
+				legacy("legacy-unowned", nil),
+			)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing...).Build()
+
+			// dashboards are disabled since
+			pmi := dashboardPmi()
+			pmi.Spec.OpenShift.Dashboard.Enabled = false
+
+			recorder := record.NewFakeRecorder(10)
+			r := PowerMonitorInternalReconciler{PruneDryRun: tc.dryRun, logger: logr.Discard()}
+			step := r.pruneStep(pmi, k8s.OpenShift, recorder, nil)
+			result := step.Reconciler.Reconcile(context.TODO(), c, scheme)
+			require.NoError(t, result.Error)
+			assert.Equal(t, reconciler.Continue, result.Action)
+
+			for _, name := range []string{powermonitor.OverviewDashboardName, powermonitor.NamespaceInfoDashboardName} {
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: powermonitor.DashboardNs, Name: name}, &corev1.ConfigMap{})
+				assert.Equal(t, tc.pruned, apierrors.IsNotFound(err), "dashboard %s", name)
+			}
+			removedSA := client.ObjectKeyFromObject(powermonitor.NewPowerMonitorServiceAccount(removed))
+			err := c.Get(context.TODO(), removedSA, &corev1.ServiceAccount{})
+			assert.Equal(t, tc.pruned, apierrors.IsNotFound(err), "objects of removed instances are stale")
+			err = c.Get(context.TODO(), client.ObjectKeyFromObject(legacy("legacy", nil)), &corev1.ServiceAccount{})
+			assert.Equal(t, tc.pruned, apierrors.IsNotFound(err), "objects without instance label are stale if controlled by pmi")
+			for _, name := range []string{"legacy-other", "legacy-unowned"} {
+				err := c.Get(context.TODO(), client.ObjectKeyFromObject(legacy(name, nil)), &corev1.ServiceAccount{})
+				assert.NoError(t, err, "%s must be kept", name)
+			}
+			assert.Len(t, recorder.Events, tc.events)
+
+			// desired objects and the objects of other instances are kept
+			for _, obj := range append(desiredPowerMonitorObjects(pmi, k8s.OpenShift), powermonitor.NewPowerMonitorServiceAccount(other)) {
+				key := client.ObjectKeyFromObject(obj)
+				assert.NoError(t, c.Get(context.TODO(), key, obj.DeepCopyObject().(client.Object)), "%s must be kept", key)
+			}
+		})
+	}
+}
+
+func TestPruneStepDryRunReportsChanges(t *testing.T) {
+	scheme := testScheme()
+	pmi := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+	stale := powermonitor.NewPowerMonitorServiceAccount(pmi)
+	stale.Name = "stale"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stale).Build()
+
+	recorder := record.NewFakeRecorder(10)
+	r := PowerMonitorInternalReconciler{PruneDryRun: true, logger: logr.Discard(), pruneHistory: &reconciler.PruneHistory{}}
+	reconcile := func() {
+		step := r.pruneStep(pmi, k8s.Kubernetes, recorder, nil)
+		require.NoError(t, step.Reconciler.Reconcile(context.TODO(), c, scheme).Error)
+	}
+
+	reconcile()
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "1 stale objects would be pruned: ServiceAccount/power-monitor/stale")
+
+	// the same stale objects are not reported again
+	reconcile()
+	assert.Empty(t, recorder.Events)
+
+	// objects are not listed again until the desired objects change
+	other := powermonitor.NewPowerMonitorServiceAccount(pmi)
+	other.Name = "other-stale"
+	require.NoError(t, c.Create(context.TODO(), other))
+	reconcile()
+	assert.Empty(t, recorder.Events)
+
+	pmi.Spec.Kepler.Deployment.Rollout = &v1alpha1.RolloutPolicy{}
+	reconcile()
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "2 stale objects would be pruned")
+}
+
+func TestDesiredPowerMonitorObjects(t *testing.T) {
+	names := func(objs []client.Object) []string {
+		var names []string
+		for _, obj := range objs {
+			names = append(names, obj.GetName())
+		}
+		return names
+	}
+
+	tt := []struct {
+		scenario string
+		pmi      *v1alpha1.PowerMonitorInternal
+		present  []string
+		absent   []string
+	}{
+		{
+			scenario: "no security",
+			pmi:      testPowerMonitorInternal(v1alpha1.SecurityModeNone),
+			present:  []string{"power-monitor"},
+			absent: []string{
+				"power-monitor-canary",
+				powermonitor.SecretKubeRBACProxyConfigName,
+				powermonitor.SecretUWMTokenName,
+				powermonitor.PowerMonitorCertsCABundleName,
+			},
+		},
+		{
+			scenario: "rbac without uwm",
+			pmi:      testPowerMonitorInternal(v1alpha1.SecurityModeRBAC),
+			present:  []string{powermonitor.SecretKubeRBACProxyConfigName},
+			absent:   []string{powermonitor.SecretUWMTokenName, powermonitor.PowerMonitorCertsCABundleName},
+		},
+		{
+			scenario: "rbac with uwm",
+			pmi: testPowerMonitorInternal(v1alpha1.SecurityModeRBAC,
+				powermonitor.UWMNamespace+":"+powermonitor.UWMServiceAccountName),
+			present: []string{
+				powermonitor.SecretKubeRBACProxyConfigName,
+				powermonitor.SecretUWMTokenName,
+				powermonitor.PowerMonitorCertsCABundleName,
+			},
+		},
+		{
+			scenario: "canary rollout",
+			pmi: func() *v1alpha1.PowerMonitorInternal {
+				pmi := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+				pmi.Spec.Kepler.Deployment.Rollout = &v1alpha1.RolloutPolicy{}
+				return pmi
+			}(),
+			present: []string{"power-monitor-canary"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			objs := desiredPowerMonitorObjects(tc.pmi, k8s.Kubernetes)
+			for _, obj := range objs {
+				assert.Equal(t, tc.pmi.Name, obj.GetLabels()[powermonitor.InstanceLabel],
+					"%s must be labelled with its instance", obj.GetName())
+			}
+			for _, name := range tc.present {
+				assert.Contains(t, names(objs), name)
+			}
+			for _, name := range tc.absent {
+				assert.NotContains(t, names(objs), name)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package controller
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -29,7 +31,38 @@ func newUpdaterWithOwner(owner metav1.Object) reconcileFn {
 	}
 }
 
+// newDeleter returns a reconcileFn that deletes the resource and takes the
+// action onError if it could not be deleted
+func newDeleter(onError reconciler.Action) reconcileFn {
+	return func(obj client.Object) reconciler.Reconciler {
+		return &reconciler.Deleter{Resource: obj, OnError: onError}
+	}
+}
+
 // deleteResource is a resourceFn that deletes resources
 func deleteResource(obj client.Object) reconciler.Reconciler {
 	return &reconciler.Deleter{Resource: obj}
 }
+
+// resourceSteps returns a step per resource that runs fn once the steps in
+// deps complete; steps are named after the kind and name of the resource
+func resourceSteps(fn reconcileFn, deps []string, resources ...client.Object) []reconciler.Step {
+	steps := []reconciler.Step{}
+	for _, res := range resources {
+		steps = append(steps, reconciler.Step{
+			Name:       strings.ToLower(res.GetObjectKind().GroupVersionKind().Kind) + "/" + res.GetName(),
+			Reconciler: fn(res),
+			DependsOn:  deps,
+		})
+	}
+	return steps
+}
+
+// stepNames returns the names of steps
+func stepNames(steps []reconciler.Step) []string {
+	names := make([]string, 0, len(steps))
+	for _, s := range steps {
+		names = append(names, s.Name)
+	}
+	return names
+}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
+)
+
+// dependsOn returns true if the step named from depends on the step named to,
+// directly or through other steps
+func dependsOn(steps []reconciler.Step, from, to string) bool {
+	i := slices.IndexFunc(steps, func(s reconciler.Step) bool { return s.Name == from })
+	if i < 0 {
+		return false
+	}
+	for _, dep := range steps[i].DependsOn {
+		if dep == to || dependsOn(steps, dep, to) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReconcilersForPowerMonitor(t *testing.T) {
+	openshiftPmi := func() *v1alpha1.PowerMonitorInternal {
+		pmi := testPowerMonitorInternal(v1alpha1.SecurityModeRBAC,
+			fmt.Sprintf("%s:%s", powermonitor.UWMNamespace, powermonitor.UWMServiceAccountName))
+		pmi.Spec.OpenShift.Enabled = true
+		pmi.Spec.OpenShift.Dashboard.Enabled = true
+		return pmi
+	}
+	deleted := func(pmi *v1alpha1.PowerMonitorInternal) *v1alpha1.PowerMonitorInternal {
+		now := metav1.Now()
+		pmi.DeletionTimestamp = &now
+		return pmi
+	}
+
+	tt := []struct {
+		scenario string
+		cluster  k8s.Cluster
+		// noPromOperator drops the prometheus-operator capability
+		noPromOperator bool
+		pmi            *v1alpha1.PowerMonitorInternal
+		// before lists pairs of steps where the first must complete before the second
+		before [][2]string
+		// absent lists steps that must not run
+		absent []string
+	}{
+		{
+			scenario: "kubernetes",
+			cluster:  k8s.Kubernetes,
+			pmi:      testPowerMonitorInternal(v1alpha1.SecurityModeNone),
+			before: [][2]string{
+				{stepClusterRole, stepClusterRoleBinding},
+				{stepNamespace, stepSecretMounter},
+				{stepSecretMounter, stepDeployer},
+				{stepDeployer, stepKubeRBACProxyObjects},
+				{stepKubeRBACProxyConfig, stepKubeRBACProxyObjects},
+				{stepUWMSecretToken, stepKubeRBACProxyObjects},
+				{stepKubeRBACProxyObjects, stepDaemonSet},
+				{stepClusterRoleBinding, stepDaemonSet},
+				{stepServiceAccount, stepDaemonSet},
+				{stepDaemonSet, stepServiceMonitor},
+				{stepServiceMonitor, stepPrune},
+				{stepNamespace, stepCarbonRules},
+				{stepCarbonRules, stepPrometheusRule},
+				{stepPrometheusRule, stepPrune},
+				{stepService, stepPrune},
+				{stepPrune, stepFinalizer},
+			},
+			absent: []string{stepCanary},
+		},
+		{
+			scenario: "canary rollout",
+			cluster:  k8s.Kubernetes,
+			pmi: func() *v1alpha1.PowerMonitorInternal {
+				pmi := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+				pmi.Spec.Kepler.Deployment.Rollout = &v1alpha1.RolloutPolicy{}
+				return pmi
+			}(),
+			before: [][2]string{
+				{stepDeployer, stepCanary},
+				{stepKubeRBACProxyObjects, stepCanary},
+				{stepServiceAccount, stepCanary},
+				{stepCanary, stepDaemonSet},
+				{stepCanary, stepPrune},
+			},
+		},
+		{
+			scenario: "openshift",
+			cluster:  k8s.OpenShift,
+			pmi:      openshiftPmi(),
+			before: [][2]string{
+				{"securitycontextconstraints/power-monitor", stepDaemonSet},
+				{stepDaemonSet, stepServiceMonitor},
+				{"configmap/" + powermonitor.OverviewDashboardName, stepFinalizer},
+			},
+		},
+		{
+			scenario:       "kubernetes without prometheus-operator",
+			cluster:        k8s.Kubernetes,
+			noPromOperator: true,
+			pmi:            testPowerMonitorInternal(v1alpha1.SecurityModeNone),
+			before: [][2]string{
+				{stepDaemonSet, stepPrune},
+			},
+			absent: []string{stepServiceMonitor, stepCarbonRules, stepPrometheusRule},
+		},
+		{
+			scenario: "cleanup",
+			cluster:  k8s.OpenShift,
+			pmi:      deleted(openshiftPmi()),
+			before: [][2]string{
+				{stepClusterRoleBinding, stepClusterRole},
+				{stepClusterRole, stepFinalizer},
+				{stepNamespace, stepFinalizer},
+			},
+			absent: []string{stepPrune},
+		},
+		{
+			scenario: "cleanup retaining the namespace",
+			cluster:  k8s.OpenShift,
+			pmi: func() *v1alpha1.PowerMonitorInternal {
+				pmi := deleted(openshiftPmi())
+				pmi.Spec.DeletionPolicy = v1alpha1.DeletionPolicyRetainNamespace
+				return pmi
+			}(),
+			before: [][2]string{
+				{stepClusterRole, stepFinalizer},
+			},
+			absent: []string{stepNamespace},
+		},
+		{
+			scenario: "cleanup retaining all objects",
+			cluster:  k8s.OpenShift,
+			pmi: func() *v1alpha1.PowerMonitorInternal {
+				pmi := deleted(openshiftPmi())
+				pmi.Spec.DeletionPolicy = v1alpha1.DeletionPolicyRetain
+				return pmi
+			}(),
+			absent: []string{stepNamespace, stepClusterRole, stepClusterRoleBinding, "configmap/" + powermonitor.OverviewDashboardName},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			cluster := Config.Cluster
+			Config.Cluster = tc.cluster
+			t.Cleanup(func() { Config.Cluster = cluster })
+			if tc.noPromOperator {
+				caps := Config.Capabilities
+				Config.Capabilities.PrometheusOperator = false
+				t.Cleanup(func() { Config.Capabilities = caps })
+			}
+
+			r := PowerMonitorInternalReconciler{logger: logr.Discard()}
+			steps, err := r.reconcilersForPowerMonitor(tc.pmi, newRollout(tc.pmi, time.Now()), nil)
+			require.NoError(t, err)
+			require.NoError(t, reconciler.ValidateSteps(steps))
+
+			for _, b := range tc.before {
+				assert.True(t, dependsOn(steps, b[1], b[0]), "%s must run after %s", b[1], b[0])
+			}
+			for _, name := range tc.absent {
+				assert.False(t, slices.ContainsFunc(steps, func(s reconciler.Step) bool { return s.Name == name }), "%s must not run", name)
+			}
+			// every other step completes before the finalizer
+			for _, s := range steps[:len(steps)-1] {
+				assert.True(t, dependsOn(steps, stepFinalizer, s.Name), "finalizer must run after %s", s.Name)
+			}
+		})
+	}
+}
+
+func TestPowerMonitorReconcilersDeletionPolicy(t *testing.T) {
+	now := metav1.Now()
+
+	for _, tc := range []struct {
+		policy      v1alpha1.DeletionPolicy
+		propagation metav1.DeletionPropagation
+	}{
+		{policy: "", propagation: ""},
+		{policy: v1alpha1.DeletionPolicyDelete, propagation: ""},
+		{policy: v1alpha1.DeletionPolicyRetainNamespace, propagation: ""},
+		{policy: v1alpha1.DeletionPolicyRetain, propagation: metav1.DeletePropagationOrphan},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			pm := &v1alpha1.PowerMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "power-monitor", DeletionTimestamp: &now},
+				Spec:       v1alpha1.PowerMonitorSpec{DeletionPolicy: tc.policy},
+			}
+			rs := PowerMonitorReconciler{logger: logr.Discard()}.reconcilersForPowerMonitor(pm)
+			require.NotEmpty(t, rs)
+
+			deleter, ok := rs[0].(*reconciler.Deleter)
+			require.True(t, ok, "power-monitor-internal must be deleted first")
+			assert.Equal(t, reconciler.Requeue, deleter.OnError)
+			assert.Equal(t, tc.propagation, deleter.PropagationPolicy)
+			// NOTE: the worker must not block until power-monitor-internal is cleaned up
+			assert.Equal(t, deletionRequeueAfter, deleter.RequeueAfter)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+)
+
+// updatePowerMonitorRestartStatus updates the restart status of pmi given the
+// daemonset of pmi; returns true if it changed
+func updatePowerMonitorRestartStatus(pmi *v1alpha1.PowerMonitorInternal, ds *appsv1.DaemonSet, now metav1.Time) bool {
+	status := restartStatus(pmi.Status.Kepler.Restart, pmi.Spec.Kepler.Deployment.RestartedAt, ds, now)
+	if equality.Semantic.DeepEqual(status, pmi.Status.Kepler.Restart) {
+		return false
+	}
+	pmi.Status.Kepler.Restart = status
+	return true
+}
+
+// restartStatus returns the status of the restart requested at requestedAt
+// given the current one; the restart is completed once ds has rolled it out
+// to every node
+func restartStatus(current *v1alpha1.RestartStatus, requestedAt *metav1.Time, ds *appsv1.DaemonSet, now metav1.Time) *v1alpha1.RestartStatus {
+	if requestedAt == nil {
+		return nil
+	}
+
+	status := &v1alpha1.RestartStatus{RequestedAt: *requestedAt}
+	if current != nil && current.RequestedAt.Equal(requestedAt) {
+		status = current.DeepCopy()
+	}
+	if status.CompletedAt == nil && restartRolledOut(ds, requestedAt) {
+		status.CompletedAt = &now
+	}
+	return status
+}
+
+// restartRolledOut returns true if every pod of ds runs the pod template
+// restarted at requestedAt and is available
+func restartRolledOut(ds *appsv1.DaemonSet, requestedAt *metav1.Time) bool {
+	if ds.Spec.Template.Annotations[powermonitor.RestartedAtAnnotation] != powermonitor.RestartedAt(requestedAt) {
+		return false
+	}
+	s := ds.Status
+	return s.ObservedGeneration >= ds.Generation &&
+		s.UpdatedNumberScheduled == s.DesiredNumberScheduled &&
+		s.NumberAvailable == s.DesiredNumberScheduled
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+)
+
+func TestRestartStatus(t *testing.T) {
+	requestedAt := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	earlier := metav1.NewTime(requestedAt.Add(-24 * time.Hour))
+	now := metav1.Now()
+
+	daemonSet := func(restartedAt *metav1.Time, updated, available int32) *appsv1.DaemonSet {
+		ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+		if restartedAt != nil {
+			ds.Spec.Template.Annotations = map[string]string{
+				powermonitor.RestartedAtAnnotation: powermonitor.RestartedAt(restartedAt),
+			}
+		}
+		ds.Status = appsv1.DaemonSetStatus{
+			ObservedGeneration:     2,
+			DesiredNumberScheduled: 3,
+			UpdatedNumberScheduled: updated,
+			NumberAvailable:        available,
+		}
+		return ds
+	}
+
+	t.Run("no restart requested", func(t *testing.T) {
+		assert.Nil(t, restartStatus(nil, nil, daemonSet(nil, 3, 3), now))
+	})
+
+	t.Run("restart in progress", func(t *testing.T) {
+		status := restartStatus(nil, &requestedAt, daemonSet(&requestedAt, 1, 2), now)
+		require.NotNil(t, status)
+		assert.Equal(t, requestedAt, status.RequestedAt)
+		assert.Nil(t, status.CompletedAt)
+	})
+
+	t.Run("restart not yet applied to the daemonset", func(t *testing.T) {
+		status := restartStatus(nil, &requestedAt, daemonSet(&earlier, 3, 3), now)
+		require.NotNil(t, status)
+		assert.Nil(t, status.CompletedAt)
+	})
+
+	t.Run("daemonset not yet observed", func(t *testing.T) {
+		ds := daemonSet(&requestedAt, 3, 3)
+		ds.Generation = 3
+		status := restartStatus(nil, &requestedAt, ds, now)
+		require.NotNil(t, status)
+		assert.Nil(t, status.CompletedAt)
+	})
+
+	t.Run("restart rolled out", func(t *testing.T) {
+		status := restartStatus(nil, &requestedAt, daemonSet(&requestedAt, 3, 3), now)
+		require.NotNil(t, status)
+		require.NotNil(t, status.CompletedAt)
+		assert.Equal(t, now, *status.CompletedAt)
+	})
+
+	t.Run("completed restart keeps its completion time", func(t *testing.T) {
+		current := &v1alpha1.RestartStatus{RequestedAt: requestedAt, CompletedAt: &earlier}
+		status := restartStatus(current, &requestedAt, daemonSet(&requestedAt, 1, 1), now)
+		require.NotNil(t, status.CompletedAt)
+		assert.Equal(t, earlier, *status.CompletedAt)
+	})
+
+	t.Run("new restart resets the completion time", func(t *testing.T) {
+		current := &v1alpha1.RestartStatus{RequestedAt: earlier, CompletedAt: &earlier}
+		status := restartStatus(current, &requestedAt, daemonSet(&earlier, 3, 3), now)
+		require.NotNil(t, status)
+		assert.Equal(t, requestedAt, status.RequestedAt)
+		assert.Nil(t, status.CompletedAt)
+	})
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+)
+
+// newRollout returns the rollout of a reconcile of pmi at now; nil if pmi
+// has no rollout policy
+func newRollout(pmi *v1alpha1.PowerMonitorInternal, now time.Time) *reconciler.Rollout {
+	policy := pmi.Spec.Kepler.Deployment.Rollout
+	if policy == nil {
+		return nil
+	}
+	return &reconciler.Rollout{
+		Policy:  *policy,
+		Current: pmi.Status.Kepler.Rollout,
+		Now:     now,
+	}
+}
+
+// updatePowerMonitorRolloutStatus updates the rollout status of pmi with the
+// status of rollout; returns true if it changed
+func updatePowerMonitorRolloutStatus(pmi *v1alpha1.PowerMonitorInternal, rollout *reconciler.Rollout) bool {
+	var status *v1alpha1.RolloutStatus
+	if rollout != nil {
+		status = rollout.Status()
+		if status == nil {
+			// the config could not be rendered; the last status is kept
+			return false
+		}
+	}
+	if equality.Semantic.DeepEqual(status, pmi.Status.Kepler.Rollout) {
+		return false
+	}
+	pmi.Status.Kepler.Rollout = status
+	return true
+}
+
+// rolloutRequeueAfter returns the delay before the bake time of the canary of
+// rollout ends; 0 if no canary is running
+func rolloutRequeueAfter(rollout *reconciler.Rollout, now time.Time) time.Duration {
+	if rollout == nil {
+		return 0
+	}
+	return canaryRequeueAfter(rollout.Status(), rollout.Policy, now)
+}
+
+// canaryRequeueAfter returns the delay before the bake time of the canary of
+// status ends; 0 if no canary is running or the bake time ended
+func canaryRequeueAfter(status *v1alpha1.RolloutStatus, policy v1alpha1.RolloutPolicy, now time.Time) time.Duration {
+	if status == nil || status.Phase != v1alpha1.RolloutCanary || status.StartTime == nil {
+		return 0
+	}
+	// NOTE: once the bake time ended, the canary is promoted as soon as its
+	// pods are ready, which triggers a reconcile
+	return max(status.StartTime.Add(reconciler.CanaryBakeTime(policy)).Sub(now), 0)
+}
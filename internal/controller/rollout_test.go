@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+func TestRolloutStatus(t *testing.T) {
+	now := time.Now()
+	promoted := &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutPromoted, Revision: "a", PromotedRevision: "a"}
+
+	t.Run("no rollout policy", func(t *testing.T) {
+		pmi := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+		pmi.Status.Kepler.Rollout = promoted
+		assert.Nil(t, newRollout(pmi, now))
+		assert.True(t, updatePowerMonitorRolloutStatus(pmi, nil), "status of a removed policy is cleared")
+		assert.Nil(t, pmi.Status.Kepler.Rollout)
+	})
+
+	t.Run("status is kept if the config is not rendered", func(t *testing.T) {
+		pmi := testPowerMonitorInternal(v1alpha1.SecurityModeNone)
+		pmi.Spec.Kepler.Deployment.Rollout = &v1alpha1.RolloutPolicy{}
+		pmi.Status.Kepler.Rollout = promoted
+		rollout := newRollout(pmi, now)
+		assert.Equal(t, promoted, rollout.Current)
+		assert.False(t, updatePowerMonitorRolloutStatus(pmi, rollout))
+		assert.Equal(t, promoted, pmi.Status.Kepler.Rollout)
+		assert.Zero(t, rolloutRequeueAfter(rollout, now))
+	})
+}
+
+func TestCanaryRequeueAfter(t *testing.T) {
+	now := time.Now()
+	start := metav1.NewTime(now.Add(-4 * time.Minute))
+	policy := v1alpha1.RolloutPolicy{Canary: v1alpha1.CanaryPolicy{BakeTime: &metav1.Duration{Duration: 5 * time.Minute}}}
+	canary := &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutCanary, Revision: "b", PromotedRevision: "a", StartTime: &start}
+
+	assert.Zero(t, canaryRequeueAfter(nil, policy, now))
+	assert.Zero(t, canaryRequeueAfter(&v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutAborted, StartTime: &start}, policy, now))
+	assert.Equal(t, time.Minute, canaryRequeueAfter(canary, policy, now))
+	assert.Zero(t, canaryRequeueAfter(canary, policy, now.Add(2*time.Minute)), "bake time ended")
+	assert.Equal(t, 6*time.Minute, canaryRequeueAfter(canary, v1alpha1.RolloutPolicy{}, now), "default bake time")
+}
@@ -13,11 +13,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/internal/metrics"
 	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
 	"github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	corev1 "k8s.io/api/core/v1"
@@ -27,8 +31,9 @@ import (
 
 type TokenExpiryReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	logger logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	logger   logr.Logger
 }
 
 // RBAC for TokenExpirationReconciler
@@ -106,6 +111,7 @@ func (r *TokenExpiryReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			r.logger.Info("secret not found, continue without error")
+			metrics.DeleteUWMTokenExpiry(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		r.logger.Error(err, "failed to retrieve secret")
@@ -114,6 +120,8 @@ func (r *TokenExpiryReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if !r.hasExpirationAnnotation(secret) {
 		r.logger.Info("prometheus-user-workload-token does not have expiration annotation, deleting it")
+		r.recordTokenExpired(ctx, secret, "Token secret %s/%s has no expiration; deleting it so that it is rotated",
+			secret.Namespace, secret.Name)
 		return r.deleteResources(ctx, secret)
 	}
 
@@ -125,15 +133,37 @@ func (r *TokenExpiryReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if expired {
 		r.logger.Info("secret has expired, reconciling", "expiration-time", expirationTime)
+		r.recordTokenExpired(ctx, secret, "Token secret %s/%s expires at %s; deleting it so that it is rotated",
+			secret.Namespace, secret.Name, expirationTime.Format(time.RFC3339))
+		metrics.DeleteUWMTokenExpiry(secret.Namespace, secret.Name)
 		return r.deleteResources(ctx, secret)
 	}
 
+	metrics.SetUWMTokenExpiry(secret.Namespace, secret.Name, expirationTime)
 	timeUntilExpiration := time.Until(expirationTime)
 	r.logger.Info("secret not expired yet, requeuing", "expiration-time", expirationTime, "time-until-expiration", timeUntilExpiration)
 
 	return ctrl.Result{RequeueAfter: Config.TokenRefreshInterval}, nil
 }
 
+// recordTokenExpired emits an event on the PowerMonitorInternal owning the token secret
+func (r *TokenExpiryReconciler) recordTokenExpired(ctx context.Context, secret *corev1.Secret, msgFmt string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	owner := metav1.GetControllerOf(secret)
+	if owner == nil || owner.Kind != "PowerMonitorInternal" {
+		return
+	}
+	pmi := &v1alpha1.PowerMonitorInternal{}
+	if err := r.Get(ctx, client.ObjectKey{Name: owner.Name}, pmi); err != nil {
+		r.logger.V(3).Info("failed to get owner of token secret; skipping event", "owner", owner.Name, "error", err)
+		return
+	}
+	recorder := eventRecorderForPowerMonitorInternal(ctx, r.Client, r.Recorder, pmi)
+	recorder.Eventf(pmi, corev1.EventTypeNormal, reconciler.EventTokenExpired, msgFmt, args...)
+}
+
 // isSecretExpired checks if the secret has expired according to the expiration annotation
 func (r *TokenExpiryReconciler) isSecretExpired(secret *corev1.Secret) (bool, time.Time, error) {
 	expirationTime, err := powermonitor.GetExpirationFromAnnotation(&secret.ObjectMeta, powermonitor.SecretTokenExpirationAnnotation)
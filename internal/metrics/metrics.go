@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics defines the prometheus metrics exported by the operator
+// using the controller-runtime metrics registry
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+const namespace = "kepler_operator"
+
+var (
+	// ReconcilerDuration is the time taken by each sub-reconciler run by reconciler.Runner
+	ReconcilerDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconciler_duration_seconds",
+		Help:      "Time taken by a sub-reconciler to reconcile",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"reconciler"})
+
+	// ReconcilerErrors is the number of errors returned by each sub-reconciler
+	ReconcilerErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconciler_errors_total",
+		Help:      "Number of errors returned by a sub-reconciler",
+	}, []string{"reconciler"})
+
+	// PowerMonitorCondition is 1 for the current status of each condition of a
+	// power-monitor and 0 for the others
+	PowerMonitorCondition = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "powermonitor_condition",
+		Help:      "Status of the conditions of a power-monitor; 1 for the current status of the condition and 0 otherwise",
+	}, []string{"name", "type", "status"})
+
+	// UWMTokenExpiry is the time at which the user workload monitoring token expires
+	UWMTokenExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "uwm_token_expiry_timestamp_seconds",
+		Help:      "Unix time at which the token used by user workload monitoring to scrape kepler expires",
+	}, []string{"namespace", "name"})
+
+	// ConfigFallbacks is the number of times the default kepler config was
+	// deployed since the config from additionalConfigMaps was invalid
+	ConfigFallbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "config_fallback_total",
+		Help:      "Number of times the default kepler config was deployed since the user provided config was invalid",
+	}, []string{"name"})
+
+	// DaemonSetDesired is the number of nodes that should run kepler
+	DaemonSetDesired = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "daemonset_desired_pods",
+		Help:      "Number of nodes that should run the pods of a daemonset managed by the operator",
+	}, []string{"namespace", "name"})
+
+	// DaemonSetReady is the number of nodes running a ready kepler pod
+	DaemonSetReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "daemonset_ready_pods",
+		Help:      "Number of nodes running a ready pod of a daemonset managed by the operator",
+	}, []string{"namespace", "name"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		ReconcilerDuration,
+		ReconcilerErrors,
+		PowerMonitorCondition,
+		UWMTokenExpiry,
+		ConfigFallbacks,
+		DaemonSetDesired,
+		DaemonSetReady,
+	)
+}
+
+// ReconcilerName returns the name of the reconciler used as the value of the
+// reconciler label; e.g. reconciler.Updater for *reconciler.Updater
+func ReconcilerName(r any) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", r), "*")
+}
+
+// ObserveReconcile records the duration of a sub-reconciler run and counts the error if any
+func ObserveReconcile(name string, duration time.Duration, err error) {
+	ReconcilerDuration.WithLabelValues(name).Observe(duration.Seconds())
+	if err != nil {
+		ReconcilerErrors.WithLabelValues(name).Inc()
+	}
+}
+
+// conditionStatuses lists all possible statuses of a condition
+var conditionStatuses = []v1alpha1.ConditionStatus{
+	v1alpha1.ConditionTrue,
+	v1alpha1.ConditionFalse,
+	v1alpha1.ConditionUnknown,
+}
+
+// SetPowerMonitorConditions records the status of the conditions of the power-monitor named name
+func SetPowerMonitorConditions(name string, conditions []v1alpha1.Condition) {
+	for _, c := range conditions {
+		for _, s := range conditionStatuses {
+			value := 0.0
+			if c.Status == s {
+				value = 1
+			}
+			PowerMonitorCondition.WithLabelValues(name, string(c.Type), string(s)).Set(value)
+		}
+	}
+}
+
+// SetDaemonSetPods records the desired and ready number of pods of a daemonset
+func SetDaemonSetPods(ns, name string, desired, ready int32) {
+	DaemonSetDesired.WithLabelValues(ns, name).Set(float64(desired))
+	DaemonSetReady.WithLabelValues(ns, name).Set(float64(ready))
+}
+
+// DeletePowerMonitor removes the metrics of a power-monitor that no longer exists
+func DeletePowerMonitor(name string) {
+	PowerMonitorCondition.DeletePartialMatch(prometheus.Labels{"name": name})
+	ConfigFallbacks.DeleteLabelValues(name)
+}
+
+// DeleteDaemonSet removes the metrics of a daemonset that no longer exists
+func DeleteDaemonSet(ns, name string) {
+	DaemonSetDesired.DeleteLabelValues(ns, name)
+	DaemonSetReady.DeleteLabelValues(ns, name)
+}
+
+// SetUWMTokenExpiry records the time at which the token secret ns/name expires
+func SetUWMTokenExpiry(ns, name string, expiry time.Time) {
+	UWMTokenExpiry.WithLabelValues(ns, name).Set(float64(expiry.Unix()))
+}
+
+// DeleteUWMTokenExpiry removes the expiry of a token secret that no longer exists
+func DeleteUWMTokenExpiry(ns, name string) {
+	UWMTokenExpiry.DeleteLabelValues(ns, name)
+}
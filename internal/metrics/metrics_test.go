@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+)
+
+// gaugeValue returns the value of a gauge or counter
+func gaugeValue(t *testing.T, c prometheus.Collector) float64 {
+	t.Helper()
+	m := &dto.Metric{}
+	switch v := c.(type) {
+	case prometheus.Gauge:
+		require.NoError(t, v.Write(m))
+		return m.GetGauge().GetValue()
+	case prometheus.Counter:
+		require.NoError(t, v.Write(m))
+		return m.GetCounter().GetValue()
+	}
+	t.Fatalf("unsupported collector %T", c)
+	return 0
+}
+
+type fakeReconciler struct{}
+
+func TestReconcilerName(t *testing.T) {
+	assert.Equal(t, "metrics.fakeReconciler", ReconcilerName(fakeReconciler{}))
+	assert.Equal(t, "metrics.fakeReconciler", ReconcilerName(&fakeReconciler{}))
+}
+
+func TestObserveReconcile(t *testing.T) {
+	name := "test.ObserveReconcile"
+	ObserveReconcile(name, time.Second, nil)
+	ObserveReconcile(name, time.Second, errors.New("fail"))
+
+	assert.Equal(t, 1.0, gaugeValue(t, ReconcilerErrors.WithLabelValues(name)))
+
+	m := &dto.Metric{}
+	require.NoError(t, ReconcilerDuration.WithLabelValues(name).(prometheus.Histogram).Write(m))
+	assert.Equal(t, uint64(2), m.GetHistogram().GetSampleCount())
+	assert.Equal(t, 2.0, m.GetHistogram().GetSampleSum())
+}
+
+func TestSetPowerMonitorConditions(t *testing.T) {
+	name := "test-conditions"
+	SetPowerMonitorConditions(name, []v1alpha1.Condition{
+		{Type: v1alpha1.Reconciled, Status: v1alpha1.ConditionTrue},
+		{Type: v1alpha1.Available, Status: v1alpha1.ConditionUnknown},
+	})
+
+	tt := []struct {
+		condition v1alpha1.ConditionType
+		status    v1alpha1.ConditionStatus
+		expected  float64
+	}{
+		{v1alpha1.Reconciled, v1alpha1.ConditionTrue, 1},
+		{v1alpha1.Reconciled, v1alpha1.ConditionFalse, 0},
+		{v1alpha1.Reconciled, v1alpha1.ConditionUnknown, 0},
+		{v1alpha1.Available, v1alpha1.ConditionTrue, 0},
+		{v1alpha1.Available, v1alpha1.ConditionFalse, 0},
+		{v1alpha1.Available, v1alpha1.ConditionUnknown, 1},
+	}
+	for _, tc := range tt {
+		g := PowerMonitorCondition.WithLabelValues(name, string(tc.condition), string(tc.status))
+		assert.Equal(t, tc.expected, gaugeValue(t, g), "%s=%s", tc.condition, tc.status)
+	}
+
+	// status transitions are reflected
+	SetPowerMonitorConditions(name, []v1alpha1.Condition{
+		{Type: v1alpha1.Reconciled, Status: v1alpha1.ConditionFalse},
+	})
+	assert.Equal(t, 0.0, gaugeValue(t, PowerMonitorCondition.WithLabelValues(name, "Reconciled", "True")))
+	assert.Equal(t, 1.0, gaugeValue(t, PowerMonitorCondition.WithLabelValues(name, "Reconciled", "False")))
+
+	DeletePowerMonitor(name)
+	assert.Equal(t, 0, PowerMonitorCondition.DeletePartialMatch(prometheus.Labels{"name": name}))
+}
+
+func TestSetDaemonSetPods(t *testing.T) {
+	SetDaemonSetPods("ns", "ds", 3, 2)
+	assert.Equal(t, 3.0, gaugeValue(t, DaemonSetDesired.WithLabelValues("ns", "ds")))
+	assert.Equal(t, 2.0, gaugeValue(t, DaemonSetReady.WithLabelValues("ns", "ds")))
+
+	DeleteDaemonSet("ns", "ds")
+	assert.False(t, DaemonSetDesired.DeleteLabelValues("ns", "ds"))
+	assert.False(t, DaemonSetReady.DeleteLabelValues("ns", "ds"))
+}
+
+func TestSetUWMTokenExpiry(t *testing.T) {
+	expiry := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	SetUWMTokenExpiry("ns", "token", expiry)
+	assert.Equal(t, float64(expiry.Unix()), gaugeValue(t, UWMTokenExpiry.WithLabelValues("ns", "token")))
+
+	DeleteUWMTokenExpiry("ns", "token")
+	assert.False(t, UWMTokenExpiry.DeleteLabelValues("ns", "token"))
+}
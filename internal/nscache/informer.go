@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nscache
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// informer is the informer of a kind across all namespaces of a Cache; the
+// event handlers and indexers added to it are added to the informers of
+// namespaces added later
+type informer struct {
+	// obj is the object the informers of added namespaces are created for
+	obj client.Object
+
+	mu          sync.Mutex
+	byNamespace map[string]cache.Informer
+	handlers    []*registration
+	indexers    []toolscache.Indexers
+}
+
+var _ cache.Informer = &informer{}
+
+// registration is the registration of an event handler with the informers
+// of all namespaces
+type registration struct {
+	// add adds the handler to the informer of a namespace
+	add func(cache.Informer) (toolscache.ResourceEventHandlerRegistration, error)
+
+	mu      sync.Mutex
+	handles map[string]toolscache.ResourceEventHandlerRegistration
+}
+
+// HasSynced returns true if the handler was called for the initial objects
+// of all namespaces
+func (r *registration) HasSynced() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, h := range r.handles {
+		if !h.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+func (i *informer) addHandler(add func(cache.Informer) (toolscache.ResourceEventHandlerRegistration, error)) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	reg := &registration{add: add, handles: map[string]toolscache.ResourceEventHandlerRegistration{}}
+	for ns, nsInformer := range i.byNamespace {
+		h, err := add(nsInformer)
+		if err != nil {
+			return nil, err
+		}
+		reg.handles[ns] = h
+	}
+	i.handlers = append(i.handlers, reg)
+	return reg, nil
+}
+
+// AddEventHandler implements cache.Informer
+func (i *informer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	return i.addHandler(func(inf cache.Informer) (toolscache.ResourceEventHandlerRegistration, error) {
+		return inf.AddEventHandler(handler)
+	})
+}
+
+// AddEventHandlerWithResyncPeriod implements cache.Informer
+func (i *informer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) (toolscache.ResourceEventHandlerRegistration, error) {
+	return i.addHandler(func(inf cache.Informer) (toolscache.ResourceEventHandlerRegistration, error) {
+		return inf.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	})
+}
+
+// AddEventHandlerWithOptions implements cache.Informer
+func (i *informer) AddEventHandlerWithOptions(handler toolscache.ResourceEventHandler, options toolscache.HandlerOptions) (toolscache.ResourceEventHandlerRegistration, error) {
+	return i.addHandler(func(inf cache.Informer) (toolscache.ResourceEventHandlerRegistration, error) {
+		return inf.AddEventHandlerWithOptions(handler, options)
+	})
+}
+
+// RemoveEventHandler implements cache.Informer
+func (i *informer) RemoveEventHandler(handle toolscache.ResourceEventHandlerRegistration) error {
+	reg, ok := handle.(*registration)
+	if !ok {
+		return fmt.Errorf("registration %T was not returned by this informer", handle)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	for ns, h := range reg.handles {
+		if nsInformer, ok := i.byNamespace[ns]; ok {
+			if err := nsInformer.RemoveEventHandler(h); err != nil {
+				return err
+			}
+		}
+	}
+	reg.handles = map[string]toolscache.ResourceEventHandlerRegistration{}
+	i.handlers = slices.DeleteFunc(i.handlers, func(r *registration) bool { return r == reg })
+	return nil
+}
+
+// AddIndexers implements cache.Informer
+func (i *informer) AddIndexers(indexers toolscache.Indexers) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, nsInformer := range i.byNamespace {
+		if err := nsInformer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	i.indexers = append(i.indexers, indexers)
+	return nil
+}
+
+// HasSynced implements cache.Informer
+func (i *informer) HasSynced() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, nsInformer := range i.byNamespace {
+		if !nsInformer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsStopped implements cache.Informer; the informer isn't stopped while it
+// has no namespaces since namespaces may be added
+func (i *informer) IsStopped() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.byNamespace) == 0 {
+		return false
+	}
+	for _, nsInformer := range i.byNamespace {
+		if !nsInformer.IsStopped() {
+			return false
+		}
+	}
+	return true
+}
+
+// addNamespace adds the indexers and event handlers of i to the informer of
+// an added namespace
+func (i *informer) addNamespace(namespace string, nsInformer cache.Informer) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, indexers := range i.indexers {
+		if err := nsInformer.AddIndexers(indexers); err != nil {
+			return fmt.Errorf("error adding indexers in namespace %s: %w", namespace, err)
+		}
+	}
+	for _, reg := range i.handlers {
+		h, err := reg.add(nsInformer)
+		if err != nil {
+			return fmt.Errorf("error adding event handler in namespace %s: %w", namespace, err)
+		}
+		reg.mu.Lock()
+		reg.handles[namespace] = h
+		reg.mu.Unlock()
+	}
+	i.byNamespace[namespace] = nsInformer
+	return nil
+}
+
+// removeNamespace removes the informer of a removed namespace
+func (i *informer) removeNamespace(namespace string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, reg := range i.handlers {
+		reg.mu.Lock()
+		delete(reg.handles, namespace)
+		reg.mu.Unlock()
+	}
+	delete(i.byNamespace, namespace)
+}
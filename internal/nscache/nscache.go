@@ -0,0 +1,521 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nscache provides a cache of the objects in a set of namespaces
+// that grows and shrinks while the operator runs.
+//
+// A cache per namespace created with cache.New and added with mgr.Add isn't
+// enough: the sources of the controllers (Owns, Watches) and the client of the
+// manager are bound to the informers of the manager cache when the operator
+// starts, and a source can't be removed from a running controller. Cache is
+// therefore the cache of the manager; it hands out informers whose event
+// handlers and indexes follow the namespaces added and removed, and routes
+// reads to the cache of the namespace they target.
+package nscache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// DefaultSyncTimeout is the time given to the cache of an added namespace to sync
+const DefaultSyncTimeout = 30 * time.Second
+
+// NotWatchedError represents an error when an object is read from a namespace
+// the cache doesn't watch
+type NotWatchedError struct {
+	Namespace string
+}
+
+func (e *NotWatchedError) Error() string {
+	return fmt.Sprintf("namespace %s is not watched by the cache", e.Namespace)
+}
+
+// newCacheFunc returns a cache of the objects in namespace; the cache of
+// cluster-scoped objects if namespace is empty
+type newCacheFunc func(namespace string) (cache.Cache, error)
+
+// Cache caches cluster-scoped objects in a cache of their own and namespaced
+// objects in a cache per namespace. Namespaces are added and removed while the
+// cache runs; the event handlers and indexes of the informers handed out are
+// added to the informers of the namespaces added later.
+type Cache struct {
+	scheme   *runtime.Scheme
+	mapper   apimeta.RESTMapper
+	newCache newCacheFunc
+	cluster  cache.Cache
+	// static namespaces are watched for as long as the cache runs
+	static []string
+	// SyncTimeout is the time given to the cache of an added namespace to sync
+	SyncTimeout time.Duration
+
+	mu         sync.RWMutex
+	ctx        context.Context
+	errs       chan error
+	namespaces map[string]*namespaceCache
+	// failed holds the errors of the namespaces whose cache didn't sync in time
+	failed    map[string]error
+	informers map[informerKey]*informer
+	indexes   []index
+}
+
+var _ cache.Cache = &Cache{}
+
+type namespaceCache struct {
+	cache.Cache
+	cancel context.CancelFunc
+	synced atomic.Bool
+}
+
+// informerKey tells apart the informers of a kind for typed, unstructured and
+// metadata-only objects
+type informerKey struct {
+	gvk  schema.GroupVersionKind
+	kind string
+}
+
+// index is a field index replayed on the caches of added namespaces
+type index struct {
+	obj     client.Object
+	field   string
+	extract client.IndexerFunc
+}
+
+// New returns a cache that watches namespaces and the namespaces added later;
+// opts are applied to the cache of every namespace. Unlike a cache.Cache,
+// the ByObject.Namespaces of opts only set the selectors of an object in the
+// namespaces listed; the type-level selectors apply in the other namespaces.
+func New(config *rest.Config, opts cache.Options, namespaces []string) (*Cache, error) {
+	newCache := func(namespace string) (cache.Cache, error) {
+		o := opts
+		o.DefaultNamespaces = nil
+		if namespace != "" {
+			o.DefaultNamespaces = map[string]cache.Config{namespace: {}}
+		}
+		o.ByObject = byObjectIn(opts.ByObject, namespace)
+		return cache.New(config, o)
+	}
+	return newNamespacedCache(opts.Scheme, opts.Mapper, newCache, namespaces)
+}
+
+// byObjectIn returns the ByObject options of the cache of namespace; the
+// namespace settings of an object are dropped for the cache of cluster-scoped
+// objects
+func byObjectIn(byObject map[client.Object]cache.ByObject, namespace string) map[client.Object]cache.ByObject {
+	if byObject == nil {
+		return nil
+	}
+	scoped := make(map[client.Object]cache.ByObject, len(byObject))
+	for obj, b := range byObject {
+		if b.Namespaces != nil {
+			cfg := b.Namespaces[namespace]
+			b.Namespaces = nil
+			if namespace != "" {
+				b.Namespaces = map[string]cache.Config{namespace: cfg}
+			}
+		}
+		scoped[obj] = b
+	}
+	return scoped
+}
+
+func newNamespacedCache(scheme *runtime.Scheme, mapper apimeta.RESTMapper, newCache newCacheFunc, namespaces []string) (*Cache, error) {
+	cluster, err := newCache(corev1.NamespaceAll)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cache of cluster-scoped objects: %w", err)
+	}
+	c := &Cache{
+		scheme:      scheme,
+		mapper:      mapper,
+		newCache:    newCache,
+		cluster:     cluster,
+		SyncTimeout: DefaultSyncTimeout,
+		errs:        make(chan error, 1),
+		namespaces:  map[string]*namespaceCache{},
+		failed:      map[string]error{},
+		informers:   map[informerKey]*informer{},
+	}
+	for _, ns := range namespaces {
+		if _, ok := c.namespaces[ns]; ok {
+			continue
+		}
+		nsCache, err := newCache(ns)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cache of namespace %s: %w", ns, err)
+		}
+		// NOTE: the manager waits for the caches of these namespaces to sync
+		// before starting the controllers
+		static := &namespaceCache{Cache: nsCache}
+		static.synced.Store(true)
+		c.namespaces[ns] = static
+		c.static = append(c.static, ns)
+	}
+	return c, nil
+}
+
+// Namespaces returns the namespaces watched, sorted
+func (c *Cache) Namespaces() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	namespaces := make([]string, 0, len(c.namespaces))
+	for ns := range c.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// AddNamespace starts watching namespace without waiting for its cache to
+// sync and returns whether the cache has synced. The namespace isn't watched
+// anymore if its cache doesn't sync within SyncTimeout, e.g. since the
+// operator isn't allowed to watch it; the next call returns the error and
+// starts watching the namespace again.
+func (c *Cache) AddNamespace(ctx context.Context, namespace string) (bool, error) {
+	c.mu.Lock()
+	err, failed := c.failed[namespace]
+	delete(c.failed, namespace)
+	c.mu.Unlock()
+	if failed {
+		return false, err
+	}
+
+	nsCache, added, err := c.addNamespace(ctx, namespace)
+	if err != nil {
+		return false, err
+	}
+	if added {
+		go c.waitForSync(namespace, nsCache)
+	}
+	return nsCache.synced.Load(), nil
+}
+
+// waitForSync waits for the cache of an added namespace to sync and stops
+// watching the namespace if it doesn't sync in time
+func (c *Cache) waitForSync(namespace string, nsCache *namespaceCache) {
+	c.mu.RLock()
+	ctx := c.ctx
+	c.mu.RUnlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	syncCtx, cancel := context.WithTimeout(ctx, c.SyncTimeout)
+	defer cancel()
+	if nsCache.WaitForCacheSync(syncCtx) {
+		nsCache.synced.Store(true)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// NOTE: the namespace may have been removed, or removed and added again,
+	// while its cache was syncing
+	if c.namespaces[namespace] != nsCache {
+		return
+	}
+	c.removeNamespace(namespace)
+	c.failed[namespace] = fmt.Errorf("timed out waiting for the cache of namespace %s to sync", namespace)
+}
+
+func (c *Cache) addNamespace(ctx context.Context, namespace string) (*namespaceCache, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if nsCache, ok := c.namespaces[namespace]; ok {
+		return nsCache, false, nil
+	}
+
+	created, err := c.newCache(namespace)
+	if err != nil {
+		return nil, false, fmt.Errorf("error creating cache of namespace %s: %w", namespace, err)
+	}
+	for _, idx := range c.indexes {
+		if err := created.IndexField(ctx, idx.obj, idx.field, idx.extract); err != nil {
+			return nil, false, fmt.Errorf("error indexing %s in namespace %s: %w", idx.field, namespace, err)
+		}
+	}
+	for _, inf := range c.informers {
+		nsInformer, err := created.GetInformer(ctx, inf.obj, cache.BlockUntilSynced(false))
+		if err != nil {
+			return nil, false, fmt.Errorf("error creating informer in namespace %s: %w", namespace, err)
+		}
+		if err := inf.addNamespace(namespace, nsInformer); err != nil {
+			return nil, false, err
+		}
+	}
+
+	nsCache := &namespaceCache{Cache: created, cancel: func() {}}
+	if c.ctx != nil {
+		nsCache.cancel = c.start(namespace, created)
+	}
+	c.namespaces[namespace] = nsCache
+	return nsCache, true, nil
+}
+
+// RemoveNamespace stops watching namespace unless it is one of the namespaces
+// the cache was created with
+func (c *Cache) RemoveNamespace(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeNamespace(namespace)
+}
+
+// removeNamespace stops watching namespace; c.mu must be held
+func (c *Cache) removeNamespace(namespace string) {
+	nsCache, ok := c.namespaces[namespace]
+	if !ok || slices.Contains(c.static, namespace) {
+		return
+	}
+	for _, inf := range c.informers {
+		inf.removeNamespace(namespace)
+	}
+	nsCache.cancel()
+	delete(c.namespaces, namespace)
+}
+
+// start runs the cache of namespace until the cache is stopped or the
+// returned function is called; c.mu must be held
+func (c *Cache) start(namespace string, nsCache cache.Cache) context.CancelFunc {
+	ctx, cancel := context.WithCancel(c.ctx)
+	go func() {
+		if err := nsCache.Start(ctx); err != nil {
+			select {
+			case c.errs <- fmt.Errorf("failed to start cache of namespace %s: %w", namespace, err):
+			default:
+			}
+		}
+	}()
+	return cancel
+}
+
+// Start runs the caches of all namespaces, including those added later,
+// until ctx is done
+func (c *Cache) Start(ctx context.Context) error {
+	c.mu.Lock()
+	c.ctx = ctx
+	go func() {
+		if err := c.cluster.Start(ctx); err != nil {
+			select {
+			case c.errs <- fmt.Errorf("failed to start cache of cluster-scoped objects: %w", err):
+			default:
+			}
+		}
+	}()
+	for ns, nsCache := range c.namespaces {
+		nsCache.cancel = c.start(ns, nsCache.Cache)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-c.errs:
+		return err
+	}
+}
+
+// WaitForCacheSync waits for the caches of all namespaces to sync
+func (c *Cache) WaitForCacheSync(ctx context.Context) bool {
+	synced := c.cluster.WaitForCacheSync(ctx)
+	for _, nsCache := range c.namespaceCaches() {
+		if !nsCache.WaitForCacheSync(ctx) {
+			synced = false
+		}
+	}
+	return synced
+}
+
+func (c *Cache) namespaceCaches() map[string]cache.Cache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	caches := make(map[string]cache.Cache, len(c.namespaces))
+	for ns, nsCache := range c.namespaces {
+		caches[ns] = nsCache.Cache
+	}
+	return caches
+}
+
+// namespaceCache returns the cache of namespace
+func (c *Cache) namespaceCache(namespace string) (cache.Cache, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	nsCache, ok := c.namespaces[namespace]
+	if !ok {
+		return nil, &NotWatchedError{Namespace: namespace}
+	}
+	return nsCache.Cache, nil
+}
+
+// GetInformer implements cache.Informers
+func (c *Cache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	namespaced, err := apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		return c.cluster.GetInformer(ctx, obj, opts...)
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	return c.informer(ctx, informerKey{gvk: gvk, kind: fmt.Sprintf("%T", obj)}, obj, opts...)
+}
+
+// GetInformerForKind implements cache.Informers
+func (c *Cache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	namespaced, err := apiutil.IsGVKNamespaced(gvk, c.mapper)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		return c.cluster.GetInformerForKind(ctx, gvk, opts...)
+	}
+	obj, err := c.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	cObj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a client.Object", gvk)
+	}
+	return c.informer(ctx, informerKey{gvk: gvk, kind: fmt.Sprintf("%T", cObj)}, cObj, opts...)
+}
+
+// informer returns the informer of key across all namespaces
+func (c *Cache) informer(ctx context.Context, key informerKey, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if inf, ok := c.informers[key]; ok {
+		return inf, nil
+	}
+
+	inf := &informer{obj: obj.DeepCopyObject().(client.Object), byNamespace: map[string]cache.Informer{}}
+	for ns, nsCache := range c.namespaces {
+		nsInformer, err := nsCache.GetInformer(ctx, obj, opts...)
+		if err != nil {
+			return nil, err
+		}
+		inf.byNamespace[ns] = nsInformer
+	}
+	c.informers[key] = inf
+	return inf, nil
+}
+
+// RemoveInformer implements cache.Informers
+func (c *Cache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	namespaced, err := apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+	if err != nil {
+		return err
+	}
+	if !namespaced {
+		return c.cluster.RemoveInformer(ctx, obj)
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, nsCache := range c.namespaces {
+		if err := nsCache.RemoveInformer(ctx, obj); err != nil {
+			return err
+		}
+	}
+	delete(c.informers, informerKey{gvk: gvk, kind: fmt.Sprintf("%T", obj)})
+	return nil
+}
+
+// IndexField implements client.FieldIndexer
+func (c *Cache) IndexField(ctx context.Context, obj client.Object, field string, extract client.IndexerFunc) error {
+	namespaced, err := apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+	if err != nil {
+		return err
+	}
+	if !namespaced {
+		return c.cluster.IndexField(ctx, obj, field, extract)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, nsCache := range c.namespaces {
+		if err := nsCache.IndexField(ctx, obj, field, extract); err != nil {
+			return err
+		}
+	}
+	c.indexes = append(c.indexes, index{obj: obj, field: field, extract: extract})
+	return nil
+}
+
+// Get implements client.Reader
+func (c *Cache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	namespaced, err := apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+	if err != nil {
+		return err
+	}
+	if !namespaced {
+		return c.cluster.Get(ctx, key, obj, opts...)
+	}
+	nsCache, err := c.namespaceCache(key.Namespace)
+	if err != nil {
+		return fmt.Errorf("unable to get %v: %w", key, err)
+	}
+	return nsCache.Get(ctx, key, obj, opts...)
+}
+
+// List implements client.Reader; objects of all namespaces watched are
+// listed if no namespace is given
+func (c *Cache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	gvk, err := apiutil.GVKForObject(list, c.scheme)
+	if err != nil {
+		return err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	namespaced, err := apiutil.IsGVKNamespaced(gvk, c.mapper)
+	if err != nil {
+		return err
+	}
+	if !namespaced {
+		return c.cluster.List(ctx, list, opts...)
+	}
+
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if listOpts.Namespace != corev1.NamespaceAll {
+		nsCache, err := c.namespaceCache(listOpts.Namespace)
+		if err != nil {
+			return fmt.Errorf("unable to list: %w", err)
+		}
+		return nsCache.List(ctx, list, opts...)
+	}
+	if listOpts.Limit > 0 || listOpts.Continue != "" {
+		return fmt.Errorf("limit and continue list options are not supported across namespaces")
+	}
+
+	items := []runtime.Object{}
+	for _, nsCache := range c.namespaceCaches() {
+		nsList := list.DeepCopyObject().(client.ObjectList)
+		if err := nsCache.List(ctx, nsList, &listOpts); err != nil {
+			return err
+		}
+		nsItems, err := apimeta.ExtractList(nsList)
+		if err != nil {
+			return err
+		}
+		items = append(items, nsItems...)
+	}
+	return apimeta.SetList(list, items)
+}
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nscache
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+)
+
+// fakeCache serves the informers of a namespace and reads its objects from a fake client
+type fakeCache struct {
+	*informertest.FakeInformers
+	reader client.Reader
+}
+
+func (c fakeCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c fakeCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+type fakeCaches struct {
+	scheme *runtime.Scheme
+	caches map[string]fakeCache
+	// unsynced namespaces never sync, e.g. since they can't be watched
+	unsynced map[string]bool
+}
+
+func (f *fakeCaches) newCache(namespace string) (cache.Cache, error) {
+	informers := &informertest.FakeInformers{Scheme: f.scheme}
+	if f.unsynced[namespace] {
+		informers.Synced = new(bool)
+	}
+	objs := []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: namespace}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}},
+	}
+	c := fakeCache{
+		FakeInformers: informers,
+		reader:        fake.NewClientBuilder().WithScheme(f.scheme).WithObjects(objs...).Build(),
+	}
+	f.caches[namespace] = c
+	return c, nil
+}
+
+func (f *fakeCaches) informer(t *testing.T, namespace string) *controllertest.FakeInformer {
+	t.Helper()
+	inf, err := f.caches[namespace].FakeInformerFor(context.TODO(), &corev1.ConfigMap{})
+	require.NoError(t, err)
+	return inf
+}
+
+func testCache(t *testing.T, unsynced ...string) (*Cache, *fakeCaches) {
+	t.Helper()
+	scheme := clientgoscheme.Scheme
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), apimeta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Node"), apimeta.RESTScopeRoot)
+
+	f := &fakeCaches{scheme: scheme, caches: map[string]fakeCache{}, unsynced: map[string]bool{}}
+	for _, ns := range unsynced {
+		f.unsynced[ns] = true
+	}
+	c, err := newNamespacedCache(scheme, mapper, f.newCache, []string{"static"})
+	require.NoError(t, err)
+	c.SyncTimeout = 10 * time.Millisecond
+	return c, f
+}
+
+func TestCacheAddRemoveNamespace(t *testing.T) {
+	ctx := context.TODO()
+	c, f := testCache(t)
+
+	inf, err := c.GetInformer(ctx, &corev1.ConfigMap{})
+	require.NoError(t, err)
+	added := []string{}
+	reg, err := inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) { added = append(added, obj.(*corev1.ConfigMap).Namespace) },
+	})
+	require.NoError(t, err)
+
+	_, err = c.AddNamespace(ctx, "dynamic")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dynamic", "static"}, c.Namespaces())
+	assert.Eventually(t, func() bool {
+		synced, err := c.AddNamespace(ctx, "dynamic")
+		return err == nil && synced
+	}, time.Second, time.Millisecond, "added namespace is reported synced once its cache syncs")
+
+	f.informer(t, "static").Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "static"}})
+	f.informer(t, "dynamic").Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "dynamic"}})
+	assert.Equal(t, []string{"static", "dynamic"}, added, "handler is added to the informer of the added namespace")
+
+	c.RemoveNamespace("dynamic")
+	c.RemoveNamespace("static")
+	assert.Equal(t, []string{"static"}, c.Namespaces(), "namespaces the cache was created with are kept")
+
+	require.NoError(t, inf.RemoveEventHandler(reg))
+}
+
+func TestCacheAddNamespaceNotSynced(t *testing.T) {
+	ctx := context.TODO()
+	c, _ := testCache(t, "forbidden")
+
+	synced, err := c.AddNamespace(ctx, "forbidden")
+	require.NoError(t, err, "namespace is added without waiting for its cache to sync")
+	assert.False(t, synced)
+
+	assert.Eventually(t, func() bool {
+		return slices.Equal([]string{"static"}, c.Namespaces())
+	}, time.Second, time.Millisecond, "namespace is removed once its cache fails to sync")
+
+	_, err = c.AddNamespace(ctx, "forbidden")
+	assert.ErrorContains(t, err, "timed out waiting for the cache of namespace forbidden to sync")
+
+	synced, err = c.AddNamespace(ctx, "forbidden")
+	require.NoError(t, err, "namespace is watched again once the error is returned")
+	assert.False(t, synced)
+	assert.Equal(t, []string{"forbidden", "static"}, c.Namespaces())
+}
+
+func TestCacheStaticNamespaceSynced(t *testing.T) {
+	c, _ := testCache(t)
+
+	synced, err := c.AddNamespace(context.TODO(), "static")
+	require.NoError(t, err)
+	assert.True(t, synced, "manager waits for the namespaces the cache was created with to sync")
+}
+
+func TestCacheRead(t *testing.T) {
+	ctx := context.TODO()
+	c, _ := testCache(t)
+	_, err := c.AddNamespace(ctx, "dynamic")
+	require.NoError(t, err)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "dynamic", Name: "cm"}, cm))
+	assert.Equal(t, "dynamic", cm.Namespace)
+
+	err = c.Get(ctx, client.ObjectKey{Namespace: "other", Name: "cm"}, cm)
+	var notWatched *NotWatchedError
+	assert.ErrorAs(t, err, &notWatched)
+	assert.Equal(t, "other", notWatched.Namespace)
+
+	node := &corev1.Node{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "node"}, node), "cluster-scoped objects are read from the cluster cache")
+
+	cms := &corev1.ConfigMapList{}
+	require.NoError(t, c.List(ctx, cms, client.InNamespace("static")))
+	assert.Len(t, cms.Items, 1)
+
+	require.NoError(t, c.List(ctx, cms))
+	namespaces := []string{}
+	for _, cm := range cms.Items {
+		namespaces = append(namespaces, cm.Namespace)
+	}
+	assert.ElementsMatch(t, []string{"static", "dynamic"}, namespaces)
+
+	assert.ErrorAs(t, c.List(ctx, cms, client.InNamespace("other")), &notWatched)
+}
+
+func TestByObjectIn(t *testing.T) {
+	dashboards := labels.SelectorFromSet(labels.Set{"console.openshift.io/dashboard": "true"})
+	managed := labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "kepler-operator"})
+	cm := &corev1.ConfigMap{}
+	byObject := map[client.Object]cache.ByObject{
+		cm: {
+			Label:      managed,
+			Namespaces: map[string]cache.Config{"dashboards": {LabelSelector: dashboards}},
+		},
+	}
+
+	scoped := byObjectIn(byObject, "dashboards")
+	assert.Equal(t, map[string]cache.Config{"dashboards": {LabelSelector: dashboards}}, scoped[cm].Namespaces)
+
+	scoped = byObjectIn(byObject, "other")
+	assert.Equal(t, map[string]cache.Config{"other": {}}, scoped[cm].Namespaces,
+		"type-level selectors apply in namespaces not listed")
+	assert.Equal(t, managed, scoped[cm].Label)
+
+	scoped = byObjectIn(byObject, "")
+	assert.Nil(t, scoped[cm].Namespaces, "cluster cache drops namespace settings")
+
+	assert.Len(t, byObject[cm].Namespaces, 1, "options are not modified")
+	assert.Nil(t, byObjectIn(nil, "dashboards"))
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing configures the OpenTelemetry tracer provider used by the operator
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+
+	"github.com/sustainable.computing.io/kepler-operator/pkg/version"
+)
+
+const serviceName = "kepler-operator"
+
+// Options configures the export of traces
+type Options struct {
+	// Endpoint is the host:port of the OTLP gRPC collector; tracing is disabled if empty
+	Endpoint string
+	// Insecure disables TLS when connecting to the collector
+	Insecure bool
+	// SamplingRatio is the fraction of traces sampled; spans of a sampled parent are always sampled
+	SamplingRatio float64
+}
+
+// Enabled returns true if traces are exported
+func (o Options) Enabled() bool {
+	return o.Endpoint != ""
+}
+
+// ShutdownFunc flushes the pending spans and stops the export of traces
+type ShutdownFunc func(context.Context) error
+
+// Setup sets the global tracer provider to one that exports spans over OTLP
+// as configured by opts. The global provider is left as is (no-op) if tracing
+// isn't enabled.
+func Setup(ctx context.Context, opts Options) (ShutdownFunc, error) {
+	if !opts.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if opts.SamplingRatio < 0 || opts.SamplingRatio > 1 {
+		return nil, fmt.Errorf("invalid sampling ratio %v; must be between 0 and 1", opts.SamplingRatio)
+	}
+
+	clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.Info().Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SamplingRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tp.Shutdown, nil
+}
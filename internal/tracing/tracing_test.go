@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		before := otel.GetTracerProvider()
+		shutdown, err := Setup(context.TODO(), Options{})
+		require.NoError(t, err)
+		assert.Equal(t, before, otel.GetTracerProvider(), "global provider must not change")
+		assert.NoError(t, shutdown(context.TODO()))
+	})
+
+	t.Run("invalid sampling ratio", func(t *testing.T) {
+		for _, ratio := range []float64{-0.1, 1.1} {
+			_, err := Setup(context.TODO(), Options{Endpoint: "localhost:4317", SamplingRatio: ratio})
+			assert.ErrorContains(t, err, "invalid sampling ratio")
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		before := otel.GetTracerProvider()
+		t.Cleanup(func() { otel.SetTracerProvider(before) })
+
+		// NOTE: the grpc exporter connects lazily, so no collector is needed
+		shutdown, err := Setup(context.TODO(), Options{Endpoint: "localhost:4317", Insecure: true, SamplingRatio: 0.5})
+		require.NoError(t, err)
+		assert.NotEqual(t, before, otel.GetTracerProvider())
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		_ = shutdown(ctx)
+	})
+}
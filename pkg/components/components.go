@@ -22,6 +22,10 @@ var (
 	}
 )
 
+// NamespaceCreatedAnnotation marks a namespace created by the operator; only such
+// namespaces are deleted by the operator
+const NamespaceCreatedAnnotation = "powermonitor.sustainable.computing.io/namespace-created"
+
 func NewNamespace(ns string) *corev1.Namespace {
 	return &corev1.Namespace{
 		TypeMeta: metav1.TypeMeta{
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package powermonitor
+
+import (
+	"fmt"
+
+	"github.com/cespare/xxhash/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
+)
+
+// CanaryName returns the name of the canary daemonset and configmap of pmi
+func CanaryName(pmi *v1alpha1.PowerMonitorInternal) string {
+	return pmi.Name + "-canary"
+}
+
+// CanaryPodSelector returns the labels of the pods of the canary daemonset of
+// pmi; they differ from the pods of the daemonset so that the service doesn't
+// select the canary pods
+func CanaryPodSelector(pmi *v1alpha1.PowerMonitorInternal) k8s.StringMap {
+	return labels(pmi).Merge(k8s.StringMap{
+		"app.kubernetes.io/name":      "power-monitor-canary",
+		"app.kubernetes.io/component": "exporter",
+	})
+}
+
+// Revision returns the revision of the Kepler config in cfm and the Kepler
+// image of ds; a change of either is rolled out by a canary
+func Revision(ds *appsv1.DaemonSet, cfm *corev1.ConfigMap) string {
+	image := ""
+	if containers := ds.Spec.Template.Spec.Containers; len(containers) > 0 {
+		image = containers[0].Image
+	}
+	return fmt.Sprintf("%x", xxhash.Sum64String(image+"\n"+cfm.Data[KeplerConfigFile]))
+}
+
+// NewPowerMonitorCanaryConfigMap returns the configmap of the canary
+// daemonset with the Kepler config of cfm
+func NewPowerMonitorCanaryConfigMap(d components.Detail, pmi *v1alpha1.PowerMonitorInternal, cfm *corev1.ConfigMap) *corev1.ConfigMap {
+	canary := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CanaryName(pmi),
+			Namespace: pmi.Namespace(),
+			Labels:    labels(pmi).ToMap(),
+		},
+	}
+	if d == components.Metadata {
+		return canary
+	}
+	canary.Data = k8s.StringMap{
+		KeplerConfigFile: cfm.Data[KeplerConfigFile],
+	}
+	return canary
+}
+
+// NewPowerMonitorCanaryDaemonSet returns a daemonset that runs the pods of ds
+// on nodes only, with the Kepler config of the canary configmap
+func NewPowerMonitorCanaryDaemonSet(d components.Detail, pmi *v1alpha1.PowerMonitorInternal, ds *appsv1.DaemonSet, nodes []string) *appsv1.DaemonSet {
+	meta := metav1.ObjectMeta{
+		Name:      CanaryName(pmi),
+		Namespace: pmi.Namespace(),
+		Labels:    labels(pmi),
+	}
+	if d == components.Metadata {
+		return &appsv1.DaemonSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "DaemonSet",
+			},
+			ObjectMeta: meta,
+		}
+	}
+
+	canary := ds.DeepCopy()
+	canary.ObjectMeta = meta
+	canary.Status = appsv1.DaemonSetStatus{}
+	canary.Spec.Selector = &metav1.LabelSelector{MatchLabels: CanaryPodSelector(pmi)}
+
+	tmpl := &canary.Spec.Template
+	tmpl.Name = CanaryName(pmi)
+	tmpl.Labels = CanaryPodSelector(pmi)
+	for i, v := range tmpl.Spec.Volumes {
+		if v.ConfigMap != nil && v.ConfigMap.Name == pmi.Name {
+			tmpl.Spec.Volumes[i] = k8s.VolumeFromConfigMap(v.Name, CanaryName(pmi))
+		}
+	}
+	tmpl.Spec.Affinity = nodeNameAffinity(corev1.NodeSelectorOpIn, nodes)
+	return canary
+}
+
+// ExcludeCanaryNodes keeps the pods of ds off the canary nodes so that they
+// don't run next to the canary pods while a canary is active
+func ExcludeCanaryNodes(ds *appsv1.DaemonSet, nodes []string) {
+	ds.Spec.Template.Spec.Affinity = nodeNameAffinity(corev1.NodeSelectorOpNotIn, nodes)
+}
+
+// nodeNameAffinity returns an affinity that requires the name of the node to
+// satisfy op for nodes
+func nodeNameAffinity(op corev1.NodeSelectorOperator, nodes []string) *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchFields: []corev1.NodeSelectorRequirement{{
+						Key:      "metadata.name",
+						Operator: op,
+						Values:   nodes,
+					}},
+				}},
+			},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package powermonitor
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
+)
+
+const (
+	// ConfigRevisionLabel is set on the ConfigMaps that keep the rendered
+	// Kepler configs to the revision of the config
+	ConfigRevisionLabel = "powermonitor.sustainable.computing.io/config-revision"
+	// ConfigRevisionStateLabel is set on a kept config once the Kepler pods
+	// are found healthy or crash-looping with it
+	ConfigRevisionStateLabel = "powermonitor.sustainable.computing.io/config-revision-state"
+	// ConfigGenerationAnnotation is set on a kept config to the generation of
+	// the power-monitor-internal that rendered it
+	ConfigGenerationAnnotation = "powermonitor.sustainable.computing.io/generation"
+
+	// ConfigRevisionKnownGood is the state of a config the Kepler pods ran healthy with
+	ConfigRevisionKnownGood = "known-good"
+	// ConfigRevisionBad is the state of a config the Kepler pods crash-looped with
+	ConfigRevisionBad = "bad"
+)
+
+// ConfigRevision returns the revision of the Kepler config in cfm; it is the
+// hash the DaemonSet is annotated with
+func ConfigRevision(cfm *corev1.ConfigMap) string {
+	hash, _ := computeConfigMapHash(cfm, KeplerConfigFile)
+	return hash
+}
+
+// ConfigRevisionName returns the name of the ConfigMap that keeps the config
+// of revision
+func ConfigRevisionName(pmi *v1alpha1.PowerMonitorInternal, revision string) string {
+	return pmi.Name + "-config-" + revision
+}
+
+// NewPowerMonitorConfigRevision returns an immutable ConfigMap that keeps the
+// Kepler config in cfm
+func NewPowerMonitorConfigRevision(pmi *v1alpha1.PowerMonitorInternal, cfm *corev1.ConfigMap) *corev1.ConfigMap {
+	revision := ConfigRevision(cfm)
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigRevisionName(pmi, revision),
+			Namespace: pmi.Namespace(),
+			Labels:    labels(pmi).Merge(k8s.StringMap{ConfigRevisionLabel: revision}).ToMap(),
+			Annotations: map[string]string{
+				ConfigGenerationAnnotation: strconv.FormatInt(pmi.Generation, 10),
+			},
+		},
+		Immutable: ptr.To(true),
+		Data: k8s.StringMap{
+			KeplerConfigFile: cfm.Data[KeplerConfigFile],
+		},
+	}
+}
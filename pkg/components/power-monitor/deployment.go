@@ -47,6 +47,9 @@ const (
 	// ConfigMap annotations
 	ConfigMapHashAnnotation = "powermonitor.sustainable.computing.io/config-map-hash"
 
+	// RestartedAtAnnotation is set on the pod template to restartedAt to roll out a restart
+	RestartedAtAnnotation = "powermonitor.sustainable.computing.io/restarted-at"
+
 	// Secure Endpoint
 	KubeRBACProxyContainerName      = "kube-rbac-proxy"
 	SecurePort                      = 8443
@@ -65,6 +68,10 @@ const (
 	UWMNamespace                    = "openshift-user-workload-monitoring"
 	SecretTokenExpirationAnnotation = "powermonitor.sustainable.computing.io/secret-token-expiration"
 	CABundleConfigMapAnnotation     = "powermonitor.sustainable.computing.io/configmap-ca-bundle"
+
+	// InstanceLabel is set on every object created for a power-monitor-internal
+	// to the name of the power-monitor-internal
+	InstanceLabel = "operator.sustainable-computing.io/internal"
 )
 
 var (
@@ -121,7 +128,7 @@ func NewPowerMonitorDaemonSet(detail components.Detail, pmi *v1alpha1.PowerMonit
 		)
 	}
 
-	return &appsv1.DaemonSet{
+	ds := &appsv1.DaemonSet{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: appsv1.SchemeGroupVersion.String(),
 			Kind:       "DaemonSet",
@@ -151,6 +158,16 @@ func NewPowerMonitorDaemonSet(detail components.Detail, pmi *v1alpha1.PowerMonit
 			}, // PodTemplateSpec
 		}, // Spec
 	}
+
+	if deployment.RestartedAt != nil {
+		setAnnotation(&ds.Spec.Template.ObjectMeta, RestartedAtAnnotation, RestartedAt(deployment.RestartedAt))
+	}
+	return ds
+}
+
+// RestartedAt returns the value of the RestartedAtAnnotation for a restart requested at t
+func RestartedAt(t *metav1.Time) string {
+	return t.UTC().Format(time.RFC3339)
 }
 
 func NewPowerMonitorService(pmi *v1alpha1.PowerMonitorInternal) *corev1.Service {
@@ -193,12 +210,12 @@ func NewPowerMonitorService(pmi *v1alpha1.PowerMonitorInternal) *corev1.Service
 	return service
 }
 
-func NewPowerMonitorNamespaceInfoDashboard(d components.Detail) *corev1.ConfigMap {
-	return openshiftDashboardConfigMap(d, NamespaceInfoDashboardName, fmt.Sprintf("%s.json", NamespaceInfoDashboardName), namespaceInfoDashboardJson)
+func NewPowerMonitorNamespaceInfoDashboard(d components.Detail, pmi *v1alpha1.PowerMonitorInternal) *corev1.ConfigMap {
+	return openshiftDashboardConfigMap(d, pmi, NamespaceInfoDashboardName, fmt.Sprintf("%s.json", NamespaceInfoDashboardName), namespaceInfoDashboardJson)
 }
 
-func NewPowerMonitorInfoDashboard(d components.Detail) *corev1.ConfigMap {
-	return openshiftDashboardConfigMap(d, OverviewDashboardName, fmt.Sprintf("%s.json", OverviewDashboardName), infoDashboardJson)
+func NewPowerMonitorInfoDashboard(d components.Detail, pmi *v1alpha1.PowerMonitorInternal) *corev1.ConfigMap {
+	return openshiftDashboardConfigMap(d, pmi, OverviewDashboardName, fmt.Sprintf("%s.json", OverviewDashboardName), infoDashboardJson)
 }
 
 func NewPowerMonitorConfigMap(d components.Detail, pmi *v1alpha1.PowerMonitorInternal, additionalConfigs ...string) (*corev1.ConfigMap, error) {
@@ -387,21 +404,34 @@ func NewPowerMonitorServiceAccount(pmi *v1alpha1.PowerMonitorInternal) *corev1.S
 	}
 }
 
-func NewPowerMonitorServiceMonitor(d components.Detail, pmi *v1alpha1.PowerMonitorInternal) *monv1.ServiceMonitor {
-	if d == components.Metadata {
-		return &monv1.ServiceMonitor{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: monv1.SchemeGroupVersion.String(),
-				Kind:       "ServiceMonitor",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      pmi.Name,
-				Namespace: pmi.Namespace(),
-				Labels:    labels(pmi).ToMap(),
-			},
-		}
+// monitorObjectMeta returns the metadata of the ServiceMonitor or PodMonitor
+// of pmi; the labels set by the operator take precedence over the ones in spec
+func monitorObjectMeta(pmi *v1alpha1.PowerMonitorInternal) metav1.ObjectMeta {
+	spec := pmi.Spec.Monitoring.ServiceMonitor
+	meta := metav1.ObjectMeta{
+		Name:      pmi.Name,
+		Namespace: pmi.Namespace(),
+		Labels:    k8s.StringMap(spec.Labels).Merge(labels(pmi)).ToMap(),
 	}
-	relabelings := []*monv1.RelabelConfig{{
+	if len(spec.Annotations) > 0 {
+		// NOTE: copied since the hashes of the scrape secrets are annotated later
+		meta.Annotations = k8s.StringMap{}.Merge(spec.Annotations).ToMap()
+	}
+	return meta
+}
+
+// metricRelabelings returns the metric relabelings of the spec of pmi
+func metricRelabelings(pmi *v1alpha1.PowerMonitorInternal) []*monv1.RelabelConfig {
+	var relabelings []*monv1.RelabelConfig
+	for _, r := range pmi.Spec.Monitoring.ServiceMonitor.MetricRelabelings {
+		relabelings = append(relabelings, r.DeepCopy())
+	}
+	return relabelings
+}
+
+// nodeRelabelings sets the instance label of the samples to the node of the Kepler pod
+func nodeRelabelings() []*monv1.RelabelConfig {
+	return []*monv1.RelabelConfig{{
 		Action:      "replace",
 		Regex:       "(.*)",
 		Replacement: "$1",
@@ -410,59 +440,142 @@ func NewPowerMonitorServiceMonitor(d components.Detail, pmi *v1alpha1.PowerMonit
 		},
 		TargetLabel: "instance",
 	}}
+}
 
-	sm := &monv1.ServiceMonitor{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: monv1.SchemeGroupVersion.String(),
-			Kind:       "ServiceMonitor",
+// uwmAuthorization is the authorization of user workload monitoring to scrape Kepler
+func uwmAuthorization() *monv1.SafeAuthorization {
+	return &monv1.SafeAuthorization{
+		Type: "Bearer",
+		Credentials: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: SecretUWMTokenName,
+			},
+			Key: ServiceAccountTokenKey,
 		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      pmi.Name,
-			Namespace: pmi.Namespace(),
-			Labels:    labels(pmi).ToMap(),
+	}
+}
+
+// serviceTLSConfig validates the certificate of the Kepler service
+func serviceTLSConfig(pmi *v1alpha1.PowerMonitorInternal) monv1.SafeTLSConfig {
+	return monv1.SafeTLSConfig{
+		CA: monv1.SecretOrConfigMap{
+			ConfigMap: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: PowerMonitorCertsCABundleName,
+				},
+				Key: "service-ca.crt",
+			},
 		},
+		ServerName: fmt.Sprintf("%s.%s.svc", pmi.Name, pmi.Namespace()),
+	}
+}
+
+func NewPowerMonitorServiceMonitor(d components.Detail, pmi *v1alpha1.PowerMonitorInternal) *monv1.ServiceMonitor {
+	typeMeta := metav1.TypeMeta{
+		APIVersion: monv1.SchemeGroupVersion.String(),
+		Kind:       monv1.ServiceMonitorsKind,
+	}
+	if d == components.Metadata {
+		return &monv1.ServiceMonitor{
+			TypeMeta: typeMeta,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pmi.Name,
+				Namespace: pmi.Namespace(),
+				Labels:    labels(pmi).ToMap(),
+			},
+		}
+	}
+
+	spec := pmi.Spec.Monitoring.ServiceMonitor
+	endpoint := monv1.Endpoint{
+		Port:                 PowerMonitorServicePortName,
+		Scheme:               "http",
+		Interval:             spec.Interval,
+		ScrapeTimeout:        spec.ScrapeTimeout,
+		HonorLabels:          spec.HonorLabels,
+		RelabelConfigs:       nodeRelabelings(),
+		MetricRelabelConfigs: metricRelabelings(pmi),
+	}
+	if pmi.Spec.Kepler.Deployment.Security.Mode == v1alpha1.SecurityModeRBAC {
+		endpoint.Port = SecurePortName
+		endpoint.Scheme = "https"
+		endpoint.Authorization = uwmAuthorization()
+		endpoint.TLSConfig = &monv1.TLSConfig{SafeTLSConfig: serviceTLSConfig(pmi)}
+	}
+
+	return &monv1.ServiceMonitor{
+		TypeMeta:   typeMeta,
+		ObjectMeta: monitorObjectMeta(pmi),
 		Spec: monv1.ServiceMonitorSpec{
-			Endpoints: []monv1.Endpoint{{
-				Port:           PowerMonitorServicePortName,
-				Scheme:         "http",
-				RelabelConfigs: relabelings,
-			}},
-			JobLabel: "app.kubernetes.io/name",
+			Endpoints:   []monv1.Endpoint{endpoint},
+			JobLabel:    "app.kubernetes.io/name",
+			SampleLimit: spec.SampleLimit,
 			Selector: metav1.LabelSelector{
 				MatchLabels: labels(pmi),
 			},
 		},
 	}
-	if pmi.Spec.Kepler.Deployment.Security.Mode == v1alpha1.SecurityModeRBAC {
-		sm.Spec.Endpoints = []monv1.Endpoint{{
-			Port:           SecurePortName,
-			Scheme:         "https",
-			RelabelConfigs: relabelings,
-			Authorization: &monv1.SafeAuthorization{
-				Type: "Bearer",
-				Credentials: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: SecretUWMTokenName,
-					},
-					Key: ServiceAccountTokenKey,
-				},
+}
+
+// NewPowerMonitorPodMonitor returns the PodMonitor that scrapes the Kepler
+// pods of pmi when spec.monitoring.serviceMonitor.kind is PodMonitor
+func NewPowerMonitorPodMonitor(d components.Detail, pmi *v1alpha1.PowerMonitorInternal) *monv1.PodMonitor {
+	typeMeta := metav1.TypeMeta{
+		APIVersion: monv1.SchemeGroupVersion.String(),
+		Kind:       monv1.PodMonitorsKind,
+	}
+	if d == components.Metadata {
+		return &monv1.PodMonitor{
+			TypeMeta: typeMeta,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pmi.Name,
+				Namespace: pmi.Namespace(),
+				Labels:    labels(pmi).ToMap(),
 			},
-			TLSConfig: &monv1.TLSConfig{
-				SafeTLSConfig: monv1.SafeTLSConfig{
-					CA: monv1.SecretOrConfigMap{
-						ConfigMap: &corev1.ConfigMapKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: PowerMonitorCertsCABundleName,
-							},
-							Key: "service-ca.crt",
-						},
-					},
-					ServerName: fmt.Sprintf("%s.%s.svc", pmi.Name, pmi.Namespace()),
-				},
+		}
+	}
+
+	spec := pmi.Spec.Monitoring.ServiceMonitor
+	endpoint := monv1.PodMetricsEndpoint{
+		Port:          PowerMonitorServicePortName,
+		Scheme:        "http",
+		Interval:      spec.Interval,
+		ScrapeTimeout: spec.ScrapeTimeout,
+		HonorLabels:   spec.HonorLabels,
+		// NOTE: the job is the name of the Kepler service, as with the
+		// ServiceMonitor, so that dashboards and rules work with both
+		RelabelConfigs: append(nodeRelabelings(), &monv1.RelabelConfig{
+			Action:      "replace",
+			Replacement: pmi.Name,
+			TargetLabel: "job",
+		}),
+		MetricRelabelConfigs: metricRelabelings(pmi),
+	}
+	if pmi.Spec.Kepler.Deployment.Security.Mode == v1alpha1.SecurityModeRBAC {
+		// NOTE: the certificate of kube-rbac-proxy is issued for the Kepler service
+		endpoint.Port = SecurePortName
+		endpoint.Scheme = "https"
+		endpoint.Authorization = uwmAuthorization()
+		endpoint.TLSConfig = &monv1.PodMetricsEndpointTLSConfig{SafeTLSConfig: serviceTLSConfig(pmi)}
+	}
+
+	return &monv1.PodMonitor{
+		TypeMeta:   typeMeta,
+		ObjectMeta: monitorObjectMeta(pmi),
+		Spec: monv1.PodMonitorSpec{
+			PodMetricsEndpoints: []monv1.PodMetricsEndpoint{endpoint},
+			SampleLimit:         spec.SampleLimit,
+			Selector: metav1.LabelSelector{
+				MatchLabels: podSelector(pmi),
 			},
-		}}
+		},
 	}
-	return sm
+}
+
+// UsePodMonitor returns true if Kepler of pmi is scraped by a PodMonitor
+// instead of a ServiceMonitor
+func UsePodMonitor(pmi *v1alpha1.PowerMonitorInternal) bool {
+	return pmi.Spec.Monitoring.ServiceMonitor.Kind == v1alpha1.MonitorKindPodMonitor
 }
 
 func NewPowerMonitorCABundleConfigMap(d components.Detail, pmi *v1alpha1.PowerMonitorInternal) *corev1.ConfigMap {
@@ -475,6 +588,7 @@ func NewPowerMonitorCABundleConfigMap(d components.Detail, pmi *v1alpha1.PowerMo
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      PowerMonitorCertsCABundleName,
 				Namespace: pmi.Namespace(),
+				Labels:    labels(pmi),
 			},
 		}
 	}
@@ -486,6 +600,7 @@ func NewPowerMonitorCABundleConfigMap(d components.Detail, pmi *v1alpha1.PowerMo
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      PowerMonitorCertsCABundleName,
 			Namespace: pmi.Namespace(),
+			Labels:    labels(pmi),
 			Annotations: map[string]string{
 				"service.beta.openshift.io/inject-cabundle": "true",
 			},
@@ -648,8 +763,8 @@ func computeConfigMapHash(cm *corev1.ConfigMap, file string) (string, error) {
 	return fmt.Sprintf("%x", hash), nil
 }
 
-func openshiftDashboardConfigMap(d components.Detail, dashboardName, dashboardJSONName, dashboardJSONPath string) *corev1.ConfigMap {
-	objMeta := openshiftDashboardObjectMeta(dashboardName)
+func openshiftDashboardConfigMap(d components.Detail, pmi *v1alpha1.PowerMonitorInternal, dashboardName, dashboardJSONName, dashboardJSONPath string) *corev1.ConfigMap {
+	objMeta := openshiftDashboardObjectMeta(pmi, dashboardName)
 
 	if d == components.Metadata {
 		return &corev1.ConfigMap{
@@ -673,12 +788,13 @@ func openshiftDashboardConfigMap(d components.Detail, dashboardName, dashboardJS
 	}
 }
 
-func openshiftDashboardObjectMeta(name string) metav1.ObjectMeta {
+func openshiftDashboardObjectMeta(pmi *v1alpha1.PowerMonitorInternal, name string) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
 		Name:      name,
 		Namespace: DashboardNs,
 		Labels: components.CommonLabels.Merge(k8s.StringMap{
 			"console.openshift.io/dashboard": "true",
+			InstanceLabel:                    pmi.Name,
 		}),
 		Annotations: k8s.StringMap{
 			"include.release.openshift.io/self-managed-high-availability": "true",
@@ -689,12 +805,25 @@ func openshiftDashboardObjectMeta(name string) metav1.ObjectMeta {
 
 func labels(pmi *v1alpha1.PowerMonitorInternal) k8s.StringMap {
 	return components.CommonLabels.Merge(k8s.StringMap{
-		"app.kubernetes.io/component":                "exporter",
-		"operator.sustainable-computing.io/internal": pmi.Name,
-		"app.kubernetes.io/part-of":                  pmi.Name,
+		"app.kubernetes.io/component": "exporter",
+		InstanceLabel:                 pmi.Name,
+		"app.kubernetes.io/part-of":   pmi.Name,
 	})
 }
 
+// MetricsLevel returns the metric levels Kepler of pmi exports; the
+// PowerMonitor default if unset or invalid
+func MetricsLevel(pmi *v1alpha1.PowerMonitorInternal) config.Level {
+	if len(pmi.Spec.Kepler.Config.MetricLevels) == 0 {
+		return v1alpha1.MetricsLevelDefault
+	}
+	level, err := config.ParseLevel(pmi.Spec.Kepler.Config.MetricLevels)
+	if err != nil {
+		return v1alpha1.MetricsLevelDefault
+	}
+	return level
+}
+
 func podSelector(pmi *v1alpha1.PowerMonitorInternal) k8s.StringMap {
 	return labels(pmi).Merge(k8s.StringMap{
 		"app.kubernetes.io/name":      "power-monitor-exporter",
@@ -844,17 +973,7 @@ func KeplerConfig(pmi *v1alpha1.PowerMonitorInternal, additionalConfigs ...strin
 	cfg.Host.SysFS = SysFSMountPath
 	cfg.Host.ProcFS = ProcFSMountPath
 
-	// Set metrics level if specified, otherwise use PowerMonitor default
-	if len(pmi.Spec.Kepler.Config.MetricLevels) > 0 {
-		level, err := config.ParseLevel(pmi.Spec.Kepler.Config.MetricLevels)
-		if err != nil {
-			cfg.Exporter.Prometheus.MetricsLevel = v1alpha1.MetricsLevelDefault
-		} else {
-			cfg.Exporter.Prometheus.MetricsLevel = level
-		}
-	} else {
-		cfg.Exporter.Prometheus.MetricsLevel = v1alpha1.MetricsLevelDefault
-	}
+	cfg.Exporter.Prometheus.MetricsLevel = MetricsLevel(pmi)
 
 	// Set staleness if specified, otherwise use PowerMonitor default
 	if pmi.Spec.Kepler.Config.Staleness != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/cespare/xxhash/v2"
 	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
 	"github.com/sustainable.computing.io/kepler-operator/internal/config"
 	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
@@ -670,7 +671,7 @@ func TestPowerMonitorConfigMap(t *testing.T) {
 
 func TestPowerMonitorDashboards(t *testing.T) {
 	tt := []struct {
-		createDashboard    func(d components.Detail) *corev1.ConfigMap
+		createDashboard    func(d components.Detail, pmi *v1alpha1.PowerMonitorInternal) *corev1.ConfigMap
 		labels             k8s.StringMap
 		dashboardName      string
 		dashboardNamespace string
@@ -680,8 +681,9 @@ func TestPowerMonitorDashboards(t *testing.T) {
 		{
 			createDashboard: NewPowerMonitorInfoDashboard,
 			labels: k8s.StringMap{
-				"console.openshift.io/dashboard": "true",
-				"app.kubernetes.io/managed-by":   "kepler-operator",
+				"console.openshift.io/dashboard":             "true",
+				"app.kubernetes.io/managed-by":               "kepler-operator",
+				"operator.sustainable-computing.io/internal": "power-monitor",
 			},
 			dashboardName:      OverviewDashboardName,
 			dashboardNamespace: DashboardNs,
@@ -691,8 +693,9 @@ func TestPowerMonitorDashboards(t *testing.T) {
 		{
 			createDashboard: NewPowerMonitorNamespaceInfoDashboard,
 			labels: k8s.StringMap{
-				"console.openshift.io/dashboard": "true",
-				"app.kubernetes.io/managed-by":   "kepler-operator",
+				"console.openshift.io/dashboard":             "true",
+				"app.kubernetes.io/managed-by":               "kepler-operator",
+				"operator.sustainable-computing.io/internal": "power-monitor",
 			},
 			dashboardName:      NamespaceInfoDashboardName,
 			dashboardNamespace: DashboardNs,
@@ -703,7 +706,8 @@ func TestPowerMonitorDashboards(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.scenario, func(t *testing.T) {
 			t.Parallel()
-			dashboard := tc.createDashboard(components.Full)
+			pmi := &v1alpha1.PowerMonitorInternal{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor"}}
+			dashboard := tc.createDashboard(components.Full, pmi)
 
 			actualName := dashboard.Name
 			assert.Equal(t, tc.dashboardName, actualName)
@@ -1504,6 +1508,104 @@ func TestPowerMonitorServiceMonitor(t *testing.T) {
 	}
 }
 
+func TestPowerMonitorServiceMonitorSettings(t *testing.T) {
+	limit := uint64(5000)
+	pmi := v1alpha1.PowerMonitorInternal{
+		ObjectMeta: metav1.ObjectMeta{Name: "power-monitor-internal"},
+		Spec: v1alpha1.PowerMonitorInternalSpec{
+			Monitoring: v1alpha1.MonitoringSpec{
+				ServiceMonitor: v1alpha1.ServiceMonitorSpec{
+					Interval:      "15s",
+					ScrapeTimeout: "10s",
+					HonorLabels:   true,
+					SampleLimit:   &limit,
+					MetricRelabelings: []monv1.RelabelConfig{{
+						Action:       "drop",
+						SourceLabels: []monv1.LabelName{"__name__"},
+						Regex:        "kepler_process_.*",
+					}},
+					Labels: map[string]string{
+						"release":                   "prometheus",
+						"app.kubernetes.io/part-of": "overridden",
+					},
+					Annotations: map[string]string{"owner": "sre"},
+				},
+			},
+		},
+	}
+
+	sm := NewPowerMonitorServiceMonitor(components.Full, &pmi)
+	assert.Equal(t, "prometheus", sm.Labels["release"])
+	assert.Equal(t, "power-monitor-internal", sm.Labels["app.kubernetes.io/part-of"], "operator labels take precedence")
+	assert.Equal(t, map[string]string{"owner": "sre"}, sm.Annotations)
+	assert.Equal(t, &limit, sm.Spec.SampleLimit)
+
+	endpoint := sm.Spec.Endpoints[0]
+	assert.Equal(t, monv1.Duration("15s"), endpoint.Interval)
+	assert.Equal(t, monv1.Duration("10s"), endpoint.ScrapeTimeout)
+	assert.True(t, endpoint.HonorLabels)
+	assert.Equal(t, []*monv1.RelabelConfig{{
+		Action:       "drop",
+		SourceLabels: []monv1.LabelName{"__name__"},
+		Regex:        "kepler_process_.*",
+	}}, endpoint.MetricRelabelConfigs)
+
+	// annotating the ServiceMonitor must not change the spec of pmi
+	sm.Annotations["hash"] = "abc"
+	assert.Equal(t, map[string]string{"owner": "sre"}, pmi.Spec.Monitoring.ServiceMonitor.Annotations)
+}
+
+func TestPowerMonitorPodMonitor(t *testing.T) {
+	tt := []struct {
+		scenario string
+		mode     v1alpha1.SecurityMode
+		port     string
+		scheme   string
+		tls      bool
+	}{
+		{scenario: "default case", port: PowerMonitorServicePortName, scheme: "http"},
+		{scenario: "rbac case", mode: v1alpha1.SecurityModeRBAC, port: SecurePortName, scheme: "https", tls: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			pmi := v1alpha1.PowerMonitorInternal{
+				ObjectMeta: metav1.ObjectMeta{Name: "power-monitor-internal"},
+			}
+			pmi.Spec.Kepler.Deployment.Namespace = "monitoring"
+			pmi.Spec.Kepler.Deployment.Security.Mode = tc.mode
+			pmi.Spec.Monitoring.ServiceMonitor.Kind = v1alpha1.MonitorKindPodMonitor
+			pmi.Spec.Monitoring.ServiceMonitor.Interval = "30s"
+
+			assert.True(t, UsePodMonitor(&pmi))
+			pm := NewPowerMonitorPodMonitor(components.Full, &pmi)
+			assert.Equal(t, monv1.PodMonitorsKind, pm.Kind)
+			assert.Equal(t, "monitoring", pm.Namespace)
+			assert.Equal(t, metav1.LabelSelector{MatchLabels: podSelector(&pmi)}, pm.Spec.Selector)
+
+			require.Len(t, pm.Spec.PodMetricsEndpoints, 1)
+			endpoint := pm.Spec.PodMetricsEndpoints[0]
+			assert.Equal(t, tc.port, endpoint.Port)
+			assert.Equal(t, tc.scheme, endpoint.Scheme)
+			assert.Equal(t, monv1.Duration("30s"), endpoint.Interval)
+			assert.Equal(t, "instance", endpoint.RelabelConfigs[0].TargetLabel)
+			assert.Equal(t, &monv1.RelabelConfig{
+				Action:      "replace",
+				Replacement: "power-monitor-internal",
+				TargetLabel: "job",
+			}, endpoint.RelabelConfigs[1], "job is the name of the service as with the ServiceMonitor")
+			if tc.tls {
+				require.NotNil(t, endpoint.TLSConfig)
+				assert.Equal(t, "power-monitor-internal.monitoring.svc", endpoint.TLSConfig.ServerName)
+				assert.NotNil(t, endpoint.Authorization)
+			} else {
+				assert.Nil(t, endpoint.TLSConfig)
+				assert.Nil(t, endpoint.Authorization)
+			}
+		})
+	}
+}
+
 func TestPowerMonitorCABundleConfigMap(t *testing.T) {
 	tt := []struct {
 		name        string
@@ -1793,6 +1895,42 @@ func TestMountConfigMapToDaemonSet(t *testing.T) {
 	}
 }
 
+func TestDaemonSetRestartedAt(t *testing.T) {
+	restartedAt := metav1.NewTime(time.Date(2025, 6, 1, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60)))
+
+	tt := []struct {
+		restartedAt *metav1.Time
+		annotation  map[string]string
+		scenario    string
+	}{
+		{
+			scenario: "no restart requested",
+		},
+		{
+			restartedAt: &restartedAt,
+			annotation: map[string]string{
+				RestartedAtAnnotation: "2025-06-01T10:30:00Z",
+			},
+			scenario: "restart requested",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+			pmi := v1alpha1.PowerMonitorInternal{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "power-monitor",
+				},
+			}
+			pmi.Spec.Kepler.Deployment.RestartedAt = tc.restartedAt
+			ds := NewPowerMonitorDaemonSet(components.Full, &pmi)
+			assert.Equal(t, tc.annotation, k8s.AnnotationFromDS(ds))
+		})
+	}
+}
+
 func TestAnnotateWithConfigMapHash(t *testing.T) {
 	tt := []struct {
 		cfm        *corev1.ConfigMap
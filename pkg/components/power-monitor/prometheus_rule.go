@@ -0,0 +1,332 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package powermonitor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
+)
+
+const (
+	// AlertsRuleGroup is the name of the group of the alerts on Kepler
+	AlertsRuleGroup = "kepler.alerts"
+	// NamespaceRuleGroup is the name of the group of the namespace recording rules
+	NamespaceRuleGroup = "kepler.namespace.rules"
+	// WorkloadRuleGroup is the name of the group of the workload recording rules
+	WorkloadRuleGroup = "kepler.workload.rules"
+	// CarbonRuleGroup is the name of the group of the carbon emission recording rules
+	CarbonRuleGroup = "kepler.carbon.rules"
+
+	// defaults, windows and labels of the rules
+	defaultTargetDownFor       = monv1.Duration("5m")
+	defaultStaleMetricsFor     = monv1.Duration("15m")
+	defaultNodePowerFor        = monv1.Duration("15m")
+	defaultConditionsFor       = monv1.Duration("15m")
+	defaultNodePowerWatts      = 500
+	staleMetricsWindow         = "10m"
+	energyAggregationWindow    = "1h"
+	energyZones                = "package|dram"
+	joulesPerKWh               = "3.6e6"
+	carbonRegionLabel          = "carbon_region"
+	carbonIntensityRecord      = "kepler:node_carbon_intensity:gco2e_per_kwh"
+	alertSeverityLabel         = "severity"
+	alertSeverityWarning       = "warning"
+	alertSummaryAnnotation     = "summary"
+	alertDescriptionAnnotation = "description"
+)
+
+// unhealthyConditions are the conditions of a PowerMonitor that are False when it is unhealthy
+var unhealthyConditions = []v1alpha1.ConditionType{
+	v1alpha1.Available,
+	v1alpha1.Reconciled,
+	v1alpha1.Coverage,
+	v1alpha1.ConfigValid,
+	v1alpha1.SecurityReady,
+}
+
+// NewPowerMonitorPrometheusRule returns the PrometheusRule with the alerts and
+// recording rules of pmi that are enabled
+func NewPowerMonitorPrometheusRule(d components.Detail, pmi *v1alpha1.PowerMonitorInternal) *monv1.PrometheusRule {
+	typeMeta := metav1.TypeMeta{
+		APIVersion: monv1.SchemeGroupVersion.String(),
+		Kind:       monv1.PrometheusRuleKind,
+	}
+	if d == components.Metadata {
+		return &monv1.PrometheusRule{
+			TypeMeta: typeMeta,
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pmi.Name,
+				Namespace: pmi.Namespace(),
+				Labels:    labels(pmi).ToMap(),
+			},
+		}
+	}
+
+	return &monv1.PrometheusRule{
+		TypeMeta: typeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pmi.Name,
+			Namespace: pmi.Namespace(),
+			Labels:    k8s.StringMap(pmi.Spec.Monitoring.PrometheusRule.Labels).Merge(labels(pmi)).ToMap(),
+		},
+		Spec: monv1.PrometheusRuleSpec{
+			Groups: prometheusRuleGroups(pmi),
+		},
+	}
+}
+
+// HasPrometheusRules returns true if any alert or recording rule of pmi is
+// enabled; the carbon emission rules are added once the intensities of the
+// nodes are known
+func HasPrometheusRules(pmi *v1alpha1.PowerMonitorInternal) bool {
+	return pmi.Spec.Carbon != nil || len(prometheusRuleGroups(pmi)) > 0
+}
+
+// prometheusRuleGroups returns the rule groups of pmi that have a rule enabled
+func prometheusRuleGroups(pmi *v1alpha1.PowerMonitorInternal) []monv1.RuleGroup {
+	groups := []monv1.RuleGroup{}
+	for _, g := range []monv1.RuleGroup{
+		{Name: AlertsRuleGroup, Rules: alertRules(pmi)},
+		{Name: NamespaceRuleGroup, Rules: namespaceRecordingRules(pmi)},
+		{Name: WorkloadRuleGroup, Rules: workloadRecordingRules(pmi)},
+	} {
+		if len(g.Rules) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// keplerSelector selects the series scraped from Kepler of pmi
+func keplerSelector(pmi *v1alpha1.PowerMonitorInternal) string {
+	return fmt.Sprintf(`job=%q,namespace=%q`, pmi.Name, pmi.Namespace())
+}
+
+// durationOr returns d or def if d is unset
+func durationOr(d, def monv1.Duration) *monv1.Duration {
+	if d == "" {
+		return ptr.To(def)
+	}
+	return ptr.To(d)
+}
+
+func alert(name, expr string, pending *monv1.Duration, summary, description string) monv1.Rule {
+	return monv1.Rule{
+		Alert: name,
+		Expr:  intstr.FromString(expr),
+		For:   pending,
+		Labels: map[string]string{
+			alertSeverityLabel: alertSeverityWarning,
+		},
+		Annotations: map[string]string{
+			alertSummaryAnnotation:     summary,
+			alertDescriptionAnnotation: description,
+		},
+	}
+}
+
+func record(name, expr string) monv1.Rule {
+	return monv1.Rule{Record: name, Expr: intstr.FromString(expr)}
+}
+
+// alertRules returns the alerts of pmi that are enabled
+func alertRules(pmi *v1alpha1.PowerMonitorInternal) []monv1.Rule {
+	spec := pmi.Spec.Monitoring.PrometheusRule.Alerts
+	sel := keplerSelector(pmi)
+	rules := []monv1.Rule{}
+
+	if ptr.Deref(spec.TargetDown.Enabled, true) {
+		rules = append(rules, alert("KeplerTargetDown",
+			fmt.Sprintf(`up{%s} == 0`, sel),
+			durationOr(spec.TargetDown.For, defaultTargetDownFor),
+			"Kepler is down on node {{ $labels.instance }}",
+			"Prometheus fails to scrape Kepler of PowerMonitor "+pmi.Name+" on node {{ $labels.instance }}; the power of the node is not monitored.",
+		))
+	}
+
+	if ptr.Deref(spec.StaleMetrics.Enabled, true) {
+		// NOTE: nodes draw energy even when idle, so the energy Kepler reports
+		// stops increasing only if Kepler fails to read it
+		rules = append(rules, alert("KeplerStaleMetrics",
+			fmt.Sprintf(`up{%[1]s} == 1 unless on (instance) increase(kepler_node_cpu_joules_total{%[1]s}[%[2]s]) > 0`, sel, staleMetricsWindow),
+			durationOr(spec.StaleMetrics.For, defaultStaleMetricsFor),
+			"Kepler metrics of node {{ $labels.instance }} are stale",
+			"The energy Kepler of PowerMonitor "+pmi.Name+" reports for node {{ $labels.instance }} has not increased for "+staleMetricsWindow+".",
+		))
+	}
+
+	if ptr.Deref(spec.NodePower.Enabled, false) {
+		threshold := ptr.Deref(spec.NodePower.ThresholdWatts, defaultNodePowerWatts)
+		rules = append(rules, alert("KeplerNodePowerHigh",
+			fmt.Sprintf(`sum by (instance) (kepler_node_cpu_watts{%s,zone=~%q}) > %d`, sel, energyZones, threshold),
+			durationOr(spec.NodePower.For, defaultNodePowerFor),
+			"Node {{ $labels.instance }} draws more than "+fmt.Sprint(threshold)+"W",
+			"The CPU packages and DRAM of node {{ $labels.instance }} draw {{ $value | humanize }}W.",
+		))
+	}
+
+	if ptr.Deref(spec.Conditions.Enabled, false) {
+		types := make([]string, 0, len(unhealthyConditions))
+		for _, t := range unhealthyConditions {
+			types = append(types, string(t))
+		}
+		rules = append(rules, alert("KeplerPowerMonitorConditionFalse",
+			fmt.Sprintf(`kepler_operator_powermonitor_condition{name=%q,type=~%q,status="False"} == 1`, pmi.Name, strings.Join(types, "|")),
+			durationOr(spec.Conditions.For, defaultConditionsFor),
+			"PowerMonitor "+pmi.Name+" is not {{ $labels.type }}",
+			"The {{ $labels.type }} condition of PowerMonitor "+pmi.Name+" is False; see its status for the reason.",
+		))
+	}
+	return rules
+}
+
+// namespaceRecordingRules returns the power and energy of each namespace
+// recorded from the pod metrics of pmi
+func namespaceRecordingRules(pmi *v1alpha1.PowerMonitorInternal) []monv1.Rule {
+	spec := pmi.Spec.Monitoring.PrometheusRule.RecordingRules.Namespace
+	if !ptr.Deref(spec.Enabled, true) || !MetricsLevel(pmi).IsPodEnabled() {
+		return nil
+	}
+	return aggregateRules(keplerSelector(pmi), "namespace", "pod", "pod_namespace")
+}
+
+// workloadRecordingRules returns the power and energy of each pod, container
+// and VM recorded from the metrics of pmi at the levels Kepler exports
+func workloadRecordingRules(pmi *v1alpha1.PowerMonitorInternal) []monv1.Rule {
+	spec := pmi.Spec.Monitoring.PrometheusRule.RecordingRules.Workload
+	if !ptr.Deref(spec.Enabled, true) {
+		return nil
+	}
+
+	level := MetricsLevel(pmi)
+	sel := keplerSelector(pmi)
+	rules := []monv1.Rule{}
+	if level.IsPodEnabled() {
+		rules = append(rules, aggregateRules(sel, "pod", "pod", "pod_namespace, pod_name")...)
+	}
+	if level.IsContainerEnabled() {
+		rules = append(rules, aggregateRules(sel, "container", "container", "container_id, container_name")...)
+	}
+	if level.IsVMEnabled() {
+		rules = append(rules, aggregateRules(sel, "vm", "vm", "vm_id, vm_name")...)
+	}
+	return rules
+}
+
+// aggregateRules records the power and energy of the kepler_<level> metrics
+// summed by labels and zone across nodes as kepler:<name>_cpu_*
+func aggregateRules(sel, name, level, labels string) []monv1.Rule {
+	return []monv1.Rule{
+		record(fmt.Sprintf("kepler:%s_cpu_watts:sum", name),
+			fmt.Sprintf(`sum by (%s, zone) (kepler_%s_cpu_watts{%s})`, labels, level, sel)),
+		record(fmt.Sprintf("kepler:%s_cpu_joules:increase%s", name, energyAggregationWindow),
+			fmt.Sprintf(`sum by (%s, zone) (increase(kepler_%s_cpu_joules_total{%s}[%s]))`, labels, level, sel, energyAggregationWindow)),
+	}
+}
+
+// NodeCarbonIntensity is the carbon intensity of the electricity used by a node
+type NodeCarbonIntensity struct {
+	// Node is the name of the node
+	Node string
+	// Region is the value of the carbon node label of the node
+	Region string
+	// GramsPerKWh is the carbon intensity in gCO2e/kWh
+	GramsPerKWh float64
+}
+
+// CarbonIntensities returns the carbon intensities in gCO2e/kWh of the values
+// of the carbon node label of pmi; the intensities in cm, if any, take
+// precedence over the ones in the spec
+func CarbonIntensities(pmi *v1alpha1.PowerMonitorInternal, cm *corev1.ConfigMap) (map[string]float64, error) {
+	intensities := map[string]float64{}
+	carbon := pmi.Spec.Carbon
+	if carbon == nil {
+		return intensities, nil
+	}
+	for region, q := range carbon.Intensities {
+		intensities[region] = q.AsApproximateFloat64()
+	}
+	if cm == nil {
+		return intensities, nil
+	}
+	for region, value := range cm.Data {
+		q, err := resource.ParseQuantity(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid carbon intensity %q of %q in configmap %s: %w", value, region, cm.Name, err)
+		}
+		intensities[region] = q.AsApproximateFloat64()
+	}
+	return intensities, nil
+}
+
+// NodeCarbonIntensities returns the carbon intensities of nodes sorted by
+// name; nodes without an intensity are omitted unless pmi has a default intensity
+func NodeCarbonIntensities(pmi *v1alpha1.PowerMonitorInternal, nodes []corev1.Node, intensities map[string]float64) []NodeCarbonIntensity {
+	carbon := pmi.Spec.Carbon
+	if carbon == nil {
+		return nil
+	}
+	nodeLabel := carbon.NodeLabel
+	if nodeLabel == "" {
+		nodeLabel = corev1.LabelTopologyRegion
+	}
+
+	result := []NodeCarbonIntensity{}
+	for _, node := range nodes {
+		region := node.Labels[nodeLabel]
+		intensity, ok := intensities[region]
+		if !ok && carbon.DefaultIntensity == nil {
+			continue
+		}
+		if !ok {
+			intensity = carbon.DefaultIntensity.AsApproximateFloat64()
+		}
+		result = append(result, NodeCarbonIntensity{Node: node.Name, Region: region, GramsPerKWh: intensity})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Node < result[j].Node })
+	return result
+}
+
+// NewPowerMonitorCarbonRuleGroup returns the rules that record the carbon intensity of
+// nodes and the carbon emissions in gCO2e of each node, namespace and pod
+// over the last hour from the energy measured by Kepler of pmi
+func NewPowerMonitorCarbonRuleGroup(pmi *v1alpha1.PowerMonitorInternal, nodes []NodeCarbonIntensity) monv1.RuleGroup {
+	rules := []monv1.Rule{}
+	for _, n := range nodes {
+		rule := record(carbonIntensityRecord, fmt.Sprintf("vector(%s)", strconv.FormatFloat(n.GramsPerKWh, 'f', -1, 64)))
+		rule.Labels = map[string]string{"instance": n.Node, carbonRegionLabel: n.Region}
+		rules = append(rules, rule)
+	}
+
+	// NOTE: intensities are multiplied by the energy of each node before the
+	// energy is summed since nodes may have different intensities
+	sel := fmt.Sprintf(`%s,zone=~%q`, keplerSelector(pmi), energyZones)
+	rules = append(rules, record("kepler:node_co2e_grams:increase"+energyAggregationWindow,
+		fmt.Sprintf(`sum by (instance) (increase(kepler_node_cpu_joules_total{%s}[%s])) / %s * on (instance) group_left (%s) %s`,
+			sel, energyAggregationWindow, joulesPerKWh, carbonRegionLabel, carbonIntensityRecord)))
+	if MetricsLevel(pmi).IsPodEnabled() {
+		for _, r := range []struct{ name, labels string }{
+			{"namespace", "pod_namespace"},
+			{"pod", "pod_namespace, pod_name"},
+		} {
+			rules = append(rules, record(fmt.Sprintf("kepler:%s_co2e_grams:increase%s", r.name, energyAggregationWindow),
+				fmt.Sprintf(`sum by (%[1]s) (sum by (instance, %[1]s) (increase(kepler_pod_cpu_joules_total{%[2]s}[%[3]s])) * on (instance) group_left %[4]s) / %[5]s`,
+					r.labels, sel, energyAggregationWindow, carbonIntensityRecord, joulesPerKWh)))
+		}
+	}
+	return monv1.RuleGroup{Name: CarbonRuleGroup, Rules: rules}
+}
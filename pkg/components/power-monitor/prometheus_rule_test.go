@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package powermonitor
+
+import (
+	"testing"
+
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+)
+
+func ruleNames(rule *monv1.PrometheusRule) map[string][]string {
+	names := map[string][]string{}
+	for _, g := range rule.Spec.Groups {
+		for _, r := range g.Rules {
+			names[g.Name] = append(names[g.Name], r.Alert+r.Record)
+		}
+	}
+	return names
+}
+
+func TestPowerMonitorPrometheusRule(t *testing.T) {
+	tt := []struct {
+		scenario string
+		spec     v1alpha1.PrometheusRuleSpec
+		levels   []string
+		rules    map[string][]string
+	}{
+		{
+			scenario: "default",
+			rules: map[string][]string{
+				AlertsRuleGroup: {"KeplerTargetDown", "KeplerStaleMetrics"},
+				NamespaceRuleGroup: {
+					"kepler:namespace_cpu_watts:sum", "kepler:namespace_cpu_joules:increase1h",
+				},
+				WorkloadRuleGroup: {
+					"kepler:pod_cpu_watts:sum", "kepler:pod_cpu_joules:increase1h",
+					"kepler:vm_cpu_watts:sum", "kepler:vm_cpu_joules:increase1h",
+				},
+			},
+		},
+		{
+			scenario: "node power alert",
+			spec: v1alpha1.PrometheusRuleSpec{
+				Alerts: v1alpha1.PrometheusAlertsSpec{
+					TargetDown:   v1alpha1.AlertSpec{Enabled: ptr.To(false)},
+					StaleMetrics: v1alpha1.AlertSpec{Enabled: ptr.To(false)},
+					NodePower:    v1alpha1.NodePowerAlertSpec{Enabled: ptr.To(true)},
+				},
+				RecordingRules: v1alpha1.PrometheusRecordingRulesSpec{
+					Workload: v1alpha1.RecordingRulesSpec{Enabled: ptr.To(false)},
+				},
+			},
+			rules: map[string][]string{
+				AlertsRuleGroup: {"KeplerNodePowerHigh"},
+				NamespaceRuleGroup: {
+					"kepler:namespace_cpu_watts:sum", "kepler:namespace_cpu_joules:increase1h",
+				},
+			},
+		},
+		{
+			scenario: "metric levels without pods",
+			levels:   []string{"node", "container"},
+			spec: v1alpha1.PrometheusRuleSpec{
+				Alerts: v1alpha1.PrometheusAlertsSpec{
+					TargetDown:   v1alpha1.AlertSpec{Enabled: ptr.To(false)},
+					StaleMetrics: v1alpha1.AlertSpec{Enabled: ptr.To(false)},
+				},
+			},
+			rules: map[string][]string{
+				WorkloadRuleGroup: {"kepler:container_cpu_watts:sum", "kepler:container_cpu_joules:increase1h"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			pmi := &v1alpha1.PowerMonitorInternal{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor"}}
+			pmi.Spec.Kepler.Deployment.Namespace = "monitoring"
+			pmi.Spec.Kepler.Config.MetricLevels = tc.levels
+			pmi.Spec.Monitoring.PrometheusRule = tc.spec
+
+			rule := NewPowerMonitorPrometheusRule(components.Full, pmi)
+			assert.Equal(t, "monitoring", rule.Namespace)
+			assert.Equal(t, tc.rules, ruleNames(rule))
+			assert.True(t, HasPrometheusRules(pmi))
+		})
+	}
+}
+
+func TestPowerMonitorPrometheusRuleSettings(t *testing.T) {
+	pmi := &v1alpha1.PowerMonitorInternal{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor"}}
+	pmi.Spec.Kepler.Deployment.Namespace = "monitoring"
+	pmi.Spec.Monitoring.PrometheusRule = v1alpha1.PrometheusRuleSpec{
+		Labels: map[string]string{"release": "prometheus"},
+		Alerts: v1alpha1.PrometheusAlertsSpec{
+			TargetDown: v1alpha1.AlertSpec{For: "1m"},
+			NodePower: v1alpha1.NodePowerAlertSpec{
+				Enabled:        ptr.To(true),
+				ThresholdWatts: ptr.To(int32(300)),
+			},
+			Conditions: v1alpha1.ConditionsAlertSpec{Enabled: ptr.To(true)},
+		},
+	}
+
+	rule := NewPowerMonitorPrometheusRule(components.Full, pmi)
+	assert.Equal(t, "prometheus", rule.Labels["release"])
+	assert.Equal(t, "power-monitor", rule.Labels[InstanceLabel])
+
+	require.NotEmpty(t, rule.Spec.Groups)
+	alerts := map[string]monv1.Rule{}
+	for _, r := range rule.Spec.Groups[0].Rules {
+		alerts[r.Alert] = r
+	}
+	assert.Equal(t, `up{job="power-monitor",namespace="monitoring"} == 0`, alerts["KeplerTargetDown"].Expr.StrVal)
+	assert.Equal(t, monv1.Duration("1m"), *alerts["KeplerTargetDown"].For)
+	assert.Equal(t, monv1.Duration("15m"), *alerts["KeplerStaleMetrics"].For)
+	assert.Equal(t,
+		`sum by (instance) (kepler_node_cpu_watts{job="power-monitor",namespace="monitoring",zone=~"package|dram"}) > 300`,
+		alerts["KeplerNodePowerHigh"].Expr.StrVal)
+	assert.Contains(t, alerts["KeplerPowerMonitorConditionFalse"].Expr.StrVal, `name="power-monitor"`)
+}
+
+func TestHasPrometheusRules(t *testing.T) {
+	pmi := &v1alpha1.PowerMonitorInternal{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor"}}
+	pmi.Spec.Monitoring.PrometheusRule = v1alpha1.PrometheusRuleSpec{
+		Alerts: v1alpha1.PrometheusAlertsSpec{
+			TargetDown:   v1alpha1.AlertSpec{Enabled: ptr.To(false)},
+			StaleMetrics: v1alpha1.AlertSpec{Enabled: ptr.To(false)},
+			Conditions:   v1alpha1.ConditionsAlertSpec{Enabled: ptr.To(false)},
+		},
+		RecordingRules: v1alpha1.PrometheusRecordingRulesSpec{
+			Namespace: v1alpha1.RecordingRulesSpec{Enabled: ptr.To(false)},
+			Workload:  v1alpha1.RecordingRulesSpec{Enabled: ptr.To(false)},
+		},
+	}
+	assert.False(t, HasPrometheusRules(pmi))
+	assert.Empty(t, NewPowerMonitorPrometheusRule(components.Full, pmi).Spec.Groups)
+}
+
+func carbonPowerMonitorInternal(carbon *v1alpha1.CarbonSpec) *v1alpha1.PowerMonitorInternal {
+	pmi := &v1alpha1.PowerMonitorInternal{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor"}}
+	pmi.Spec.Kepler.Deployment.Namespace = "monitoring"
+	pmi.Spec.Carbon = carbon
+	return pmi
+}
+
+func TestCarbonIntensities(t *testing.T) {
+	pmi := carbonPowerMonitorInternal(&v1alpha1.CarbonSpec{
+		Intensities: map[string]resource.Quantity{
+			"eu-west": resource.MustParse("250"),
+			"us-east": resource.MustParse("400.5"),
+		},
+	})
+
+	intensities, err := CarbonIntensities(pmi, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"eu-west": 250, "us-east": 400.5}, intensities)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "carbon"},
+		Data:       map[string]string{"eu-west": " 120 ", "ap-south": "700"},
+	}
+	intensities, err = CarbonIntensities(pmi, cm)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"eu-west": 120, "us-east": 400.5, "ap-south": 700}, intensities,
+		"intensities of the configmap take precedence")
+
+	cm.Data["eu-west"] = "low"
+	_, err = CarbonIntensities(pmi, cm)
+	assert.ErrorContains(t, err, `invalid carbon intensity "low" of "eu-west"`)
+}
+
+func TestNodeCarbonIntensities(t *testing.T) {
+	node := func(name, region string) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{corev1.LabelTopologyRegion: region, "site": region + "-site"},
+		}}
+	}
+	nodes := []corev1.Node{node("worker-2", "us-east"), node("worker-1", "eu-west"), node("worker-3", "unknown")}
+	intensities := map[string]float64{"eu-west": 250, "us-east": 400, "eu-west-site": 100}
+
+	tt := []struct {
+		scenario string
+		carbon   *v1alpha1.CarbonSpec
+		want     []NodeCarbonIntensity
+	}{
+		{
+			scenario: "region label",
+			carbon:   &v1alpha1.CarbonSpec{},
+			want: []NodeCarbonIntensity{
+				{Node: "worker-1", Region: "eu-west", GramsPerKWh: 250},
+				{Node: "worker-2", Region: "us-east", GramsPerKWh: 400},
+			},
+		},
+		{
+			scenario: "default intensity",
+			carbon:   &v1alpha1.CarbonSpec{DefaultIntensity: ptr.To(resource.MustParse("500"))},
+			want: []NodeCarbonIntensity{
+				{Node: "worker-1", Region: "eu-west", GramsPerKWh: 250},
+				{Node: "worker-2", Region: "us-east", GramsPerKWh: 400},
+				{Node: "worker-3", Region: "unknown", GramsPerKWh: 500},
+			},
+		},
+		{
+			scenario: "custom node label",
+			carbon:   &v1alpha1.CarbonSpec{NodeLabel: "site"},
+			want: []NodeCarbonIntensity{
+				{Node: "worker-1", Region: "eu-west-site", GramsPerKWh: 100},
+			},
+		},
+		{
+			scenario: "carbon disabled",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			pmi := carbonPowerMonitorInternal(tc.carbon)
+			assert.Equal(t, tc.want, NodeCarbonIntensities(pmi, nodes, intensities))
+		})
+	}
+}
+
+func TestPowerMonitorCarbonRuleGroup(t *testing.T) {
+	pmi := carbonPowerMonitorInternal(&v1alpha1.CarbonSpec{})
+	assert.True(t, HasPrometheusRules(pmi))
+
+	group := NewPowerMonitorCarbonRuleGroup(pmi, []NodeCarbonIntensity{
+		{Node: "worker-1", Region: "eu-west", GramsPerKWh: 250.5},
+	})
+	assert.Equal(t, CarbonRuleGroup, group.Name)
+	require.Len(t, group.Rules, 4)
+
+	intensity := group.Rules[0]
+	assert.Equal(t, "kepler:node_carbon_intensity:gco2e_per_kwh", intensity.Record)
+	assert.Equal(t, "vector(250.5)", intensity.Expr.StrVal)
+	assert.Equal(t, map[string]string{"instance": "worker-1", "carbon_region": "eu-west"}, intensity.Labels)
+
+	names := []string{}
+	for _, r := range group.Rules[1:] {
+		names = append(names, r.Record)
+	}
+	assert.Equal(t, []string{
+		"kepler:node_co2e_grams:increase1h",
+		"kepler:namespace_co2e_grams:increase1h",
+		"kepler:pod_co2e_grams:increase1h",
+	}, names)
+	assert.Equal(t,
+		`sum by (pod_namespace) (sum by (instance, pod_namespace) (increase(kepler_pod_cpu_joules_total{job="power-monitor",namespace="monitoring",zone=~"package|dram"}[1h])) * on (instance) group_left kepler:node_carbon_intensity:gco2e_per_kwh) / 3.6e6`,
+		group.Rules[2].Expr.StrVal)
+
+	// only node emissions are recorded without the pod metric level
+	pmi.Spec.Kepler.Config.MetricLevels = []string{"node"}
+	group = NewPowerMonitorCarbonRuleGroup(pmi, nil)
+	require.Len(t, group.Rules, 1)
+	assert.Equal(t, "kepler:node_co2e_grams:increase1h", group.Rules[0].Record)
+}
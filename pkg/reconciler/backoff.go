@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Default delays between requeues of an object
+const (
+	DefaultRequeueBaseDelay = 5 * time.Second
+	DefaultRequeueMaxDelay  = 5 * time.Minute
+	DefaultRequeueJitter    = 0.1
+)
+
+// Backoff computes the delay before an object is requeued; the delay grows
+// exponentially with the number of consecutive requeues of the object up to
+// MaxDelay. Backoff is safe for concurrent use.
+type Backoff struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Jitter is the maximum fraction of the delay randomly added to it so that
+	// objects failing together don't hit the API server together
+	Jitter float64
+
+	mu       sync.Mutex
+	requeues map[types.NamespacedName]int32
+}
+
+// NewBackoff returns a Backoff with the given delays
+func NewBackoff(baseDelay, maxDelay time.Duration, jitter float64) *Backoff {
+	return &Backoff{BaseDelay: baseDelay, MaxDelay: maxDelay, Jitter: jitter}
+}
+
+// Next records a requeue of key and returns the delay before it is reconciled
+// again. The requested delay is used if it is longer than the backoff.
+func (b *Backoff) Next(key types.NamespacedName, requested time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.requeues == nil {
+		b.requeues = map[types.NamespacedName]int32{}
+	}
+	n := b.requeues[key]
+	b.requeues[key] = n + 1
+
+	delay := b.delay(n)
+	if requested > delay {
+		return requested
+	}
+	return delay
+}
+
+// delay returns the delay after n consecutive requeues
+func (b *Backoff) delay(n int32) time.Duration {
+	delay := b.BaseDelay
+	for i := int32(0); i < n && delay < b.MaxDelay; i++ {
+		delay *= 2
+	}
+	if b.Jitter > 0 {
+		delay += time.Duration(rand.Float64() * b.Jitter * float64(delay))
+	}
+	if b.MaxDelay > 0 && delay > b.MaxDelay {
+		delay = b.MaxDelay
+	}
+	return delay
+}
+
+// Reset forgets the requeues of key once it is reconciled without a requeue
+func (b *Backoff) Reset(key types.NamespacedName) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.requeues, key)
+}
+
+// Requeues returns the number of consecutive requeues of key
+func (b *Backoff) Requeues(key types.NamespacedName) int32 {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.requeues[key]
+}
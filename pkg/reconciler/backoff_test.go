@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBackoff_Next(t *testing.T) {
+	tt := []struct {
+		name      string
+		requested time.Duration
+		expected  []time.Duration
+	}{
+		{
+			name:     "exponential up to the max delay",
+			expected: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			name:      "requested delay longer than backoff",
+			requested: 3 * time.Second,
+			expected:  []time.Duration{3 * time.Second, 3 * time.Second, 4 * time.Second, 5 * time.Second},
+		},
+		{
+			name:      "requested delay longer than max delay",
+			requested: time.Hour,
+			expected:  []time.Duration{time.Hour, time.Hour},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewBackoff(time.Second, 5*time.Second, 0)
+			key := types.NamespacedName{Name: "obj"}
+			for i, expected := range tc.expected {
+				assert.Equal(t, expected, b.Next(key, tc.requested), "requeue %d", i+1)
+			}
+			assert.Equal(t, int32(len(tc.expected)), b.Requeues(key))
+		})
+	}
+}
+
+func TestBackoff_Jitter(t *testing.T) {
+	b := NewBackoff(time.Second, time.Minute, 0.5)
+	key := types.NamespacedName{Name: "obj"}
+
+	for i := range 5 {
+		base := time.Second << i
+		delay := b.Next(key, 0)
+		assert.GreaterOrEqual(t, delay, base)
+		assert.LessOrEqual(t, delay, base+base/2)
+	}
+
+	// jitter never exceeds the max delay
+	for range 10 {
+		assert.LessOrEqual(t, b.Next(key, 0), time.Minute)
+	}
+}
+
+func TestBackoff_Reset(t *testing.T) {
+	b := NewBackoff(time.Second, time.Minute, 0)
+	k1, k2 := types.NamespacedName{Name: "one"}, types.NamespacedName{Name: "two"}
+
+	b.Next(k1, 0)
+	b.Next(k1, 0)
+	b.Next(k2, 0)
+	b.Reset(k1)
+
+	assert.Zero(t, b.Requeues(k1))
+	assert.Equal(t, int32(1), b.Requeues(k2))
+	assert.Equal(t, time.Second, b.Next(k1, 0))
+}
+
+func TestBackoff_Nil(t *testing.T) {
+	var b *Backoff
+	assert.Zero(t, b.Requeues(types.NamespacedName{Name: "obj"}))
+	assert.NotPanics(t, func() { b.Reset(types.NamespacedName{Name: "obj"}) })
+}
+
+func TestBackoff_Concurrent(t *testing.T) {
+	b := NewBackoff(time.Second, time.Minute, 0.1)
+	key := types.NamespacedName{Name: "obj"}
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.Next(key, 0)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(50), b.Requeues(key))
+}
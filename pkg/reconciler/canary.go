@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+)
+
+const (
+	// DefaultCanaryPercent is the percentage of nodes used as canary nodes if unset
+	DefaultCanaryPercent = 10
+	// DefaultCanaryBakeTime is the bake time of a canary if unset
+	DefaultCanaryBakeTime = 10 * time.Minute
+	// DefaultCanaryMaxRestarts is the number of restarts of the canary pods
+	// allowed if unset; a few restarts are tolerated since a pod may restart
+	// for reasons unrelated to the change, e.g. the eviction of the node
+	DefaultCanaryMaxRestarts = 3
+)
+
+// Rollout holds back a change of the Kepler config or image from the
+// power-monitor daemonset until a canary promotes it. A Rollout is shared by
+// the PowerMonitorDeployer, the CanaryRollout and the DaemonSetUpdater of a
+// single reconcile, which run in that order.
+type Rollout struct {
+	Policy v1alpha1.RolloutPolicy
+	// Current is the rollout status at the start of the reconcile
+	Current *v1alpha1.RolloutStatus
+	Now     time.Time
+
+	mu       sync.Mutex
+	revision string
+	cfm      *corev1.ConfigMap
+	holding  bool
+	status   *v1alpha1.RolloutStatus
+}
+
+// Status returns the rollout status after the reconcile; nil if the Kepler
+// config could not be rendered
+func (r *Rollout) Status() *v1alpha1.RolloutStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// hold records the revision rendered into cfm and returns true if it must be
+// held back from the daemonset until a canary promotes it
+func (r *Rollout) hold(revision string, cfm *corev1.ConfigMap) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revision = revision
+	r.cfm = cfm
+	// NOTE: the first revision and a revision reverted to the promoted one
+	// have nothing to roll out
+	if r.Current == nil || r.Current.PromotedRevision == "" || r.Current.PromotedRevision == revision {
+		r.status = &v1alpha1.RolloutStatus{
+			Phase:            v1alpha1.RolloutPromoted,
+			Revision:         revision,
+			PromotedRevision: revision,
+			Message:          "Kepler config and image are rolled out to all nodes",
+		}
+		if r.Current != nil && r.Current.PromotedRevision == revision {
+			r.status.StartTime = r.Current.StartTime
+		}
+		r.holding = false
+		return false
+	}
+	r.holding = true
+	return true
+}
+
+// promote marks the revision held back as promoted
+func (r *Rollout) promote(status *v1alpha1.RolloutStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status.Phase = v1alpha1.RolloutPromoted
+	status.PromotedRevision = status.Revision
+	status.Message = "Canary pods were healthy for the bake time; promoted to all nodes"
+	r.status = status
+	r.holding = false
+}
+
+func (r *Rollout) setStatus(status *v1alpha1.RolloutStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *Rollout) isHolding() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.holding
+}
+
+// holdBack keeps the Kepler config and image of the deployed daemonset in ds
+// while a revision is held back and keeps its pods off the canary nodes while
+// the canary runs; any other change of ds is applied
+func (r *Rollout) holdBack(ctx context.Context, c client.Client, ds *appsv1.DaemonSet) error {
+	if !r.isHolding() {
+		return nil
+	}
+	// NOTE: the pods on the canary nodes are replaced by the canary pods and
+	// come back once the canary is promoted or aborted
+	if status := r.Status(); status != nil && status.Phase == v1alpha1.RolloutCanary && len(status.CanaryNodes) > 0 {
+		powermonitor.ExcludeCanaryNodes(ds, status.CanaryNodes)
+	}
+	existing := &appsv1.DaemonSet{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(ds), existing); err != nil {
+		// NOTE: a daemonset deleted while a revision is held back is recreated as is
+		return client.IgnoreNotFound(err)
+	}
+
+	hashKey := powermonitor.ConfigMapHashAnnotation + "-" + ds.Name
+	if hash, ok := existing.Spec.Template.Annotations[hashKey]; ok {
+		ds.Spec.Template.Annotations[hashKey] = hash
+	}
+	for i := range ds.Spec.Template.Spec.Containers {
+		container := &ds.Spec.Template.Spec.Containers[i]
+		for _, deployed := range existing.Spec.Template.Spec.Containers {
+			if deployed.Name == container.Name {
+				container.Image = deployed.Image
+			}
+		}
+	}
+	return nil
+}
+
+// CanaryRollout runs a revision held back by the Rollout on the canary nodes
+// and promotes it once the canary pods are healthy for the bake time or
+// aborts it once they restart too often
+type CanaryRollout struct {
+	Pmi      *v1alpha1.PowerMonitorInternal
+	Ds       *appsv1.DaemonSet
+	Rollout  *Rollout
+	Recorder record.EventRecorder
+}
+
+// Reconcile implements the Reconciler interface
+func (r CanaryRollout) Reconcile(ctx context.Context, c client.Client, s *runtime.Scheme) Result {
+	rollout := r.Rollout
+	if !rollout.isHolding() {
+		return r.deleteCanary(ctx, c, s)
+	}
+
+	status := rollout.Current.DeepCopy()
+	if status.Revision != rollout.revision || status.Phase == v1alpha1.RolloutPromoted {
+		nodes, err := r.canaryNodes(ctx, c)
+		if err != nil {
+			return Result{Action: Stop, Error: fmt.Errorf("failed to select canary nodes: %w", err)}
+		}
+		startTime := metav1.NewTime(rollout.Now)
+		status = &v1alpha1.RolloutStatus{
+			Phase:            v1alpha1.RolloutCanary,
+			Revision:         rollout.revision,
+			PromotedRevision: rollout.Current.PromotedRevision,
+			CanaryNodes:      nodes,
+			StartTime:        &startTime,
+		}
+		if len(nodes) == 0 {
+			status.Phase = v1alpha1.RolloutAborted
+			status.Message = "No canary node selected; the change is not rolled out"
+			recordWarning(r.Recorder, r.Pmi, EventCanaryAborted, "Canary rollout of revision %s aborted: %s", status.Revision, status.Message)
+		} else {
+			recordNormal(r.Recorder, r.Pmi, EventCanaryStarted,
+				"Canary rollout of revision %s started on nodes %s", status.Revision, strings.Join(nodes, ", "))
+		}
+	}
+	rollout.setStatus(status)
+
+	if status.Phase == v1alpha1.RolloutAborted {
+		return r.deleteCanary(ctx, c, s)
+	}
+
+	cfm := powermonitor.NewPowerMonitorCanaryConfigMap(components.Full, r.Pmi, rollout.cfm)
+	ds := powermonitor.NewPowerMonitorCanaryDaemonSet(components.Full, r.Pmi, r.Ds, status.CanaryNodes)
+	for _, obj := range []client.Object{cfm, ds} {
+		if result := (Updater{Owner: r.Pmi, Resource: obj}).Reconcile(ctx, c, s); result.Error != nil || result.Action != Continue {
+			return result
+		}
+	}
+
+	restarts, err := r.canaryRestarts(ctx, c)
+	if err != nil {
+		return Result{Action: Stop, Error: fmt.Errorf("failed to list canary pods: %w", err)}
+	}
+	if maxRestarts := ptr.Deref(rollout.Policy.Canary.MaxRestarts, DefaultCanaryMaxRestarts); restarts > maxRestarts {
+		status.Phase = v1alpha1.RolloutAborted
+		status.Message = fmt.Sprintf("Canary pods restarted %d times; at most %d restarts are allowed", restarts, maxRestarts)
+		recordWarning(r.Recorder, r.Pmi, EventCanaryAborted, "Canary rollout of revision %s aborted: %s", status.Revision, status.Message)
+		return r.deleteCanary(ctx, c, s)
+	}
+
+	deployed := &appsv1.DaemonSet{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(ds), deployed); client.IgnoreNotFound(err) != nil {
+		return Result{Action: Stop, Error: fmt.Errorf("failed to get canary daemonset: %w", err)}
+	}
+	if !canaryReady(deployed) {
+		status.Message = fmt.Sprintf("Waiting for canary pods to be ready on %d of %d nodes",
+			deployed.Status.DesiredNumberScheduled-deployed.Status.NumberReady, deployed.Status.DesiredNumberScheduled)
+		return Result{}
+	}
+
+	bakeUntil := status.StartTime.Add(CanaryBakeTime(rollout.Policy))
+	if rollout.Now.Before(bakeUntil) {
+		status.Message = fmt.Sprintf("Canary pods are ready; baking until %s", bakeUntil.UTC().Format(time.RFC3339))
+		return Result{}
+	}
+
+	// the deployer doesn't update the configmap of a revision held back
+	if result := (Updater{Owner: r.Pmi, Resource: rollout.cfm}).Reconcile(ctx, c, s); result.Error != nil || result.Action != Continue {
+		return result
+	}
+	rollout.promote(status)
+	recordNormal(r.Recorder, r.Pmi, EventCanaryPromoted, "Canary rollout of revision %s promoted to all nodes", status.Revision)
+	return r.deleteCanary(ctx, c, s)
+}
+
+// CanaryBakeTime returns the bake time of the canary of policy
+func CanaryBakeTime(policy v1alpha1.RolloutPolicy) time.Duration {
+	if policy.Canary.BakeTime == nil {
+		return DefaultCanaryBakeTime
+	}
+	return policy.Canary.BakeTime.Duration
+}
+
+// canaryNodes returns the sorted names of the canary nodes selected among the
+// nodes running power-monitor pods
+func (r CanaryRollout) canaryNodes(ctx context.Context, c client.Client) ([]string, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(r.Ds.Namespace), client.MatchingLabels(r.Ds.Spec.Selector.MatchLabels)); err != nil {
+		return nil, err
+	}
+	running := map[string]bool{}
+	for _, p := range pods.Items {
+		if p.Spec.NodeName != "" && p.DeletionTimestamp.IsZero() {
+			running[p.Spec.NodeName] = true
+		}
+	}
+
+	canary := r.Rollout.Policy.Canary
+	if len(canary.NodeSelector) > 0 {
+		nodes := &corev1.NodeList{}
+		if err := c.List(ctx, nodes, client.MatchingLabels(canary.NodeSelector)); err != nil {
+			return nil, err
+		}
+		var selected []string
+		for _, n := range nodes.Items {
+			if running[n.Name] {
+				selected = append(selected, n.Name)
+			}
+		}
+		sort.Strings(selected)
+		return selected, nil
+	}
+
+	if len(running) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(running))
+	for name := range running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	percent := int(ptr.Deref(canary.Percent, DefaultCanaryPercent))
+	count := max((len(names)*percent+99)/100, 1)
+	return names[:count], nil
+}
+
+// canaryRestarts returns the number of container restarts of the canary pods
+func (r CanaryRollout) canaryRestarts(ctx context.Context, c client.Client) (int32, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(r.Pmi.Namespace()), client.MatchingLabels(powermonitor.CanaryPodSelector(r.Pmi))); err != nil {
+		return 0, err
+	}
+	restarts := int32(0)
+	for _, p := range pods.Items {
+		if !p.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, cs := range p.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+	}
+	return restarts, nil
+}
+
+// canaryReady returns true if the pods of the latest generation of ds are
+// ready on every node
+func canaryReady(ds *appsv1.DaemonSet) bool {
+	s := ds.Status
+	return s.ObservedGeneration >= ds.Generation &&
+		s.DesiredNumberScheduled > 0 &&
+		s.UpdatedNumberScheduled == s.DesiredNumberScheduled &&
+		s.NumberReady == s.DesiredNumberScheduled
+}
+
+// deleteCanary deletes the canary daemonset and configmap if they exist
+func (r CanaryRollout) deleteCanary(ctx context.Context, c client.Client, s *runtime.Scheme) Result {
+	for _, obj := range []client.Object{
+		powermonitor.NewPowerMonitorCanaryDaemonSet(components.Metadata, r.Pmi, nil, nil),
+		powermonitor.NewPowerMonitorCanaryConfigMap(components.Metadata, r.Pmi, nil),
+	} {
+		if err := c.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return Result{Action: Stop, Error: fmt.Errorf("failed to delete canary %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, err)}
+		}
+	}
+	return Result{}
+}
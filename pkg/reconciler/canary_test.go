@@ -0,0 +1,287 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+)
+
+func TestRolloutHold(t *testing.T) {
+	cfm := &corev1.ConfigMap{}
+
+	tt := []struct {
+		scenario string
+		current  *v1alpha1.RolloutStatus
+		hold     bool
+	}{
+		{scenario: "first revision is promoted"},
+		{
+			scenario: "promoted revision is not held back",
+			current:  &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutPromoted, Revision: "new", PromotedRevision: "new"},
+		},
+		{
+			scenario: "revision reverted during a canary is not held back",
+			current:  &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutCanary, Revision: "bad", PromotedRevision: "new"},
+		},
+		{
+			scenario: "new revision is held back",
+			current:  &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutPromoted, Revision: "old", PromotedRevision: "old"},
+			hold:     true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			r := &Rollout{Current: tc.current, Now: time.Now()}
+			assert.Equal(t, tc.hold, r.hold("new", cfm))
+			if tc.hold {
+				assert.Nil(t, r.Status(), "status is set by the canary")
+				return
+			}
+			require.NotNil(t, r.Status())
+			assert.Equal(t, v1alpha1.RolloutPromoted, r.Status().Phase)
+			assert.Equal(t, "new", r.Status().PromotedRevision)
+		})
+	}
+}
+
+func TestRolloutHoldBack(t *testing.T) {
+	pmi := canaryTestPowerMonitorInternal()
+	deployed := powermonitor.NewPowerMonitorDaemonSet(components.Full, pmi)
+	deployed.Spec.Template.Annotations = map[string]string{
+		powermonitor.ConfigMapHashAnnotation + "-" + pmi.Name: "old-hash",
+	}
+
+	pmi.Spec.Kepler.Deployment.Image = "kepler:new"
+	pmi.Spec.Kepler.Deployment.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+	ds := powermonitor.NewPowerMonitorDaemonSet(components.Full, pmi)
+	ds.Spec.Template.Annotations = map[string]string{
+		powermonitor.ConfigMapHashAnnotation + "-" + pmi.Name: "new-hash",
+	}
+
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(deployed).Build()
+	r := &Rollout{holding: true}
+	require.NoError(t, r.holdBack(context.TODO(), c, ds))
+
+	assert.Equal(t, "kepler:old", ds.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "old-hash", ds.Spec.Template.Annotations[powermonitor.ConfigMapHashAnnotation+"-"+pmi.Name])
+	assert.Equal(t, pmi.Spec.Kepler.Deployment.Tolerations, ds.Spec.Template.Spec.Tolerations,
+		"changes other than the config and image are not held back")
+	assert.Nil(t, ds.Spec.Template.Spec.Affinity, "no canary is running")
+
+	// the pods stay off the canary nodes while the canary runs
+	r.setStatus(&v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutCanary, CanaryNodes: []string{"node-a"}})
+	require.NoError(t, r.holdBack(context.TODO(), c, ds))
+	match := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0]
+	assert.Equal(t, corev1.NodeSelectorOpNotIn, match.Operator)
+	assert.Equal(t, []string{"node-a"}, match.Values)
+}
+
+func TestCanaryRollout(t *testing.T) {
+	now := time.Now()
+	pmi := canaryTestPowerMonitorInternal()
+	ds := powermonitor.NewPowerMonitorDaemonSet(components.Full, pmi)
+	cfm, err := powermonitor.NewPowerMonitorConfigMap(components.Full, pmi)
+	require.NoError(t, err)
+
+	nodes := []client.Object{}
+	for _, name := range []string{"node-d", "node-c", "node-b", "node-a"} {
+		nodes = append(nodes,
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"canary": name[len(name)-1:]}}},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "kepler-" + name, Namespace: pmi.Namespace(), Labels: ds.Spec.Selector.MatchLabels},
+				Spec:       corev1.PodSpec{NodeName: name},
+			},
+		)
+	}
+	readyCanary := powermonitor.NewPowerMonitorCanaryDaemonSet(components.Full, pmi, ds, []string{"node-a"})
+	readyCanary.Status = appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1, NumberReady: 1}
+	restartingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "canary", Namespace: pmi.Namespace(), Labels: powermonitor.CanaryPodSelector(pmi)},
+		Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 2}}},
+	}
+
+	startedAt := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-d))
+		return &t
+	}
+	promoted := &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutPromoted, Revision: "old", PromotedRevision: "old"}
+	canary := func(d time.Duration) *v1alpha1.RolloutStatus {
+		return &v1alpha1.RolloutStatus{
+			Phase: v1alpha1.RolloutCanary, Revision: powermonitor.Revision(ds, cfm), PromotedRevision: "old",
+			CanaryNodes: []string{"node-a"}, StartTime: startedAt(d),
+		}
+	}
+
+	tt := []struct {
+		scenario      string
+		policy        v1alpha1.CanaryPolicy
+		current       *v1alpha1.RolloutStatus
+		objects       []client.Object
+		phase         v1alpha1.RolloutPhase
+		canaryNodes   []string
+		canaryApplied bool
+		promoted      bool
+		events        []string
+	}{
+		{
+			scenario:      "change starts a canary on a percentage of the nodes",
+			policy:        v1alpha1.CanaryPolicy{Percent: ptr.To(int32(50))},
+			current:       promoted,
+			phase:         v1alpha1.RolloutCanary,
+			canaryNodes:   []string{"node-a", "node-b"},
+			canaryApplied: true,
+			events:        []string{EventCanaryStarted},
+		},
+		{
+			scenario:      "a canary runs on at least one node",
+			policy:        v1alpha1.CanaryPolicy{Percent: ptr.To(int32(1))},
+			current:       promoted,
+			phase:         v1alpha1.RolloutCanary,
+			canaryNodes:   []string{"node-a"},
+			canaryApplied: true,
+			events:        []string{EventCanaryStarted},
+		},
+		{
+			scenario:      "change starts a canary on the nodes selected",
+			policy:        v1alpha1.CanaryPolicy{NodeSelector: map[string]string{"canary": "c"}},
+			current:       promoted,
+			phase:         v1alpha1.RolloutCanary,
+			canaryNodes:   []string{"node-c"},
+			canaryApplied: true,
+			events:        []string{EventCanaryStarted},
+		},
+		{
+			scenario: "change is aborted without canary nodes",
+			policy:   v1alpha1.CanaryPolicy{NodeSelector: map[string]string{"canary": "none"}},
+			current:  promoted,
+			phase:    v1alpha1.RolloutAborted,
+			events:   []string{EventCanaryAborted},
+		},
+		{
+			scenario:      "canary pods not yet ready",
+			current:       canary(time.Hour),
+			phase:         v1alpha1.RolloutCanary,
+			canaryNodes:   []string{"node-a"},
+			canaryApplied: true,
+		},
+		{
+			scenario:      "ready canary bakes",
+			current:       canary(time.Minute),
+			objects:       []client.Object{readyCanary.DeepCopy()},
+			phase:         v1alpha1.RolloutCanary,
+			canaryNodes:   []string{"node-a"},
+			canaryApplied: true,
+		},
+		{
+			scenario:      "ready canary is promoted after the bake time",
+			current:       canary(time.Hour),
+			objects:       []client.Object{readyCanary.DeepCopy()},
+			phase:         v1alpha1.RolloutPromoted,
+			canaryNodes:   []string{"node-a"},
+			canaryApplied: true,
+			promoted:      true,
+			events:        []string{EventCanaryPromoted},
+		},
+		{
+			scenario:      "restarting canary is aborted",
+			policy:        v1alpha1.CanaryPolicy{MaxRestarts: ptr.To(int32(1))},
+			current:       canary(time.Hour),
+			objects:       []client.Object{readyCanary.DeepCopy(), restartingPod},
+			phase:         v1alpha1.RolloutAborted,
+			canaryNodes:   []string{"node-a"},
+			canaryApplied: true,
+			events:        []string{EventCanaryAborted},
+		},
+		{
+			scenario:      "few restarts are tolerated by default",
+			current:       canary(time.Minute),
+			objects:       []client.Object{readyCanary.DeepCopy(), restartingPod},
+			phase:         v1alpha1.RolloutCanary,
+			canaryNodes:   []string{"node-a"},
+			canaryApplied: true,
+		},
+		{
+			scenario:    "aborted change is not retried",
+			current:     &v1alpha1.RolloutStatus{Phase: v1alpha1.RolloutAborted, Revision: powermonitor.Revision(ds, cfm), PromotedRevision: "old"},
+			objects:     []client.Object{readyCanary.DeepCopy()},
+			phase:       v1alpha1.RolloutAborted,
+			canaryNodes: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			objs := append(append([]client.Object{}, nodes...), tc.objects...)
+			c := &applyRecorder{Client: fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(objs...).Build()}
+			recorder := record.NewFakeRecorder(10)
+
+			rollout := &Rollout{Policy: v1alpha1.RolloutPolicy{Canary: tc.policy}, Current: tc.current, Now: now}
+			require.True(t, rollout.hold(powermonitor.Revision(ds, cfm), cfm))
+
+			result := CanaryRollout{Pmi: pmi, Ds: ds, Rollout: rollout, Recorder: recorder}.Reconcile(context.TODO(), c, testScheme())
+			require.NoError(t, result.Error)
+			assert.Equal(t, Continue, result.Action)
+
+			status := rollout.Status()
+			require.NotNil(t, status)
+			assert.Equal(t, tc.phase, status.Phase, status.Message)
+			assert.Equal(t, tc.canaryNodes, status.CanaryNodes)
+			assert.Equal(t, tc.phase == v1alpha1.RolloutCanary || tc.phase == v1alpha1.RolloutAborted, rollout.isHolding())
+
+			applied := map[string]client.Object{}
+			for _, obj := range c.applied {
+				applied[obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName()] = obj
+			}
+			canaryDs, ok := applied["DaemonSet/"+powermonitor.CanaryName(pmi)]
+			assert.Equal(t, tc.canaryApplied, ok)
+			if ok {
+				terms := canaryDs.(*appsv1.DaemonSet).Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				assert.Equal(t, tc.canaryNodes, terms[0].MatchFields[0].Values)
+			}
+			_, ok = applied["ConfigMap/"+pmi.Name]
+			assert.Equal(t, tc.promoted, ok, "the config is deployed once promoted")
+
+			if tc.phase != v1alpha1.RolloutCanary {
+				err := c.Get(context.TODO(), client.ObjectKeyFromObject(readyCanary), &appsv1.DaemonSet{})
+				assert.True(t, apierrors.IsNotFound(err), "canary must be deleted: %v", err)
+			}
+			assertEvents(t, recorder, tc.events...)
+		})
+	}
+}
+
+func canaryTestPowerMonitorInternal() *v1alpha1.PowerMonitorInternal {
+	return &v1alpha1.PowerMonitorInternal{
+		ObjectMeta: metav1.ObjectMeta{Name: "power-monitor", UID: "pmi-uid"},
+		Spec: v1alpha1.PowerMonitorInternalSpec{
+			Kepler: v1alpha1.PowerMonitorInternalKeplerSpec{
+				Config: v1alpha1.PowerMonitorInternalKeplerConfigSpec{
+					LogLevel: "info",
+				},
+				Deployment: v1alpha1.PowerMonitorInternalKeplerDeploymentSpec{
+					Image:     "kepler:old",
+					Namespace: "power-monitor",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+)
+
+// CarbonRulesReconciler adds the carbon emission recording rules of Pmi to
+// Rule from the carbon intensities of the nodes; Rule must be updated after
+type CarbonRulesReconciler struct {
+	Pmi  *v1alpha1.PowerMonitorInternal
+	Rule *monv1.PrometheusRule
+}
+
+func (r CarbonRulesReconciler) Reconcile(ctx context.Context, c client.Client, s *runtime.Scheme) Result {
+	carbon := r.Pmi.Spec.Carbon
+	if carbon == nil {
+		return Result{}
+	}
+
+	var cm *corev1.ConfigMap
+	if carbon.ConfigMap != nil {
+		ns := r.Pmi.Namespace()
+		var err error
+		cm, err = getConfigMap(ctx, c, carbon.ConfigMap.Name, ns)
+		if err != nil {
+			return Result{
+				Action: Stop,
+				Error:  fmt.Errorf("error occurred while getting %q configmap %w", carbon.ConfigMap.Name, err),
+			}
+		}
+		if cm == nil {
+			return waitFor("configmap", carbon.ConfigMap.Name, ns, "carbon intensities are read from it")
+		}
+	}
+
+	intensities, err := powermonitor.CarbonIntensities(r.Pmi, cm)
+	if err != nil {
+		return Result{Action: Stop, Error: err}
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := c.List(ctx, nodes); err != nil {
+		return Result{Action: Stop, Error: fmt.Errorf("error occurred while listing nodes %w", err)}
+	}
+	// NOTE: the group is replaced so that the rules aren't duplicated if the step is retried
+	groups := slices.DeleteFunc(r.Rule.Spec.Groups, func(g monv1.RuleGroup) bool {
+		return g.Name == powermonitor.CarbonRuleGroup
+	})
+	r.Rule.Spec.Groups = append(groups, powermonitor.NewPowerMonitorCarbonRuleGroup(
+		r.Pmi, powermonitor.NodeCarbonIntensities(r.Pmi, nodes.Items, intensities)))
+	return Result{}
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+)
+
+func TestCarbonRulesReconciler(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "worker-1",
+		Labels: map[string]string{corev1.LabelTopologyRegion: "eu-west"},
+	}}
+	intensities := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "carbon", Namespace: "test-ns"},
+		Data:       map[string]string{"eu-west": "250"},
+	}
+
+	tt := []struct {
+		scenario string
+		carbon   *v1alpha1.CarbonSpec
+		objects  []client.Object
+		waiting  bool
+		rules    int
+	}{
+		{
+			scenario: "carbon disabled",
+			objects:  []client.Object{node},
+		},
+		{
+			scenario: "configmap missing",
+			carbon:   &v1alpha1.CarbonSpec{ConfigMap: &v1alpha1.ConfigMapRef{Name: "carbon"}},
+			objects:  []client.Object{node},
+			waiting:  true,
+		},
+		{
+			scenario: "intensities from configmap",
+			carbon:   &v1alpha1.CarbonSpec{ConfigMap: &v1alpha1.ConfigMapRef{Name: "carbon"}},
+			objects:  []client.Object{node, intensities},
+			// node intensity, node, namespace and pod emissions
+			rules: 4,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			pmi := serviceMonitorTestPMI()
+			pmi.Spec.Carbon = tc.carbon
+			c := fake.NewClientBuilder().WithScheme(serviceMonitorTestScheme()).WithObjects(tc.objects...).Build()
+			rule := &monv1.PrometheusRule{}
+
+			r := CarbonRulesReconciler{Pmi: pmi, Rule: rule}
+			result := r.Reconcile(context.TODO(), c, nil)
+			if tc.waiting {
+				var waitErr *WaitingError
+				require.ErrorAs(t, result.Error, &waitErr)
+				assert.Equal(t, "carbon", waitErr.Name)
+				assert.Equal(t, Stop, result.Action)
+				return
+			}
+			require.NoError(t, result.Error)
+			if tc.rules == 0 {
+				assert.Empty(t, rule.Spec.Groups)
+				return
+			}
+
+			// reconciling again replaces the carbon rules
+			require.NoError(t, r.Reconcile(context.TODO(), c, nil).Error)
+			require.Len(t, rule.Spec.Groups, 1)
+			assert.Equal(t, powermonitor.CarbonRuleGroup, rule.Spec.Groups[0].Name)
+			assert.Len(t, rule.Spec.Groups[0].Rules, tc.rules)
+		})
+	}
+}
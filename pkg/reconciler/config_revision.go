@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+)
+
+// ConfigRolledBackError represents an error when the Kepler pods crash-looped
+// with the config rendered from the spec and the config was rolled back
+type ConfigRolledBackError struct {
+	Revision     string
+	RolledBackTo string
+}
+
+func (e *ConfigRolledBackError) Error() string {
+	if e.RolledBackTo == "" {
+		return fmt.Sprintf("kepler pods crash-loop with config revision %s; no known-good revision to roll back to", e.Revision)
+	}
+	return fmt.Sprintf("kepler pods crash-loop with config revision %s; rolled back to revision %s", e.Revision, e.RolledBackTo)
+}
+
+// ConfigRevisionNotFoundError represents an error when the pinned config
+// revision is not kept
+type ConfigRevisionNotFoundError struct {
+	Revision  string
+	Namespace string
+}
+
+func (e *ConfigRevisionNotFoundError) Error() string {
+	return fmt.Sprintf("config revision %s not found in %s namespace", e.Revision, e.Namespace)
+}
+
+// configHistory keeps the Kepler configs rendered for a power-monitor-internal
+// as immutable ConfigMaps and picks the config to deploy out of them
+type configHistory struct {
+	pmi      *v1alpha1.PowerMonitorInternal
+	ds       *appsv1.DaemonSet
+	recorder record.EventRecorder
+}
+
+// enabled returns true if configs are kept or a revision is pinned
+func (h configHistory) enabled() bool {
+	cfg := h.pmi.Spec.Kepler.Config
+	return ptr.Deref(cfg.RevisionHistoryLimit, 0) > 0 || cfg.PinnedRevision != ""
+}
+
+// configToDeploy keeps the config rendered in cfm and returns the config to
+// deploy in its place: the pinned revision if any, else the last known-good
+// revision if the Kepler pods crash-looped with the rendered config.
+// The error returned along with a config is reported but doesn't stop the deployment
+func (h configHistory) configToDeploy(ctx context.Context, c client.Client, s *runtime.Scheme, cfm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	revisions, err := h.revisions(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("error listing config revisions: %w", err)
+	}
+	deployed, err := h.judgeDeployed(ctx, c, revisions)
+	if err != nil {
+		return nil, fmt.Errorf("error checking deployed config revision: %w", err)
+	}
+
+	// NOTE: without history, only the pinned revision is deployed, so the
+	// rendered config isn't kept; it would be pruned right after otherwise
+	keepHistory := ptr.Deref(h.pmi.Spec.Kepler.Config.RevisionHistoryLimit, 0) > 0
+	rendered := findRevision(revisions, powermonitor.ConfigRevision(cfm))
+	if rendered == nil && keepHistory {
+		rendered = powermonitor.NewPowerMonitorConfigRevision(h.pmi, cfm)
+		if err := ctrlutil.SetControllerReference(h.pmi, rendered, s); err != nil {
+			return nil, fmt.Errorf("error setting owner of config revision: %w", err)
+		}
+		if err := c.Create(ctx, rendered); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("error keeping config revision: %w", err)
+		}
+		// NOTE: the rendered config is the newest revision
+		revisions = append([]*corev1.ConfigMap{rendered}, revisions...)
+	}
+
+	active, reportErr := rendered, error(nil)
+	knownGood := lastKnownGood(revisions)
+	if pinned := h.pmi.Spec.Kepler.Config.PinnedRevision; pinned != "" {
+		if active = findRevision(revisions, pinned); active == nil {
+			return nil, &ConfigRevisionNotFoundError{Revision: pinned, Namespace: h.pmi.Namespace()}
+		}
+		// NOTE: pinning a bad revision retries it, so it is judged again once
+		// deployed; it is cleared only until then so that it isn't retried forever
+		if active.Labels[powermonitor.ConfigRevisionStateLabel] == powermonitor.ConfigRevisionBad && deployed != pinned {
+			if err := markRevision(ctx, c, active, ""); err != nil {
+				return nil, fmt.Errorf("error clearing state of pinned config revision: %w", err)
+			}
+		}
+	} else if rendered.Labels[powermonitor.ConfigRevisionStateLabel] == powermonitor.ConfigRevisionBad {
+		rolledBack := &ConfigRolledBackError{Revision: revisionOf(rendered)}
+		if knownGood != nil {
+			active = knownGood
+			rolledBack.RolledBackTo = revisionOf(knownGood)
+		}
+		reportErr = rolledBack
+	}
+
+	if err := h.prune(ctx, c, revisions, active, rendered, knownGood); err != nil {
+		return nil, fmt.Errorf("error pruning config revisions: %w", err)
+	}
+
+	deploy := cfm.DeepCopy()
+	deploy.Data = map[string]string{
+		powermonitor.KeplerConfigFile: active.Data[powermonitor.KeplerConfigFile],
+	}
+	return deploy, reportErr
+}
+
+// revisions returns the kept configs, newest first
+func (h configHistory) revisions(ctx context.Context, c client.Client) ([]*corev1.ConfigMap, error) {
+	hasRevision, err := labels.NewRequirement(powermonitor.ConfigRevisionLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := labels.NewRequirement(powermonitor.InstanceLabel, selection.Equals, []string{h.pmi.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	list := &corev1.ConfigMapList{}
+	if err := c.List(ctx, list,
+		client.InNamespace(h.pmi.Namespace()),
+		client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*hasRevision, *instance)},
+	); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*corev1.ConfigMap, 0, len(list.Items))
+	for i := range list.Items {
+		revisions = append(revisions, &list.Items[i])
+	}
+	sort.SliceStable(revisions, func(i, j int) bool {
+		ti, tj := revisions[i].CreationTimestamp, revisions[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return revisions[i].Name < revisions[j].Name
+	})
+	return revisions, nil
+}
+
+// judgeDeployed marks the revision the daemonset runs with as bad if its pods
+// crash-loop, or as known-good once it is rolled out, and returns the revision;
+// a revision is judged once
+func (h configHistory) judgeDeployed(ctx context.Context, c client.Client, revisions []*corev1.ConfigMap) (string, error) {
+	ds := &appsv1.DaemonSet{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(h.ds), ds); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+
+	deployed := ds.Spec.Template.Annotations[powermonitor.ConfigMapHashAnnotation+"-"+h.pmi.Name]
+	rev := findRevision(revisions, deployed)
+	if rev == nil || rev.Labels[powermonitor.ConfigRevisionStateLabel] != "" {
+		return deployed, nil
+	}
+
+	crashLooping, err := h.crashLooping(ctx, c, ds, deployed)
+	if err != nil {
+		return deployed, err
+	}
+
+	switch {
+	case crashLooping:
+		recordWarning(h.recorder, h.pmi, EventConfigRolledBack,
+			"Kepler pods crash-loop with config revision %s; rolling back to the last known-good revision", deployed)
+		return deployed, markRevision(ctx, c, rev, powermonitor.ConfigRevisionBad)
+	case daemonSetRolledOut(ds):
+		return deployed, markRevision(ctx, c, rev, powermonitor.ConfigRevisionKnownGood)
+	}
+	return deployed, nil
+}
+
+// crashLooping returns true if a pod of ds that runs with the config revision
+// has a container in CrashLoopBackOff
+func (h configHistory) crashLooping(ctx context.Context, c client.Client, ds *appsv1.DaemonSet, revision string) (bool, error) {
+	if ds.Spec.Selector == nil {
+		return false, nil
+	}
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods,
+		client.InNamespace(ds.Namespace),
+		client.MatchingLabels(ds.Spec.Selector.MatchLabels),
+	); err != nil {
+		return false, err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Annotations[powermonitor.ConfigMapHashAnnotation+"-"+h.pmi.Name] != revision {
+			continue
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// prune deletes the revisions beyond the history limit; the active, the
+// rendered and the last known-good revisions are always kept
+func (h configHistory) prune(ctx context.Context, c client.Client, revisions []*corev1.ConfigMap, keep ...*corev1.ConfigMap) error {
+	limit := int(ptr.Deref(h.pmi.Spec.Kepler.Config.RevisionHistoryLimit, 0))
+	for i, rev := range revisions {
+		if i < limit || containsRevision(keep, rev) {
+			continue
+		}
+		if err := c.Delete(ctx, rev); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// daemonSetRolledOut returns true if every pod of ds is updated and available
+func daemonSetRolledOut(ds *appsv1.DaemonSet) bool {
+	st := ds.Status
+	return st.ObservedGeneration >= ds.Generation &&
+		st.DesiredNumberScheduled > 0 &&
+		st.UpdatedNumberScheduled == st.DesiredNumberScheduled &&
+		st.NumberAvailable == st.DesiredNumberScheduled
+}
+
+// markRevision sets the state of the kept config rev; an empty state clears it
+func markRevision(ctx context.Context, c client.Client, rev *corev1.ConfigMap, state string) error {
+	if rev.Labels == nil {
+		rev.Labels = map[string]string{}
+	}
+	if state == "" {
+		delete(rev.Labels, powermonitor.ConfigRevisionStateLabel)
+	} else {
+		rev.Labels[powermonitor.ConfigRevisionStateLabel] = state
+	}
+	return c.Update(ctx, rev)
+}
+
+func revisionOf(rev *corev1.ConfigMap) string {
+	return rev.Labels[powermonitor.ConfigRevisionLabel]
+}
+
+func findRevision(revisions []*corev1.ConfigMap, revision string) *corev1.ConfigMap {
+	for _, rev := range revisions {
+		if revision != "" && revisionOf(rev) == revision {
+			return rev
+		}
+	}
+	return nil
+}
+
+func lastKnownGood(revisions []*corev1.ConfigMap) *corev1.ConfigMap {
+	for _, rev := range revisions {
+		if rev.Labels[powermonitor.ConfigRevisionStateLabel] == powermonitor.ConfigRevisionKnownGood {
+			return rev
+		}
+	}
+	return nil
+}
+
+func containsRevision(revisions []*corev1.ConfigMap, rev *corev1.ConfigMap) bool {
+	for _, r := range revisions {
+		if r != nil && r.Name == rev.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
+)
+
+func TestConfigHistory(t *testing.T) {
+	now := time.Now()
+	pmi := canaryTestPowerMonitorInternal()
+	pmi.Spec.Kepler.Config.RevisionHistoryLimit = ptr.To(int32(2))
+	hashKey := powermonitor.ConfigMapHashAnnotation + "-" + pmi.Name
+
+	rendered, err := powermonitor.NewPowerMonitorConfigMap(components.Full, pmi)
+	require.NoError(t, err)
+	renderedRev := powermonitor.ConfigRevision(rendered)
+
+	// revision returns a kept config created age ago in the given state
+	revision := func(data string, age time.Duration, state string) *corev1.ConfigMap {
+		rev := powermonitor.NewPowerMonitorConfigRevision(pmi, &corev1.ConfigMap{
+			Data: map[string]string{powermonitor.KeplerConfigFile: data},
+		})
+		rev.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		if state != "" {
+			rev.Labels[powermonitor.ConfigRevisionStateLabel] = state
+		}
+		return rev
+	}
+	renderedRevision := func(state string) *corev1.ConfigMap {
+		rev := powermonitor.NewPowerMonitorConfigRevision(pmi, rendered)
+		rev.CreationTimestamp = metav1.NewTime(now)
+		if state != "" {
+			rev.Labels[powermonitor.ConfigRevisionStateLabel] = state
+		}
+		return rev
+	}
+	good := revision("good", time.Hour, powermonitor.ConfigRevisionKnownGood)
+	goodRev := revisionOf(good)
+
+	// deployed returns the daemonset running with the config revision
+	deployed := func(revision string, rolledOut bool) *appsv1.DaemonSet {
+		ds := powermonitor.NewPowerMonitorDaemonSet(components.Full, pmi)
+		ds.Spec.Template.Annotations = map[string]string{hashKey: revision}
+		if rolledOut {
+			ds.Status = appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1, NumberAvailable: 1}
+		}
+		return ds
+	}
+	crashLooping := func(revision string) *corev1.Pod {
+		ds := powermonitor.NewPowerMonitorDaemonSet(components.Full, pmi)
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kepler", Namespace: pmi.Namespace(),
+				Labels:      ds.Spec.Selector.MatchLabels,
+				Annotations: map[string]string{hashKey: revision},
+			},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}}},
+		}
+	}
+
+	bad := revision("bad", time.Minute, powermonitor.ConfigRevisionBad)
+	badRev := revisionOf(bad)
+
+	tt := []struct {
+		scenario   string
+		pinned     string
+		limit      *int32
+		objects    []client.Object
+		deployed   string
+		kept       map[string]string
+		rolledBack *ConfigRolledBackError
+		notFound   bool
+		events     []string
+	}{
+		{
+			scenario: "rendered config is kept",
+			deployed: rendered.Data[powermonitor.KeplerConfigFile],
+			kept:     map[string]string{renderedRev: ""},
+		},
+		{
+			scenario: "rolled out revision is known-good",
+			objects:  []client.Object{renderedRevision(""), deployed(renderedRev, true)},
+			deployed: rendered.Data[powermonitor.KeplerConfigFile],
+			kept:     map[string]string{renderedRev: powermonitor.ConfigRevisionKnownGood},
+		},
+		{
+			scenario: "crash-looping revision is rolled back to the last known-good revision",
+			objects: []client.Object{
+				good, renderedRevision(""), deployed(renderedRev, false), crashLooping(renderedRev),
+			},
+			deployed: "good",
+			kept: map[string]string{
+				renderedRev: powermonitor.ConfigRevisionBad,
+				goodRev:     powermonitor.ConfigRevisionKnownGood,
+			},
+			rolledBack: &ConfigRolledBackError{Revision: renderedRev, RolledBackTo: goodRev},
+			events:     []string{EventConfigRolledBack},
+		},
+		{
+			scenario:   "bad revision is deployed without a known-good revision",
+			objects:    []client.Object{renderedRevision(powermonitor.ConfigRevisionBad)},
+			deployed:   rendered.Data[powermonitor.KeplerConfigFile],
+			kept:       map[string]string{renderedRev: powermonitor.ConfigRevisionBad},
+			rolledBack: &ConfigRolledBackError{Revision: renderedRev},
+		},
+		{
+			scenario: "judged revision is not judged again",
+			objects: []client.Object{
+				good, renderedRevision(""), deployed(goodRev, false), crashLooping(goodRev),
+			},
+			deployed: rendered.Data[powermonitor.KeplerConfigFile],
+			kept: map[string]string{
+				renderedRev: "",
+				goodRev:     powermonitor.ConfigRevisionKnownGood,
+			},
+		},
+		{
+			scenario: "pinned revision is deployed",
+			pinned:   goodRev,
+			objects:  []client.Object{good},
+			deployed: "good",
+			kept: map[string]string{
+				renderedRev: "",
+				goodRev:     powermonitor.ConfigRevisionKnownGood,
+			},
+		},
+		{
+			scenario: "pinned revision without history keeps only the pinned revision",
+			pinned:   goodRev,
+			limit:    ptr.To(int32(0)),
+			objects:  []client.Object{good},
+			deployed: "good",
+			kept:     map[string]string{goodRev: powermonitor.ConfigRevisionKnownGood},
+		},
+		{
+			scenario: "pinning a bad revision clears its state",
+			pinned:   badRev,
+			objects:  []client.Object{bad, deployed(renderedRev, true)},
+			deployed: "bad",
+			kept:     map[string]string{renderedRev: "", badRev: ""},
+		},
+		{
+			scenario: "pinned bad revision that crash-loops again stays bad",
+			pinned:   badRev,
+			objects:  []client.Object{bad, deployed(badRev, false), crashLooping(badRev)},
+			deployed: "bad",
+			kept:     map[string]string{renderedRev: "", badRev: powermonitor.ConfigRevisionBad},
+		},
+		{
+			scenario: "pinned revision that isn't kept",
+			pinned:   "missing",
+			notFound: true,
+		},
+		{
+			scenario: "revisions beyond the limit are pruned except the last known-good",
+			objects: []client.Object{
+				good,
+				revision("older", 2*time.Minute, ""),
+				revision("old", time.Minute, ""),
+			},
+			deployed: rendered.Data[powermonitor.KeplerConfigFile],
+			kept: map[string]string{
+				renderedRev:                        "",
+				revisionOf(revision("old", 0, "")): "",
+				goodRev:                            powermonitor.ConfigRevisionKnownGood,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			pmi := pmi.DeepCopy()
+			pmi.Spec.Kepler.Config.PinnedRevision = tc.pinned
+			if tc.limit != nil {
+				pmi.Spec.Kepler.Config.RevisionHistoryLimit = tc.limit
+			}
+			objects := make([]client.Object, 0, len(tc.objects))
+			for _, obj := range tc.objects {
+				objects = append(objects, obj.DeepCopyObject().(client.Object))
+			}
+			c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(objects...).Build()
+			recorder := record.NewFakeRecorder(10)
+
+			h := configHistory{pmi: pmi, ds: powermonitor.NewPowerMonitorDaemonSet(components.Full, pmi), recorder: recorder}
+			require.True(t, h.enabled())
+			active, err := h.configToDeploy(context.TODO(), c, testScheme(), rendered.DeepCopy())
+
+			if tc.notFound {
+				assert.Nil(t, active)
+				var notFound *ConfigRevisionNotFoundError
+				assert.ErrorAs(t, err, &notFound)
+				return
+			}
+			if tc.rolledBack != nil {
+				assert.Equal(t, tc.rolledBack, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NotNil(t, active)
+			assert.Equal(t, rendered.Name, active.Name)
+			assert.Equal(t, tc.deployed, active.Data[powermonitor.KeplerConfigFile])
+
+			list := &corev1.ConfigMapList{}
+			require.NoError(t, c.List(context.TODO(), list, client.HasLabels{powermonitor.ConfigRevisionLabel}))
+			kept := map[string]string{}
+			for _, cm := range list.Items {
+				kept[cm.Labels[powermonitor.ConfigRevisionLabel]] = cm.Labels[powermonitor.ConfigRevisionStateLabel]
+			}
+			assert.Equal(t, tc.kept, kept)
+			assertEvents(t, recorder, tc.events...)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,12 +20,24 @@ type Deleter struct {
 	Resource    client.Object
 	OnError     Action
 	WaitTimeout time.Duration
+	// RequeueAfter, if set, makes the deleter return OnError with the delay
+	// while Resource exists instead of waiting for it to be gone; waiting
+	// blocks the worker running the reconcile, which matters for objects with
+	// finalizers that take long
+	RequeueAfter time.Duration
+	// PropagationPolicy, if set, controls how the dependents of Resource are deleted
+	PropagationPolicy metav1.DeletionPropagation
 }
 
 func (r Deleter) Reconcile(ctx context.Context, c client.Client, scheme *runtime.Scheme) Result {
 	objKey := client.ObjectKeyFromObject(r.Resource)
 
-	if err := c.Delete(ctx, r.Resource); client.IgnoreNotFound(err) != nil {
+	opts := []client.DeleteOption{}
+	if r.PropagationPolicy != "" {
+		opts = append(opts, client.PropagationPolicy(r.PropagationPolicy))
+	}
+
+	if err := c.Delete(ctx, r.Resource, opts...); client.IgnoreNotFound(err) != nil {
 		return Result{
 			Error:  r.error("failed to delete", err),
 			Action: r.OnError,
@@ -33,6 +46,20 @@ func (r Deleter) Reconcile(ctx context.Context, c client.Client, scheme *runtime
 
 	dup := r.Resource.DeepCopyObject().(client.Object)
 
+	if r.RequeueAfter > 0 {
+		err := c.Get(ctx, objKey, dup)
+		switch {
+		case errors.IsNotFound(err):
+			return Result{}
+		case err != nil:
+			return Result{
+				Error:  r.error("failed to get", err),
+				Action: r.OnError,
+			}
+		}
+		return Result{Action: r.OnError, RequeueAfter: r.RequeueAfter}
+	}
+
 	timeout := max(r.WaitTimeout, 60*time.Second)
 	err := wait.PollUntilContextTimeout(ctx, 5*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 		err := c.Get(ctx, objKey, dup)
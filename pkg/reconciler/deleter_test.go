@@ -6,6 +6,7 @@ package reconciler
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,6 +14,7 @@ import (
 	"golang.org/x/net/context"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 
@@ -52,3 +54,29 @@ func TestDeleterReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleterRequeuesUntilDeleted(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(testScheme))
+
+	// NOTE: the finalizer keeps the object around once it is deleted
+	blocked := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "blocked", Namespace: "ns", Finalizers: []string{"test/finalizer"},
+	}}
+	deleted := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "ns"}}
+	c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(blocked, deleted).Build()
+
+	result := Deleter{Resource: blocked, OnError: Requeue, RequeueAfter: 5 * time.Second}.Reconcile(context.TODO(), c, testScheme)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, Requeue, result.Action)
+	assert.Equal(t, 5*time.Second, result.RequeueAfter)
+
+	// a best-effort deletion doesn't wait for the object to be gone
+	result = Deleter{Resource: blocked, OnError: Continue, RequeueAfter: 5 * time.Second}.Reconcile(context.TODO(), c, testScheme)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, Continue, result.Action)
+
+	result = Deleter{Resource: deleted, OnError: Requeue, RequeueAfter: 5 * time.Second}.Reconcile(context.TODO(), c, testScheme)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, Continue, result.Action)
+}
@@ -0,0 +1,279 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// FieldManager is the field manager of the objects applied by the operator
+const FieldManager = "kepler-operator"
+
+// Drift describes the fields of an object applied by the operator that were
+// changed by other field managers since it was last applied
+type Drift struct {
+	GVK       schema.GroupVersionKind
+	Namespace string
+	Name      string
+	// Managers are the field managers that changed the fields
+	Managers []string
+	// Fields are the paths of the fields changed
+	Fields []string
+	// Reverted is true if the object was applied again, reverting the drift
+	Reverted bool
+
+	// paths are the fields changed; they are left out of the object applied
+	// when the drift is only reported
+	paths []fieldpath.Path
+}
+
+// DriftDetector collects the drift found by the Updaters of a reconcile
+// before they apply objects; it is safe for concurrent use
+type DriftDetector struct {
+	// ReportOnly leaves the drifted fields of objects as is instead of
+	// reverting them; the other fields are still applied
+	ReportOnly bool
+
+	mu     sync.Mutex
+	drifts []Drift
+}
+
+// Drifts returns the drift found so far sorted by kind, namespace and name
+func (d *DriftDetector) Drifts() []Drift {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	drifts := slices.Clone(d.drifts)
+	slices.SortFunc(drifts, func(a, b Drift) int {
+		return strings.Compare(
+			a.GVK.Kind+"/"+a.Namespace+"/"+a.Name,
+			b.GVK.Kind+"/"+b.Namespace+"/"+b.Name)
+	})
+	return drifts
+}
+
+func (d *DriftDetector) record(drift Drift) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.drifts = append(d.drifts, drift)
+}
+
+type driftDetectorKey struct{}
+
+// WithDriftDetector returns a context that makes the Updaters run with it
+// record the drift of the objects they apply in d
+func WithDriftDetector(ctx context.Context, d *DriftDetector) context.Context {
+	return context.WithValue(ctx, driftDetectorKey{}, d)
+}
+
+func driftDetectorFrom(ctx context.Context) *DriftDetector {
+	d, _ := ctx.Value(driftDetectorKey{}).(*DriftDetector)
+	return d
+}
+
+// detectDrift returns the drift of the existing object of desired or nil if
+// it doesn't exist or wasn't changed by other field managers
+func detectDrift(ctx context.Context, c client.Client, s *runtime.Scheme, desired client.Object) (*Drift, error) {
+	gvk, err := apiutil.GVKForObject(desired, s)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	existing, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an object", gvk)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, err
+	}
+	fields := fieldpath.SetFromValue(value.NewValueInterface(u))
+
+	// fields set by the operator that no other field manager changed since
+	owned := fieldpath.NewSet()
+	for _, f := range existing.GetManagedFields() {
+		if f.Manager != FieldManager || f.Subresource != "" || f.FieldsV1 == nil {
+			continue
+		}
+		set, err := fieldSet(f)
+		if err != nil {
+			return nil, err
+		}
+		owned = owned.Union(set)
+	}
+
+	drift := Drift{GVK: gvk, Namespace: desired.GetNamespace(), Name: desired.GetName()}
+	for _, f := range existing.GetManagedFields() {
+		if f.Manager == FieldManager || f.Subresource != "" || f.FieldsV1 == nil {
+			continue
+		}
+		managed, err := fieldSet(f)
+		if err != nil {
+			return nil, err
+		}
+		changed := driftedFields(managed, fields, owned)
+		if len(changed) == 0 {
+			continue
+		}
+		if !slices.Contains(drift.Managers, f.Manager) {
+			drift.Managers = append(drift.Managers, f.Manager)
+		}
+		for _, p := range changed {
+			if !slices.Contains(drift.Fields, p.String()) {
+				drift.Fields = append(drift.Fields, p.String())
+				drift.paths = append(drift.paths, p)
+			}
+		}
+	}
+
+	if len(drift.Fields) == 0 {
+		return nil, nil
+	}
+	slices.Sort(drift.Managers)
+	slices.Sort(drift.Fields)
+	return &drift, nil
+}
+
+// driftedFields returns the fields of desired in managed that the operator
+// no longer owns, i.e. the fields whose value another field manager changed
+func driftedFields(managed, desired, owned *fieldpath.Set) []fieldpath.Path {
+	var fields []fieldpath.Path
+	managed.Leaves().Iterate(func(p fieldpath.Path) {
+		if owned.Has(p) || !overlaps(desired, p) {
+			return
+		}
+		fields = append(fields, p.Copy())
+	})
+	return fields
+}
+
+// overlaps returns true if p, any of its parents or any of its children is
+// a field of set; lists that are atomic, such as args, are a single field in
+// managed fields but a field per item in a set built from a value
+func overlaps(set *fieldpath.Set, p fieldpath.Path) bool {
+	for i := 1; i <= len(p); i++ {
+		if set.Has(p[:i]) {
+			return true
+		}
+	}
+	for _, pe := range p {
+		child, ok := set.Children.Get(pe)
+		if !ok {
+			return false
+		}
+		set = child
+	}
+	return !set.Empty()
+}
+
+// withoutDrift returns desired as an unstructured object without the fields
+// of drift, so that applying it leaves them as the other field managers set them
+func withoutDrift(desired client.Object, s *runtime.Scheme, drift *Drift) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(desired, s)
+	if err != nil {
+		return nil, err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range drift.paths {
+		removeField(u, p)
+	}
+	obj := &unstructured.Unstructured{Object: u}
+	obj.SetGroupVersionKind(gvk)
+	return obj, nil
+}
+
+// removeField removes the field at p from v, a map or list of an unstructured
+// object, and returns v; v is left as is if the field doesn't exist
+func removeField(v any, p fieldpath.Path) any {
+	if len(p) == 0 {
+		return v
+	}
+	pe := p[0]
+	if pe.FieldName != nil {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		child, ok := m[*pe.FieldName]
+		switch {
+		case !ok:
+		case len(p) == 1:
+			delete(m, *pe.FieldName)
+		default:
+			m[*pe.FieldName] = removeField(child, p[1:])
+		}
+		return m
+	}
+
+	l, ok := v.([]any)
+	if !ok {
+		return v
+	}
+	for i, item := range l {
+		if !matchesItem(item, i, pe) {
+			continue
+		}
+		if len(p) == 1 {
+			return slices.Delete(l, i, i+1)
+		}
+		l[i] = removeField(item, p[1:])
+		break
+	}
+	return l
+}
+
+// matchesItem returns true if pe is the path element of item, the i-th item of a list
+func matchesItem(item any, i int, pe fieldpath.PathElement) bool {
+	switch {
+	case pe.Index != nil:
+		return *pe.Index == i
+	case pe.Value != nil:
+		return value.Equals(value.NewValueInterface(item), *pe.Value)
+	case pe.Key != nil:
+		m, ok := item.(map[string]any)
+		if !ok {
+			return false
+		}
+		for _, f := range *pe.Key {
+			if !value.Equals(value.NewValueInterface(m[f.Name]), f.Value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// fieldSet returns the fields managed by f
+func fieldSet(f metav1.ManagedFieldsEntry) (*fieldpath.Set, error) {
+	set := &fieldpath.Set{}
+	if err := set.FromJSON(bytes.NewReader(f.FieldsV1.Raw)); err != nil {
+		return nil, fmt.Errorf("invalid managed fields of %s: %w", f.Manager, err)
+	}
+	return set, nil
+}
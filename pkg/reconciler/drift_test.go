@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// managedFields returns a managed fields entry of manager for the fields in json
+func managedFields(manager string, op metav1.ManagedFieldsOperationType, json string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  op,
+		APIVersion: "v1",
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(json)},
+	}
+}
+
+func TestDetectDrift(t *testing.T) {
+	scheme, _ := testSetup(t)
+
+	configMap := func(managed ...metav1.ManagedFieldsEntry) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns", ManagedFields: managed},
+			Data:       map[string]string{"a": "1", "b": "2"},
+		}
+	}
+	daemonSet := func(managed ...metav1.ManagedFieldsEntry) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "ns", ManagedFields: managed},
+			Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "kepler", Image: "kepler", Args: []string{"--a"}}},
+			}}},
+		}
+	}
+
+	tt := []struct {
+		scenario string
+		existing client.Object
+		desired  client.Object
+		drift    *Drift
+	}{
+		{
+			scenario: "missing object",
+			desired:  configMap(),
+		},
+		{
+			scenario: "no other field manager",
+			existing: configMap(managedFields(FieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{"f:a":{},"f:b":{}}}`)),
+			desired:  configMap(),
+		},
+		{
+			scenario: "field changed by another manager",
+			existing: configMap(
+				managedFields(FieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{"f:a":{}}}`),
+				managedFields("kubectl-edit", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:b":{}}}`),
+			),
+			desired: configMap(),
+			drift:   &Drift{Managers: []string{"kubectl-edit"}, Fields: []string{".data.b"}},
+		},
+		{
+			scenario: "field shared with another manager",
+			existing: configMap(
+				managedFields(FieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{"f:a":{},"f:b":{}}}`),
+				managedFields("kubectl-edit", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:b":{}}}`),
+			),
+			desired: configMap(),
+		},
+		{
+			scenario: "field not set by the operator",
+			existing: configMap(
+				managedFields(FieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{"f:a":{},"f:b":{}}}`),
+				managedFields("kubectl", metav1.ManagedFieldsOperationUpdate, `{"f:metadata":{"f:annotations":{"f:note":{}}}}`),
+			),
+			desired: configMap(),
+		},
+		{
+			scenario: "atomic list changed by another manager",
+			existing: daemonSet(
+				managedFields("policy-engine", metav1.ManagedFieldsOperationUpdate,
+					`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"kepler\"}":{"f:args":{}}}}}}}`),
+			),
+			desired: daemonSet(),
+			drift: &Drift{
+				Managers: []string{"policy-engine"},
+				Fields:   []string{`.spec.template.spec.containers[name="kepler"].args`},
+			},
+		},
+		{
+			scenario: "status is ignored",
+			existing: func() client.Object {
+				status := managedFields("kube-controller-manager", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:b":{}}}`)
+				status.Subresource = "status"
+				return configMap(status)
+			}(),
+			desired: configMap(),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			b := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.existing != nil {
+				b = b.WithObjects(tc.existing)
+			}
+			c := b.Build()
+
+			drift, err := detectDrift(context.TODO(), c, scheme, tc.desired)
+			require.NoError(t, err)
+			if tc.drift == nil {
+				assert.Nil(t, drift)
+				return
+			}
+			require.NotNil(t, drift)
+			assert.Equal(t, tc.desired.GetName(), drift.Name)
+			assert.Equal(t, tc.drift.Managers, drift.Managers)
+			assert.Equal(t, tc.drift.Fields, drift.Fields)
+		})
+	}
+}
+
+func TestUpdaterDrift(t *testing.T) {
+	scheme, _ := testSetup(t)
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns", ManagedFields: []metav1.ManagedFieldsEntry{
+			managedFields("kubectl-edit", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:a":{}}}`),
+		}},
+		Data: map[string]string{"a": "edited"},
+	}
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+		Data:       map[string]string{"a": "1"},
+	}
+
+	for _, reportOnly := range []bool{false, true} {
+		c := &applyRecorder{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()}
+		d := &DriftDetector{ReportOnly: reportOnly}
+		ctx := WithDriftDetector(context.TODO(), d)
+
+		result := Updater{Resource: desired.DeepCopy(), Logger: logr.Discard()}.Reconcile(ctx, c, scheme)
+		require.NoError(t, result.Error)
+		assert.Equal(t, Continue, result.Action)
+
+		drifts := d.Drifts()
+		require.Len(t, drifts, 1)
+		assert.Equal(t, "ConfigMap", drifts[0].GVK.Kind)
+		assert.Equal(t, []string{".data.a"}, drifts[0].Fields)
+		assert.Equal(t, !reportOnly, drifts[0].Reverted)
+
+		// drift is reverted by applying the object again unless only reported
+		require.Len(t, c.applied, 1)
+		_, reverted := appliedField(t, c.applied[0], "data", "a")
+		assert.Equal(t, !reportOnly, reverted)
+	}
+}
+
+// appliedField returns the field at path of an object applied
+func appliedField(t *testing.T, obj client.Object, path ...string) (any, bool) {
+	t.Helper()
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err)
+	v, ok, err := unstructured.NestedFieldNoCopy(u, path...)
+	require.NoError(t, err)
+	return v, ok
+}
+
+func TestUpdaterReportOnlyAppliesSpecChanges(t *testing.T) {
+	scheme, _ := testSetup(t)
+	kepler := `"k:{\"name\":\"kepler\"}"`
+
+	tt := []struct {
+		scenario string
+		existing client.Object
+		desired  client.Object
+		kept     []string
+		changed  []string
+		want     any
+	}{
+		{
+			scenario: "field of a map",
+			existing: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns", ManagedFields: []metav1.ManagedFieldsEntry{
+					managedFields(FieldManager, metav1.ManagedFieldsOperationApply, `{"f:data":{"f:b":{}}}`),
+					managedFields("kubectl-edit", metav1.ManagedFieldsOperationUpdate, `{"f:data":{"f:a":{}}}`),
+				}},
+				Data: map[string]string{"a": "edited", "b": "1"},
+			},
+			desired: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+				Data:       map[string]string{"a": "1", "b": "2"},
+			},
+			kept:    []string{"data", "a"},
+			changed: []string{"data", "b"},
+			want:    "2",
+		},
+		{
+			scenario: "field of a list item",
+			existing: &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "ns", ManagedFields: []metav1.ManagedFieldsEntry{
+					managedFields(FieldManager, metav1.ManagedFieldsOperationApply,
+						`{"f:spec":{"f:template":{"f:spec":{"f:containers":{`+kepler+`:{"f:image":{}}}}}}}`),
+					managedFields("policy-engine", metav1.ManagedFieldsOperationUpdate,
+						`{"f:spec":{"f:template":{"f:spec":{"f:containers":{`+kepler+`:{"f:args":{}}}}}}}`),
+				}},
+				Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "kepler", Image: "kepler:v1", Args: []string{"--debug"}}},
+				}}},
+			},
+			desired: &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "ns"},
+				Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "kepler", Image: "kepler:v2", Args: []string{"--a"}}},
+				}}},
+			},
+			kept:    []string{"args"},
+			changed: []string{"image"},
+			want:    "kepler:v2",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			c := &applyRecorder{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.existing).Build()}
+			d := &DriftDetector{ReportOnly: true}
+
+			result := Updater{Resource: tc.desired, Logger: logr.Discard()}.Reconcile(WithDriftDetector(context.TODO(), d), c, scheme)
+			require.NoError(t, result.Error)
+			require.Len(t, d.Drifts(), 1)
+			require.Len(t, c.applied, 1, "object is applied although it drifted")
+
+			applied := c.applied[0]
+			if ds, ok := applied.(*unstructured.Unstructured); ok && ds.GetKind() == "DaemonSet" {
+				containers, found, err := unstructured.NestedSlice(ds.Object, "spec", "template", "spec", "containers")
+				require.NoError(t, err)
+				require.True(t, found)
+				require.Len(t, containers, 1)
+				applied = &unstructured.Unstructured{Object: containers[0].(map[string]any)}
+			}
+			_, ok := appliedField(t, applied, tc.kept...)
+			assert.False(t, ok, "drifted field is left out of the object applied")
+			v, ok := appliedField(t, applied, tc.changed...)
+			require.True(t, ok)
+			assert.Equal(t, tc.want, v, "change of the spec is applied")
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the events emitted by the reconcilers
+const (
+	EventConfigMapUpdated = "ConfigMapUpdated"
+	EventConfigFallback   = "ConfigFallback"
+	EventRolloutTriggered = "RolloutTriggered"
+	EventSecretNotFound   = "SecretNotFound"
+	EventTokenRotated     = "TokenRotated"
+	EventTokenExpired     = "TokenExpired"
+	EventFinalizerAdded   = "FinalizerAdded"
+	EventFinalizerRemoved = "FinalizerRemoved"
+	EventDeletionTimedOut = "DeletionTimedOut"
+	EventDeletionForced   = "DeletionForced"
+	EventResourcePruned   = "ResourcePruned"
+	EventPruneDryRun      = "PruneDryRun"
+	EventDriftDetected    = "DriftDetected"
+	EventReconcilePaused  = "ReconcilePaused"
+	EventReconcileResumed = "ReconcileResumed"
+	EventCanaryStarted    = "CanaryStarted"
+	EventCanaryPromoted   = "CanaryPromoted"
+	EventCanaryAborted    = "CanaryAborted"
+	EventConfigRolledBack = "ConfigRolledBack"
+)
+
+// recordEvent emits an event on obj if a recorder is set
+func recordEvent(recorder record.EventRecorder, obj runtime.Object, eventType, reason, msgFmt string, args ...any) {
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(obj, eventType, reason, msgFmt, args...)
+}
+
+func recordNormal(recorder record.EventRecorder, obj runtime.Object, reason, msgFmt string, args ...any) {
+	recordEvent(recorder, obj, corev1.EventTypeNormal, reason, msgFmt, args...)
+}
+
+func recordWarning(recorder record.EventRecorder, obj runtime.Object, reason, msgFmt string, args ...any) {
+	recordEvent(recorder, obj, corev1.EventTypeWarning, reason, msgFmt, args...)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -19,6 +20,7 @@ type Finalizer struct {
 	Resource  client.Object
 	Finalizer string
 	Logger    logr.Logger
+	Recorder  record.EventRecorder
 }
 
 func (r Finalizer) Reconcile(ctx context.Context, c client.Client, s *runtime.Scheme) Result {
@@ -47,6 +49,9 @@ func (r Finalizer) Reconcile(ctx context.Context, c client.Client, s *runtime.Sc
 
 		ctrlutil.RemoveFinalizer(refreshed, r.Finalizer)
 		err := c.Update(ctx, refreshed)
+		if err == nil {
+			recordNormal(r.Recorder, refreshed, EventFinalizerRemoved, "Removed finalizer %s", r.Finalizer)
+		}
 		return Result{Error: err, Action: Stop}
 	}
 
@@ -55,6 +60,9 @@ func (r Finalizer) Reconcile(ctx context.Context, c client.Client, s *runtime.Sc
 
 		ctrlutil.AddFinalizer(refreshed, r.Finalizer)
 		err := c.Update(ctx, refreshed)
+		if err == nil {
+			recordNormal(r.Recorder, refreshed, EventFinalizerAdded, "Added finalizer %s", r.Finalizer)
+		}
 		return Result{Error: err, Action: Stop}
 	}
 
@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -82,6 +83,7 @@ func TestFinalizerReconcile(t *testing.T) {
 		expectedAction  Action
 		expectedError   bool
 		expectFinalizer bool
+		expectedEvent   string
 	}{
 		{
 			name: "add finalizer to new ConfigMap",
@@ -91,6 +93,7 @@ func TestFinalizerReconcile(t *testing.T) {
 			expectedAction:  Stop,
 			expectedError:   false,
 			expectFinalizer: true,
+			expectedEvent:   EventFinalizerAdded,
 		},
 		{
 			name: "add finalizer to new PowerMonitorInternal",
@@ -100,6 +103,7 @@ func TestFinalizerReconcile(t *testing.T) {
 			expectedAction:  Stop,
 			expectedError:   false,
 			expectFinalizer: true,
+			expectedEvent:   EventFinalizerAdded,
 		},
 		{
 			name: "object already has finalizer - no action needed",
@@ -124,6 +128,7 @@ func TestFinalizerReconcile(t *testing.T) {
 			expectedAction:  Stop,
 			expectedError:   false,
 			expectFinalizer: false,
+			expectedEvent:   EventFinalizerRemoved,
 		},
 	}
 
@@ -131,11 +136,13 @@ func TestFinalizerReconcile(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			obj := tt.setupObject()
 			testClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(obj).Build()
+			recorder := record.NewFakeRecorder(10)
 
 			finalizer := Finalizer{
 				Resource:  obj.DeepCopyObject().(client.Object),
 				Finalizer: testFinalizerName,
 				Logger:    logr.Discard(),
+				Recorder:  recorder,
 			}
 
 			result := finalizer.Reconcile(context.TODO(), testClient, scheme)
@@ -146,6 +153,7 @@ func TestFinalizerReconcile(t *testing.T) {
 			} else {
 				assert.NoError(t, result.Error)
 			}
+			assertEvents(t, recorder, tt.expectedEvent)
 
 			// Verify finalizer state in the cluster
 			// Note: For deleted objects, after finalizer removal, the object might be gone from the fake client
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// DefaultParallelism is the default number of independent steps run concurrently
+const DefaultParallelism = 4
+
+// Step is a node of a dependency graph of reconcilers. A step runs once all
+// the steps it depends on have completed; steps that don't depend on each
+// other may run concurrently.
+type Step struct {
+	// Name identifies the step in the graph and must be unique
+	Name       string
+	Reconciler Reconciler
+	// DependsOn lists the names of the steps that must complete first
+	DependsOn []string
+}
+
+// Sequential returns steps that run reconcilers one after the other in order
+func Sequential(reconcilers ...Reconciler) []Step {
+	steps := make([]Step, 0, len(reconcilers))
+	for i, r := range reconcilers {
+		step := Step{Name: strconv.Itoa(i), Reconciler: r}
+		if i > 0 {
+			step.DependsOn = []string{strconv.Itoa(i - 1)}
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// ValidateSteps returns an error if the names of steps aren't unique, if a
+// step depends on an unknown step or if steps depend on each other in a cycle
+func ValidateSteps(steps []Step) error {
+	_, err := newGraph(steps)
+	return err
+}
+
+// graph is the validated form of steps where steps are referred to by their index
+type graph struct {
+	steps []Step
+	// deps is the number of steps each step depends on
+	deps []int
+	// dependents lists the steps that depend on each step
+	dependents [][]int
+}
+
+// newGraph validates that the names of steps are unique, that their
+// dependencies exist and that they don't form a cycle
+func newGraph(steps []Step) (*graph, error) {
+	index := make(map[string]int, len(steps))
+	for i, s := range steps {
+		if _, ok := index[s.Name]; ok {
+			return nil, fmt.Errorf("duplicate reconcile step %q", s.Name)
+		}
+		index[s.Name] = i
+	}
+
+	g := &graph{
+		steps:      steps,
+		deps:       make([]int, len(steps)),
+		dependents: make([][]int, len(steps)),
+	}
+	for i, s := range steps {
+		for _, dep := range s.DependsOn {
+			j, ok := index[dep]
+			if !ok {
+				return nil, fmt.Errorf("reconcile step %q depends on unknown step %q", s.Name, dep)
+			}
+			g.deps[i]++
+			g.dependents[j] = append(g.dependents[j], i)
+		}
+	}
+
+	if cycle := g.cycle(); len(cycle) > 0 {
+		return nil, fmt.Errorf("reconcile steps %v form a dependency cycle", cycle)
+	}
+	return g, nil
+}
+
+// roots returns the steps that depend on no other step
+func (g *graph) roots() []int {
+	var roots []int
+	for i, n := range g.deps {
+		if n == 0 {
+			roots = append(roots, i)
+		}
+	}
+	return roots
+}
+
+// cycle returns the names of the steps that can never run because they
+// depend on each other, directly or not
+func (g *graph) cycle() []string {
+	deps := append([]int(nil), g.deps...)
+	ready := g.roots()
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		for _, d := range g.dependents[i] {
+			deps[d]--
+			if deps[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	var names []string
+	for i, n := range deps {
+		if n > 0 {
+			names = append(names, g.steps[i].Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
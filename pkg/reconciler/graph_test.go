@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequential(t *testing.T) {
+	a, b, c := newMockReconciler(Continue, nil), newMockReconciler(Continue, nil), newMockReconciler(Continue, nil)
+	steps := Sequential(a, b, c)
+
+	assert.Equal(t, []Step{
+		{Name: "0", Reconciler: a},
+		{Name: "1", Reconciler: b, DependsOn: []string{"0"}},
+		{Name: "2", Reconciler: c, DependsOn: []string{"1"}},
+	}, steps)
+	assert.Empty(t, Sequential())
+}
+
+func TestNewGraph(t *testing.T) {
+	r := newMockReconciler(Continue, nil)
+
+	tt := []struct {
+		scenario string
+		steps    []Step
+		roots    []int
+		err      string
+	}{
+		{
+			scenario: "no steps",
+		},
+		{
+			scenario: "independent steps",
+			steps:    []Step{{Name: "a", Reconciler: r}, {Name: "b", Reconciler: r}},
+			roots:    []int{0, 1},
+		},
+		{
+			scenario: "dependency declared after dependent",
+			steps: []Step{
+				{Name: "binding", Reconciler: r, DependsOn: []string{"role"}},
+				{Name: "role", Reconciler: r},
+			},
+			roots: []int{1},
+		},
+		{
+			scenario: "duplicate step",
+			steps:    []Step{{Name: "a", Reconciler: r}, {Name: "a", Reconciler: r}},
+			err:      `duplicate reconcile step "a"`,
+		},
+		{
+			scenario: "unknown dependency",
+			steps:    []Step{{Name: "a", Reconciler: r, DependsOn: []string{"b"}}},
+			err:      `reconcile step "a" depends on unknown step "b"`,
+		},
+		{
+			scenario: "cycle",
+			steps: []Step{
+				{Name: "root", Reconciler: r},
+				{Name: "a", Reconciler: r, DependsOn: []string{"root", "c"}},
+				{Name: "b", Reconciler: r, DependsOn: []string{"a"}},
+				{Name: "c", Reconciler: r, DependsOn: []string{"b"}},
+			},
+			err: "reconcile steps [a b c] form a dependency cycle",
+		},
+		{
+			scenario: "self dependency",
+			steps:    []Step{{Name: "a", Reconciler: r, DependsOn: []string{"a"}}},
+			err:      "reconcile steps [a] form a dependency cycle",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			g, err := newGraph(tc.steps)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.roots, g.roots())
+		})
+	}
+}
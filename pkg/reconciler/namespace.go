@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+)
+
+// NamespaceReconciler creates the namespace or adopts it if it already exists.
+// Only a namespace created by the operator is annotated as such so that a
+// namespace that existed before is never deleted by the operator.
+type NamespaceReconciler struct {
+	// Owner is the object the namespace was created for by earlier versions
+	// of the operator, which set it as the owner of the namespace
+	Owner     metav1.Object
+	Namespace *corev1.Namespace
+	OnError   Action
+	Logger    logr.Logger
+}
+
+func (r NamespaceReconciler) Reconcile(ctx context.Context, c client.Client, s *runtime.Scheme) Result {
+	existing := &corev1.Namespace{}
+	created := false
+	switch err := c.Get(ctx, client.ObjectKeyFromObject(r.Namespace), existing); {
+	case errors.IsNotFound(err):
+		created = true
+	case err != nil:
+		return Result{Action: r.OnError, Error: fmt.Errorf("namespace %s: failed to get: %w", r.Namespace.Name, err)}
+	default:
+		created = NamespaceCreatedByOperator(existing) || r.ownedBy(existing)
+	}
+
+	ns := r.Namespace.DeepCopy()
+	if created {
+		if ns.Annotations == nil {
+			ns.Annotations = map[string]string{}
+		}
+		ns.Annotations[components.NamespaceCreatedAnnotation] = "true"
+	} else {
+		r.Logger.V(3).Info("adopting existing namespace", "namespace", ns.Name)
+	}
+
+	// NOTE: the namespace has no owner so that it is never garbage collected
+	// along with the owner; it is deleted only by a NamespaceDeleter
+	return Updater{Resource: ns, OnError: r.OnError, Logger: r.Logger}.Reconcile(ctx, c, s)
+}
+
+// ownedBy returns true if ns is owned by Owner as earlier versions of the
+// operator did for the namespaces they created
+func (r NamespaceReconciler) ownedBy(ns *corev1.Namespace) bool {
+	if r.Owner == nil {
+		return false
+	}
+	for _, ref := range ns.OwnerReferences {
+		if ref.UID == r.Owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+// NamespaceCreatedByOperator returns true if ns was created by the operator
+func NamespaceCreatedByOperator(ns *corev1.Namespace) bool {
+	return ns.Annotations[components.NamespaceCreatedAnnotation] == "true"
+}
+
+// NamespaceDeleter deletes the namespace only if it was created by the operator;
+// see Deleter for OnError and RequeueAfter
+type NamespaceDeleter struct {
+	Namespace    *corev1.Namespace
+	OnError      Action
+	RequeueAfter time.Duration
+	Logger       logr.Logger
+}
+
+func (r NamespaceDeleter) Reconcile(ctx context.Context, c client.Client, s *runtime.Scheme) Result {
+	existing := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(r.Namespace), existing); err != nil {
+		if errors.IsNotFound(err) {
+			return Result{}
+		}
+		return Result{Action: r.OnError, Error: fmt.Errorf("namespace %s: failed to get: %w", r.Namespace.Name, err)}
+	}
+
+	if !NamespaceCreatedByOperator(existing) {
+		r.Logger.V(3).Info("retaining namespace not created by the operator", "namespace", existing.Name)
+		return Result{}
+	}
+	return Deleter{Resource: r.Namespace, OnError: r.OnError, RequeueAfter: r.RequeueAfter}.Reconcile(ctx, c, s)
+}
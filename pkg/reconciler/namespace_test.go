@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
+)
+
+// applyRecorder records the objects applied since the fake client doesn't support apply patches
+type applyRecorder struct {
+	client.Client
+	applied []client.Object
+}
+
+func (c *applyRecorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.applied = append(c.applied, obj)
+	return nil
+}
+
+func TestNamespaceReconciler(t *testing.T) {
+	scheme, _ := testSetup(t)
+	owner := &metav1.ObjectMeta{Name: "power-monitor", UID: types.UID("pmi-uid")}
+
+	tt := []struct {
+		scenario string
+		existing *corev1.Namespace
+		created  bool
+	}{
+		{
+			scenario: "missing namespace is created",
+			created:  true,
+		},
+		{
+			scenario: "existing namespace is adopted",
+			existing: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor"}},
+			created:  false,
+		},
+		{
+			scenario: "namespace created by the operator stays so",
+			existing: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "power-monitor",
+				Annotations: map[string]string{components.NamespaceCreatedAnnotation: "true"},
+			}},
+			created: true,
+		},
+		{
+			scenario: "namespace owned by power-monitor-internal was created by the operator",
+			existing: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:            "power-monitor",
+				OwnerReferences: []metav1.OwnerReference{{Name: "power-monitor", UID: owner.UID}},
+			}},
+			created: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			b := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.existing != nil {
+				b = b.WithObjects(tc.existing)
+			}
+			c := &applyRecorder{Client: b.Build()}
+
+			ns := components.NewNamespace("power-monitor")
+			result := NamespaceReconciler{Owner: owner, Namespace: ns, Logger: logr.Discard()}.Reconcile(context.TODO(), c, scheme)
+			require.NoError(t, result.Error)
+			assert.Equal(t, Continue, result.Action)
+
+			require.Len(t, c.applied, 1)
+			applied := c.applied[0].(*corev1.Namespace)
+			assert.Equal(t, tc.created, NamespaceCreatedByOperator(applied))
+			assert.Empty(t, applied.OwnerReferences, "namespace must not be garbage collected with its owner")
+			assert.Empty(t, ns.Annotations, "namespace passed in must not be modified")
+		})
+	}
+}
+
+func TestNamespaceDeleter(t *testing.T) {
+	scheme, _ := testSetup(t)
+
+	tt := []struct {
+		scenario string
+		existing *corev1.Namespace
+		deleted  bool
+	}{
+		{
+			scenario: "namespace created by the operator is deleted",
+			existing: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "power-monitor",
+				Annotations: map[string]string{components.NamespaceCreatedAnnotation: "true"},
+			}},
+			deleted: true,
+		},
+		{
+			scenario: "adopted namespace is retained",
+			existing: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "power-monitor"}},
+			deleted:  false,
+		},
+		{
+			scenario: "missing namespace",
+			deleted:  true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			b := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.existing != nil {
+				b = b.WithObjects(tc.existing)
+			}
+			c := b.Build()
+
+			ns := components.NewNamespace("power-monitor")
+			result := NamespaceDeleter{Namespace: ns, OnError: Requeue, Logger: logr.Discard()}.Reconcile(context.TODO(), c, scheme)
+			require.NoError(t, result.Error)
+			assert.Equal(t, Continue, result.Action)
+
+			err := c.Get(context.TODO(), client.ObjectKeyFromObject(ns), &corev1.Namespace{})
+			assert.Equal(t, tc.deleted, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestNamespaceDeleterRequeuesWhileTerminating(t *testing.T) {
+	scheme, _ := testSetup(t)
+	// NOTE: the finalizer keeps the namespace terminating once it is deleted
+	existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "power-monitor",
+		Annotations: map[string]string{components.NamespaceCreatedAnnotation: "true"},
+		Finalizers:  []string{"test/finalizer"},
+	}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+
+	ns := components.NewNamespace("power-monitor")
+	deleter := NamespaceDeleter{Namespace: ns, OnError: Requeue, RequeueAfter: time.Second, Logger: logr.Discard()}
+	result := deleter.Reconcile(context.TODO(), c, scheme)
+	require.NoError(t, result.Error)
+	assert.Equal(t, Requeue, result.Action)
+	assert.Equal(t, time.Second, result.RequeueAfter)
+
+	terminating := &corev1.Namespace{}
+	require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(ns), terminating))
+	assert.False(t, terminating.DeletionTimestamp.IsZero())
+
+	// the namespace is gone once its finalizer is removed
+	terminating.Finalizers = nil
+	require.NoError(t, c.Update(context.TODO(), terminating))
+	result = deleter.Reconcile(context.TODO(), c, scheme)
+	require.NoError(t, result.Error)
+	assert.Equal(t, Continue, result.Action)
+}
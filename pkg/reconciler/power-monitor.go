@@ -5,10 +5,15 @@ package reconciler
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"maps"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	"github.com/sustainable.computing.io/kepler-operator/internal/metrics"
 	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
 	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
 	appsv1 "k8s.io/api/apps/v1"
@@ -16,14 +21,18 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // PowerMonitorDeployer deploys the PowerMonitor ConfigMap for the given PowerMonitorInterna
 // land annotates the DaemonSet so that it is reloaded if the ConfigMap changes
 type PowerMonitorDeployer struct {
-	Pmi *v1alpha1.PowerMonitorInternal
-	Ds  *appsv1.DaemonSet
+	Pmi      *v1alpha1.PowerMonitorInternal
+	Ds       *appsv1.DaemonSet
+	Recorder record.EventRecorder
+	// Rollout, if set, holds back a change of the config until a canary promotes it
+	Rollout *Rollout
 }
 
 // Reconcile implements the PowerMonitorDeployer interface
@@ -33,16 +42,64 @@ func (r PowerMonitorDeployer) Reconcile(ctx context.Context, c client.Client, s
 		return Result{Action: Stop, Error: fmt.Errorf("error creating config: %w", err)}
 	}
 
+	var configErr error
 	cfm, err := powermonitor.NewPowerMonitorConfigMap(components.Full, r.Pmi, additionalConfigs...)
 	if err != nil {
-		return Result{Action: Stop, Error: fmt.Errorf("error creating configmap: %w", err)}
+		configErr = fmt.Errorf("error creating configmap: %w", &InvalidConfigError{Err: err})
+		// NOTE: config that fails validation is replaced by the default config
+		// whereas config that can't be built at all is left empty
+		if cfm.Data[powermonitor.KeplerConfigFile] == "" {
+			return Result{Action: Stop, Error: configErr}
+		}
+		recordWarning(r.Recorder, r.Pmi, EventConfigFallback,
+			"Kepler config is invalid; falling back to the default config: %v", err)
+		metrics.ConfigFallbacks.WithLabelValues(r.Pmi.Name).Inc()
+	}
+
+	if history := (configHistory{pmi: r.Pmi, ds: r.Ds, recorder: r.Recorder}); history.enabled() {
+		active, err := history.configToDeploy(ctx, c, s, cfm)
+		if active == nil {
+			return Result{Action: Stop, Error: err}
+		}
+		// a rolled back config is deployed but the crash-looping revision is still reported
+		cfm, configErr = active, stderrors.Join(configErr, err)
 	}
+
 	err = powermonitor.AnnotateWithConfigMapHash(&r.Ds.Spec.Template.ObjectMeta, cfm, powermonitor.ConfigMapHashAnnotation, powermonitor.KeplerConfigFile)
 	if err != nil {
 		return Result{Action: Stop, Error: fmt.Errorf("error annotating configmap hash to daemonset: %w", err)}
 	}
+
+	if r.Rollout != nil && r.Rollout.hold(powermonitor.Revision(r.Ds, cfm), cfm) {
+		// the config is deployed by the canary rollout once promoted
+		return Result{Action: Continue, Error: configErr}
+	}
+
+	changed := r.configChanged(ctx, c, cfm)
+
 	// Update the ConfigMap
-	return Updater{Owner: r.Pmi, Resource: cfm}.Reconcile(ctx, c, s)
+	result := Updater{Owner: r.Pmi, Resource: cfm}.Reconcile(ctx, c, s)
+	if result.Error != nil || result.Action != Continue {
+		return result
+	}
+
+	if changed {
+		hash := r.Ds.Spec.Template.Annotations[powermonitor.ConfigMapHashAnnotation+"-"+cfm.Name]
+		recordNormal(r.Recorder, r.Pmi, EventConfigMapUpdated,
+			"Kepler config rendered to configmap %s/%s with hash %s", cfm.Namespace, cfm.Name, hash)
+	}
+
+	// the fallback config is deployed but the error is still reported
+	return Result{Action: Continue, Error: configErr}
+}
+
+// configChanged returns true if the config in cfm differs from the deployed ConfigMap
+func (r PowerMonitorDeployer) configChanged(ctx context.Context, c client.Client, cfm *corev1.ConfigMap) bool {
+	existing := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(cfm), existing); err != nil {
+		return true
+	}
+	return existing.Data[powermonitor.KeplerConfigFile] != cfm.Data[powermonitor.KeplerConfigFile]
 }
 
 // readAdditionalConfigs fetches the ConfigMaps referenced in the spec, merges them, and returns the final config data
@@ -61,7 +118,7 @@ func (r PowerMonitorDeployer) readAdditionalConfigs(ctx context.Context, c clien
 		cfm := &corev1.ConfigMap{}
 		if err := c.Get(ctx, types.NamespacedName{Namespace: ns, Name: ref.Name}, cfm); err != nil {
 			if errors.IsNotFound(err) {
-				return nil, fmt.Errorf("configMap %s not found in %s namespace", ref.Name, ns)
+				return nil, &ConfigMapNotFoundError{Name: ref.Name, Namespace: ns}
 			}
 			return nil, fmt.Errorf("failed to get ConfigMap %s: %w", ref.Name, err)
 		}
@@ -82,6 +139,30 @@ func (r PowerMonitorDeployer) readAdditionalConfigs(ctx context.Context, c clien
 	return additionalConfigs, nil
 }
 
+// ConfigMapNotFoundError represents an error when a referenced additional ConfigMap is missing
+type ConfigMapNotFoundError struct {
+	Name      string
+	Namespace string
+}
+
+func (e *ConfigMapNotFoundError) Error() string {
+	return fmt.Sprintf("configMap %s not found in %s namespace", e.Name, e.Namespace)
+}
+
+// InvalidConfigError represents an error when the kepler config rendered from
+// the spec and the additional ConfigMaps fails to validate
+type InvalidConfigError struct {
+	Err error
+}
+
+func (e *InvalidConfigError) Error() string {
+	return fmt.Sprintf("invalid kepler config: %v", e.Err)
+}
+
+func (e *InvalidConfigError) Unwrap() error {
+	return e.Err
+}
+
 // SecretNotFoundError represents an error when one or more referenced secrets are missing
 type SecretNotFoundError struct {
 	MissingSecrets []string
@@ -98,9 +179,10 @@ func (e *SecretNotFoundError) Error() string {
 // SecretMounter validates that all referenced secrets exist and annotates the DaemonSet
 // with secret hashes to trigger pod restarts when secrets change
 type SecretMounter struct {
-	Pmi    *v1alpha1.PowerMonitorInternal
-	Ds     *appsv1.DaemonSet
-	Logger logr.Logger
+	Pmi      *v1alpha1.PowerMonitorInternal
+	Ds       *appsv1.DaemonSet
+	Logger   logr.Logger
+	Recorder record.EventRecorder
 }
 
 // Reconcile implements the SecretMounter interface
@@ -138,14 +220,70 @@ func (r SecretMounter) Reconcile(ctx context.Context, c client.Client, s *runtim
 	// If some secrets are missing, continue reconciliation but return an error
 	// that can be detected by the controller to set degraded status
 	if len(missingSecrets) > 0 {
-		return Result{
-			Action: Continue,
-			Error: &SecretNotFoundError{
-				MissingSecrets: missingSecrets,
-				Namespace:      ns,
-			},
+		err := &SecretNotFoundError{
+			MissingSecrets: missingSecrets,
+			Namespace:      ns,
 		}
+		recordWarning(r.Recorder, r.Pmi, EventSecretNotFound, "%s", err.Error())
+		return Result{Action: Continue, Error: err}
 	}
 
 	return Result{Action: Continue}
 }
+
+// DaemonSetUpdater updates the power-monitor DaemonSet and emits an event with
+// the cause whenever the update triggers a rollout of the pods
+type DaemonSetUpdater struct {
+	Pmi      *v1alpha1.PowerMonitorInternal
+	Ds       *appsv1.DaemonSet
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+	// Rollout, if set, holds back a change of the config and image until a
+	// canary promotes it
+	Rollout *Rollout
+}
+
+// Reconcile implements the Reconciler interface
+func (r DaemonSetUpdater) Reconcile(ctx context.Context, c client.Client, s *runtime.Scheme) Result {
+	existing := &appsv1.DaemonSet{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(r.Ds), existing); err != nil {
+		// a newly created daemonset doesn't trigger a rollout
+		existing = nil
+	}
+	if r.Rollout != nil {
+		if err := r.Rollout.holdBack(ctx, c, r.Ds); err != nil {
+			return Result{Action: Stop, Error: fmt.Errorf("failed to hold back daemonset changes: %w", err)}
+		}
+	}
+	// NOTE: the desired annotations are copied since patch overwrites r.Ds with
+	// the response from the server
+	desired := maps.Clone(r.Ds.Spec.Template.Annotations)
+
+	result := Updater{Owner: r.Pmi, Resource: r.Ds, Logger: r.Logger}.Reconcile(ctx, c, s)
+	if result.Error != nil || result.Action != Continue || existing == nil {
+		return result
+	}
+
+	if r.Ds.Generation > existing.Generation {
+		recordNormal(r.Recorder, r.Pmi, EventRolloutTriggered,
+			"Rollout of daemonset %s/%s triggered: %s", r.Ds.Namespace, r.Ds.Name,
+			rolloutCause(existing.Spec.Template.Annotations, desired))
+	}
+	return result
+}
+
+// rolloutCause describes the pod template annotations that changed between
+// current and desired
+func rolloutCause(current, desired map[string]string) string {
+	changed := []string{}
+	for k, v := range desired {
+		if current[k] != v {
+			changed = append(changed, k)
+		}
+	}
+	if len(changed) == 0 {
+		return "daemonset spec changed"
+	}
+	sort.Strings(changed)
+	return "annotations changed: " + strings.Join(changed, ", ")
+}
@@ -6,6 +6,7 @@ package reconciler
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -14,11 +15,13 @@ import (
 	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -47,6 +50,24 @@ func (m *testMockClient) Get(ctx context.Context, key types.NamespacedName, obj
 	return m.Client.Get(ctx, key, obj, opts...)
 }
 
+// assertEvents asserts that exactly the events with the given reasons were recorded
+func assertEvents(t *testing.T, recorder *record.FakeRecorder, reasons ...string) {
+	t.Helper()
+	actual := []string{}
+	for len(recorder.Events) > 0 {
+		// events are formatted as "<type> <reason> <message>"
+		fields := strings.SplitN(<-recorder.Events, " ", 3)
+		actual = append(actual, fields[1])
+	}
+	expected := []string{}
+	for _, r := range reasons {
+		if r != "" {
+			expected = append(expected, r)
+		}
+	}
+	assert.Equal(t, expected, actual)
+}
+
 func (m *testMockClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
 	// Simulate server-side apply by creating the object
 	return m.Create(ctx, obj)
@@ -62,6 +83,8 @@ func TestPowerMonitorDeployer_Reconcile(t *testing.T) {
 		expectedAction Action
 		expectedError  bool
 		errorContains  string
+		errorAs        error
+		expectedEvents []string
 	}{
 		{
 			name: "successful reconciliation with no additional configs",
@@ -91,6 +114,7 @@ func TestPowerMonitorDeployer_Reconcile(t *testing.T) {
 			},
 			expectedAction: Continue,
 			expectedError:  false,
+			expectedEvents: []string{EventConfigMapUpdated},
 		},
 		{
 			name: "successful reconciliation with additional configs",
@@ -136,6 +160,7 @@ monitor:
 			},
 			expectedAction: Continue,
 			expectedError:  false,
+			expectedEvents: []string{EventConfigMapUpdated},
 		},
 		{
 			name: "ignores invalid additional configs",
@@ -180,6 +205,7 @@ monitor:
 			},
 			expectedAction: Continue,
 			expectedError:  false,
+			expectedEvents: []string{EventConfigMapUpdated},
 		},
 		{
 			name: "fails when additional configmap not found",
@@ -212,6 +238,47 @@ monitor:
 			expectedAction: Stop,
 			expectedError:  true,
 			errorContains:  "configMap missing-config not found in test-ns namespace",
+			errorAs:        &ConfigMapNotFoundError{},
+		},
+		{
+			name: "falls back to default config when additional config is invalid",
+			pmi: &v1alpha1.PowerMonitorInternal{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pmi",
+					Namespace: "test-ns",
+				},
+				Spec: v1alpha1.PowerMonitorInternalSpec{
+					Kepler: v1alpha1.PowerMonitorInternalKeplerSpec{
+						Config: v1alpha1.PowerMonitorInternalKeplerConfigSpec{
+							LogLevel: "info",
+							AdditionalConfigMaps: []v1alpha1.ConfigMapRef{
+								{Name: "bad-config"},
+							},
+						},
+						Deployment: v1alpha1.PowerMonitorInternalKeplerDeploymentSpec{
+							Image:     "test-image:latest",
+							Namespace: "test-ns",
+						},
+					},
+				},
+			},
+			setupClient: func() client.Client {
+				cfm := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "bad-config", Namespace: "test-ns"},
+					Data: map[string]string{
+						powermonitor.KeplerConfigFile: "log:\n  format: xml\n",
+					},
+				}
+				return &testMockClient{
+					Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(cfm).Build(),
+					getErrors: make(map[string]error),
+				}
+			},
+			expectedAction: Continue,
+			expectedError:  true,
+			errorContains:  "invalid kepler config",
+			errorAs:        &InvalidConfigError{},
+			expectedEvents: []string{EventConfigFallback, EventConfigMapUpdated},
 		},
 		{
 			name: "fails when client get operation fails",
@@ -277,12 +344,15 @@ kube:
 				},
 			}
 
+			recorder := record.NewFakeRecorder(10)
 			deployer := PowerMonitorDeployer{
-				Pmi: tt.pmi,
-				Ds:  ds,
+				Pmi:      tt.pmi,
+				Ds:       ds,
+				Recorder: recorder,
 			}
 
 			result := deployer.Reconcile(context.Background(), client, scheme)
+			assertEvents(t, recorder, tt.expectedEvents...)
 
 			assert.Equal(t, tt.expectedAction, result.Action)
 			if tt.expectedError {
@@ -290,6 +360,9 @@ kube:
 				if tt.errorContains != "" {
 					assert.Contains(t, result.Error.Error(), tt.errorContains)
 				}
+				if tt.errorAs != nil {
+					assert.ErrorAs(t, result.Error, &tt.errorAs)
+				}
 			} else {
 				assert.NoError(t, result.Error)
 
@@ -741,13 +814,20 @@ func TestSecretMounter_Reconcile(t *testing.T) {
 				},
 			}
 
+			recorder := record.NewFakeRecorder(10)
 			mounter := SecretMounter{
-				Pmi:    tt.pmi,
-				Ds:     ds,
-				Logger: logr.Discard(), // Use discard logger for tests
+				Pmi:      tt.pmi,
+				Ds:       ds,
+				Logger:   logr.Discard(), // Use discard logger for tests
+				Recorder: recorder,
 			}
 
 			result := mounter.Reconcile(context.Background(), client, scheme)
+			if tt.errorType == "SecretNotFoundError" {
+				assertEvents(t, recorder, EventSecretNotFound)
+			} else {
+				assertEvents(t, recorder)
+			}
 
 			assert.Equal(t, tt.expectedAction, result.Action)
 			if tt.expectedError {
@@ -756,8 +836,8 @@ func TestSecretMounter_Reconcile(t *testing.T) {
 					assert.Contains(t, result.Error.Error(), tt.errorContains)
 				}
 				if tt.errorType == "SecretNotFoundError" {
-					_, ok := result.Error.(*SecretNotFoundError)
-					assert.True(t, ok, "Error should be of type SecretNotFoundError")
+					var secretErr *SecretNotFoundError
+					assert.ErrorAs(t, result.Error, &secretErr, "Error should be of type SecretNotFoundError")
 				}
 			} else {
 				assert.NoError(t, result.Error)
@@ -765,3 +845,98 @@ func TestSecretMounter_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+// daemonSetApplyClient emulates server-side apply of DaemonSets by bumping the
+// generation whenever the spec changes
+type daemonSetApplyClient struct {
+	client.Client
+}
+
+func (m *daemonSetApplyClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ds := obj.(*appsv1.DaemonSet)
+	existing := &appsv1.DaemonSet{}
+	if err := m.Get(ctx, client.ObjectKeyFromObject(ds), existing); err != nil {
+		ds.Generation = 1
+		return m.Create(ctx, ds)
+	}
+	ds.ResourceVersion = existing.ResourceVersion
+	ds.Generation = existing.Generation
+	if !equality.Semantic.DeepEqual(existing.Spec, ds.Spec) {
+		ds.Generation++
+	}
+	return m.Update(ctx, ds)
+}
+
+func TestDaemonSetUpdater_Reconcile(t *testing.T) {
+	scheme := testScheme()
+	pmi := &v1alpha1.PowerMonitorInternal{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pmi"},
+	}
+	newDaemonSet := func(hash string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pmi",
+				Namespace: "test-ns",
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{powermonitor.ConfigMapHashAnnotation + "-test-pmi": hash},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		existing       *appsv1.DaemonSet
+		desired        *appsv1.DaemonSet
+		expectedEvents []string
+	}{
+		{
+			name:    "creating daemonset does not trigger rollout",
+			desired: newDaemonSet("abc"),
+		},
+		{
+			name:     "unchanged daemonset does not trigger rollout",
+			existing: newDaemonSet("abc"),
+			desired:  newDaemonSet("abc"),
+		},
+		{
+			name:           "config change triggers rollout",
+			existing:       newDaemonSet("abc"),
+			desired:        newDaemonSet("def"),
+			expectedEvents: []string{EventRolloutTriggered},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.existing != nil {
+				builder = builder.WithObjects(tt.existing)
+			}
+			c := &daemonSetApplyClient{Client: builder.Build()}
+			recorder := record.NewFakeRecorder(10)
+
+			result := DaemonSetUpdater{
+				Pmi:      pmi,
+				Ds:       tt.desired,
+				Logger:   logr.Discard(),
+				Recorder: recorder,
+			}.Reconcile(context.Background(), c, scheme)
+
+			assert.NoError(t, result.Error)
+			assert.Equal(t, Continue, result.Action)
+			assertEvents(t, recorder, tt.expectedEvents...)
+		})
+	}
+}
+
+func TestRolloutCause(t *testing.T) {
+	assert.Equal(t, "daemonset spec changed", rolloutCause(map[string]string{"a": "1"}, map[string]string{"a": "1"}))
+	assert.Equal(t, "annotations changed: a, c",
+		rolloutCause(map[string]string{"a": "1", "b": "2"}, map[string]string{"a": "2", "b": "2", "c": "3"}))
+}
@@ -0,0 +1,311 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Pruner deletes the objects of Kinds that match Selector but are not in
+// Desired, i.e. objects the operator created earlier but no longer needs.
+// In DryRun mode the stale objects are only reported.
+type Pruner struct {
+	// Owner is the object on which events are recorded
+	Owner client.Object
+	// Kinds lists the kinds of objects to prune
+	Kinds []client.ObjectList
+	// Selector selects the objects managed by the operator
+	Selector labels.Selector
+	// InstanceLabel, if set, is the label naming the instance an object was
+	// created for; the objects of the instances in Instances other than Owner
+	// are kept, so that only the objects of Owner and of the instances that
+	// no longer exist are pruned. Objects created before the label was set
+	// belong to the instance that is their controller; objects of no instance
+	// are kept.
+	InstanceLabel string
+	// Instances is the kind of the instances, e.g. a PowerMonitorInternalList
+	Instances client.ObjectList
+	// Desired lists the objects that must be kept
+	Desired []client.Object
+	DryRun  bool
+	// History, if set, skips the prune while Desired, Kinds and the other
+	// instances are the ones last pruned for Owner, and reports the stale
+	// objects of a DryRun only when they change
+	History  *PruneHistory
+	OnError  Action
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+}
+
+func (r Pruner) Reconcile(ctx context.Context, c client.Client, s *runtime.Scheme) Result {
+	desired := map[string]bool{}
+	for _, obj := range r.Desired {
+		key, err := objectKey(obj, s)
+		if err != nil {
+			return Result{Action: r.OnError, Error: fmt.Errorf("pruner: %w", err)}
+		}
+		desired[key] = true
+	}
+
+	others, err := r.otherInstances(ctx, c)
+	if err != nil {
+		return Result{Action: r.OnError, Error: err}
+	}
+
+	// NOTE: objects become stale only when the desired objects or the
+	// instances change, so listing all objects of Kinds on every reconcile
+	// is wasteful
+	owner := r.ownerKey()
+	state := r.state(s, desired, others)
+	if r.History.pruned(owner, state) {
+		r.Logger.V(6).Info("skipping prune; desired objects are unchanged since last pruned")
+		return Result{}
+	}
+
+	var errs []error
+	var stale []client.Object
+	for _, list := range r.Kinds {
+		objs, err := r.staleObjects(ctx, c, s, list, desired, others)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		stale = append(stale, objs...)
+	}
+
+	if r.DryRun {
+		r.report(s, stale)
+	} else {
+		for _, obj := range stale {
+			if err := r.prune(ctx, c, s, obj); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return Result{Action: r.OnError, Error: err}
+	}
+	r.History.recordPruned(owner, state)
+	return Result{}
+}
+
+// ownerKey returns the key of Owner in History
+func (r Pruner) ownerKey() types.NamespacedName {
+	if r.Owner == nil {
+		return types.NamespacedName{}
+	}
+	return client.ObjectKeyFromObject(r.Owner)
+}
+
+// state identifies the objects a prune keeps: the desired objects, the kinds
+// listed and the other instances
+func (r Pruner) state(s *runtime.Scheme, desired, others map[string]bool) string {
+	kinds := make([]string, 0, len(r.Kinds))
+	for _, list := range r.Kinds {
+		gvk, _ := apiutil.GVKForObject(list, s)
+		kinds = append(kinds, gvk.String())
+	}
+	return strings.Join([]string{
+		strings.Join(slices.Sorted(maps.Keys(desired)), ","),
+		strings.Join(kinds, ","),
+		strings.Join(slices.Sorted(maps.Keys(others)), ","),
+	}, "|")
+}
+
+// otherInstances returns the names of the instances other than Owner
+func (r Pruner) otherInstances(ctx context.Context, c client.Client) (map[string]bool, error) {
+	others := map[string]bool{}
+	if r.InstanceLabel == "" || r.Instances == nil {
+		return others, nil
+	}
+
+	list := r.Instances.DeepCopyObject().(client.ObjectList)
+	if err := c.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("pruner: failed to list instances: %w", err)
+	}
+	err := meta.EachListItem(list, func(item runtime.Object) error {
+		obj, err := meta.Accessor(item)
+		if err != nil {
+			return fmt.Errorf("pruner: %w", err)
+		}
+		if r.Owner == nil || obj.GetName() != r.Owner.GetName() {
+			others[obj.GetName()] = true
+		}
+		return nil
+	})
+	return others, err
+}
+
+// staleObjects returns the objects of list that match Selector but are neither
+// desired nor created for one of the other instances
+func (r Pruner) staleObjects(ctx context.Context, c client.Client, s *runtime.Scheme, list client.ObjectList, desired, others map[string]bool) ([]client.Object, error) {
+	gvk, err := apiutil.GVKForObject(list, s)
+	if err != nil {
+		return nil, fmt.Errorf("pruner: %w", err)
+	}
+	kind := strings.TrimSuffix(gvk.Kind, "List")
+
+	if err := c.List(ctx, list, client.MatchingLabelsSelector{Selector: r.Selector}); err != nil {
+		if meta.IsNoMatchError(err) {
+			// the kind isn't served by the cluster, so there is nothing to prune
+			r.Logger.V(6).Info("skipping prune of kind not served", "kind", kind)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("pruner: failed to list %s: %w", kind, err)
+	}
+
+	var stale []client.Object
+	err = meta.EachListItem(list, func(item runtime.Object) error {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return fmt.Errorf("pruner: unexpected %T in %s list", item, kind)
+		}
+		key, err := objectKey(obj, s)
+		if err != nil {
+			return fmt.Errorf("pruner: %w", err)
+		}
+		if r.InstanceLabel != "" {
+			instance := r.instanceOf(obj, s)
+			if instance == "" || others[instance] {
+				return nil
+			}
+		}
+		if !desired[key] && obj.GetDeletionTimestamp().IsZero() {
+			stale = append(stale, obj)
+		}
+		return nil
+	})
+	return stale, err
+}
+
+// instanceOf returns the instance obj was created for: the value of its
+// InstanceLabel or, for objects created before the label was set, the name
+// of its controller if the controller is of the kind of Instances
+func (r Pruner) instanceOf(obj client.Object, s *runtime.Scheme) string {
+	if instance, ok := obj.GetLabels()[r.InstanceLabel]; ok {
+		return instance
+	}
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil || r.Instances == nil {
+		return ""
+	}
+	gvk, err := apiutil.GVKForObject(r.Instances, s)
+	if err != nil || ref.Kind != strings.TrimSuffix(gvk.Kind, "List") || ref.APIVersion != gvk.GroupVersion().String() {
+		return ""
+	}
+	return ref.Name
+}
+
+// report records a single event listing the stale objects that would be
+// pruned unless Reports has already reported them
+func (r Pruner) report(s *runtime.Scheme, stale []client.Object) {
+	keys := make([]string, 0, len(stale))
+	for _, obj := range stale {
+		key, _ := objectKey(obj, s)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if !r.History.reportChanged(r.ownerKey(), keys) || len(keys) == 0 {
+		return
+	}
+	r.Logger.Info("stale objects would be pruned (dry-run)", "objects", keys)
+	recordNormal(r.Recorder, r.Owner, EventPruneDryRun, "%d stale objects would be pruned: %s",
+		len(keys), strings.Join(keys, ", "))
+}
+
+// prune deletes obj
+func (r Pruner) prune(ctx context.Context, c client.Client, s *runtime.Scheme, obj client.Object) error {
+	key, _ := objectKey(obj, s)
+	r.Logger.Info("pruning stale object", "object", key)
+	if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("pruner: failed to delete %s: %w", key, err)
+	}
+	recordNormal(r.Recorder, r.Owner, EventResourcePruned, "Pruned stale object %s", key)
+	return nil
+}
+
+// PruneHistory remembers, for each owner, the state last pruned by a Pruner
+// and the stale objects last reported by a dry-run Pruner. PruneHistory is
+// safe for concurrent use.
+type PruneHistory struct {
+	mu       sync.Mutex
+	states   map[types.NamespacedName]string
+	reported map[types.NamespacedName][]string
+}
+
+// pruned returns true if state was the last one pruned for owner; always
+// false for a nil PruneHistory
+func (h *PruneHistory) pruned(owner types.NamespacedName, state string) bool {
+	if h == nil {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	prev, ok := h.states[owner]
+	return ok && prev == state
+}
+
+// recordPruned records state as the last one pruned for owner
+func (h *PruneHistory) recordPruned(owner types.NamespacedName, state string) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.states == nil {
+		h.states = map[types.NamespacedName]string{}
+	}
+	h.states[owner] = state
+}
+
+// reportChanged records stale as the objects reported for owner and returns
+// true if they differ from the ones reported last; always true for a nil
+// PruneHistory
+func (h *PruneHistory) reportChanged(owner types.NamespacedName, stale []string) bool {
+	if h == nil {
+		return true
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.reported == nil {
+		h.reported = map[types.NamespacedName][]string{}
+	}
+	if prev, ok := h.reported[owner]; ok && slices.Equal(prev, stale) {
+		return false
+	}
+	h.reported[owner] = stale
+	return true
+}
+
+// objectKey identifies obj by its kind, namespace and name
+func objectKey(obj client.Object, s *runtime.Scheme) (string, error) {
+	gvk, err := apiutil.GVKForObject(obj, s)
+	if err != nil {
+		return "", err
+	}
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", gvk.Kind, obj.GetName()), nil
+	}
+	return fmt.Sprintf("%s/%s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName()), nil
+}
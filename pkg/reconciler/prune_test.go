@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestPruner(t *testing.T) {
+	scheme, _ := testSetup(t)
+	managed := map[string]string{"app.kubernetes.io/managed-by": "kepler-operator", "instance": "a"}
+	configMap := func(name string, labels map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels}}
+	}
+
+	tt := []struct {
+		scenario string
+		dryRun   bool
+		deleted  []string
+		kept     []string
+	}{
+		{
+			scenario: "stale objects are deleted",
+			deleted:  []string{"stale"},
+			kept:     []string{"desired", "unmanaged", "other-instance"},
+		},
+		{
+			scenario: "dry-run deletes nothing",
+			dryRun:   true,
+			kept:     []string{"desired", "stale", "unmanaged", "other-instance"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				configMap("desired", managed),
+				configMap("stale", managed),
+				configMap("unmanaged", nil),
+				configMap("other-instance", map[string]string{"app.kubernetes.io/managed-by": "kepler-operator", "instance": "b"}),
+			).Build()
+
+			result := Pruner{
+				Kinds:    []client.ObjectList{&corev1.ConfigMapList{}, &corev1.SecretList{}},
+				Selector: labels.SelectorFromSet(managed),
+				Desired:  []client.Object{configMap("desired", nil)},
+				DryRun:   tc.dryRun,
+				Logger:   logr.Discard(),
+			}.Reconcile(context.TODO(), c, scheme)
+			require.NoError(t, result.Error)
+			assert.Equal(t, Continue, result.Action)
+
+			for _, name := range tc.deleted {
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: "ns", Name: name}, &corev1.ConfigMap{})
+				assert.True(t, apierrors.IsNotFound(err), "%s must be deleted", name)
+			}
+			for _, name := range tc.kept {
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: "ns", Name: name}, &corev1.ConfigMap{})
+				assert.NoError(t, err, "%s must be kept", name)
+			}
+		})
+	}
+
+	t.Run("objects are listed again only when desired objects change", func(t *testing.T) {
+		lists := 0
+		c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				lists++
+				return c.List(ctx, list, opts...)
+			},
+		}).Build()
+
+		history := &PruneHistory{}
+		prune := func(desired ...client.Object) {
+			result := Pruner{
+				Owner:    configMap("owner", nil),
+				Kinds:    []client.ObjectList{&corev1.ConfigMapList{}},
+				Selector: labels.SelectorFromSet(managed),
+				Desired:  desired,
+				History:  history,
+				Logger:   logr.Discard(),
+			}.Reconcile(context.TODO(), c, scheme)
+			require.NoError(t, result.Error)
+		}
+
+		prune(configMap("desired", nil))
+		prune(configMap("desired", nil))
+		assert.Equal(t, 1, lists)
+
+		prune(configMap("desired", nil), configMap("added", nil))
+		assert.Equal(t, 2, lists)
+	})
+
+	t.Run("list errors are returned", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				return errors.New("forbidden")
+			},
+		}).Build()
+
+		result := Pruner{
+			Kinds:    []client.ObjectList{&corev1.ConfigMapList{}},
+			Selector: labels.SelectorFromSet(managed),
+			OnError:  Requeue,
+			Logger:   logr.Discard(),
+		}.Reconcile(context.TODO(), c, scheme)
+		assert.ErrorContains(t, result.Error, "forbidden")
+		assert.Equal(t, Requeue, result.Action)
+	})
+}
@@ -5,6 +5,7 @@ package reconciler
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,6 +27,9 @@ func (a Action) String() string {
 type Result struct {
 	Action Action
 	Error  error
+	// RequeueAfter is the delay requested before the next reconcile when Action
+	// is Requeue; the Runner may requeue later than requested to back off
+	RequeueAfter time.Duration
 }
 
 type Reconciler interface {
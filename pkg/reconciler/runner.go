@@ -5,51 +5,309 @@ package reconciler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/sustainable.computing.io/kepler-operator/internal/metrics"
 )
 
+const tracerName = "github.com/sustainable.computing.io/kepler-operator/pkg/reconciler"
+
 type Runner struct {
+	// Reconcilers are run one after the other in order; ignored if Steps is set
 	Reconcilers []Reconciler
-	Client      client.Client
-	Scheme      *runtime.Scheme
-	Logger      logr.Logger
+	// Steps are run as a dependency graph; independent steps run concurrently
+	// up to Parallelism at a time
+	Steps       []Step
+	Parallelism int
+
+	Client client.Client
+	Scheme *runtime.Scheme
+	Logger logr.Logger
+
+	// Tracer traces the run of reconcilers; defaults to the tracer of the global provider
+	Tracer trace.Tracer
+
+	// Backoff, if set, delays the requeue of Key exponentially with the number
+	// of consecutive requeues; otherwise requeues are delayed by DefaultRequeueBaseDelay
+	Backoff *Backoff
+	Key     types.NamespacedName
 }
 
 func (runner Runner) Run(ctx context.Context) (ctrl.Result, error) {
-	var err error
+	tracer := runner.Tracer
+	if tracer == nil {
+		tracer = otel.Tracer(tracerName)
+	}
+
+	ctx, span := tracer.Start(ctx, "Runner.Run",
+		trace.WithAttributes(attribute.Int("reconciler.count", len(runner.steps()))))
+	defer span.End()
+
+	result, err := runner.run(ctx, tracer)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+// stepResult is the result of the reconciler of a step run by the Runner
+type stepResult struct {
+	step   int
+	result Result
+}
+
+func (runner Runner) run(ctx context.Context, tracer trace.Tracer) (ctrl.Result, error) {
+	g, err := newGraph(runner.steps())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
-	for _, r := range runner.Reconcilers {
-		runner.Logger.V(6).Info("reconciler.run ...")
-		result := r.Reconcile(ctx, runner.Client, runner.Scheme)
+	parallelism := max(runner.Parallelism, 1)
+	deps := append([]int(nil), g.deps...)
+	ready := g.roots()
+	results := make(chan stepResult)
+	errs := make([]error, len(g.steps))
+
+	// halt is the action of the first step that stopped or requeued the
+	// reconciliation; no further steps are started once it is set and the
+	// steps running are waited for
+	halt, requeueAfter := Continue, time.Duration(0)
+	running := 0
+
+	for {
+		for halt == Continue && running < parallelism && len(ready) > 0 {
+			i := ready[0]
+			ready = ready[1:]
+			running++
+			go func() {
+				runner.Logger.V(6).Info("reconciler.run ...", "step", g.steps[i].Name)
+				results <- stepResult{step: i, result: runner.reconcile(ctx, tracer, g.steps[i].Reconciler)}
+			}()
+		}
+		if running == 0 {
+			break
+		}
 
+		sr := <-results
+		running--
+		result := sr.result
 		if result.Error != nil {
-			err = result.Error
+			errs[sr.step] = runner.reconcilerError(g.steps[sr.step], result.Error)
 		}
 
 		switch result.Action {
 		case Continue:
 			if result.Error != nil {
-				runner.Logger.V(3).Info("continue reconciliation despite error", "error", err)
+				runner.Logger.V(3).Info("continue reconciliation despite error", "error", result.Error)
+			}
+			for _, d := range g.dependents[sr.step] {
+				deps[d]--
+				if deps[d] == 0 {
+					ready = insertSorted(ready, d)
+				}
 			}
 		case Stop:
-			runner.Logger.V(3).Info("stopping further reconciliation as requested")
-			return ctrl.Result{
-				Requeue: err == nil, // requeue if err is nil
-			}, err
-
-		case Requeue:
-			if err != nil {
-				runner.Logger.V(3).Info("requeue reconciliation despite error", "error", err)
-			} else {
-				runner.Logger.V(3).Info("requeue reconciliation; no error so far")
+			if halt == Continue {
+				halt = Stop
 			}
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		case Requeue:
+			// NOTE: a requeue takes precedence over a concurrent stop so that
+			// the reconciliation is retried
+			halt = Requeue
+			requeueAfter = max(requeueAfter, result.RequeueAfter)
 		}
 	}
+
+	err = errors.Join(errs...)
+	switch halt {
+	case Stop:
+		runner.Logger.V(3).Info("stopping further reconciliation as requested")
+		runner.Backoff.Reset(runner.Key)
+		return ctrl.Result{
+			Requeue: err == nil, // requeue if err is nil
+		}, err
+
+	case Requeue:
+		delay := runner.requeueDelay(requeueAfter)
+		if err != nil {
+			runner.Logger.V(3).Info("requeue reconciliation despite error", "error", err, "after", delay)
+		} else {
+			runner.Logger.V(3).Info("requeue reconciliation; no error so far", "after", delay)
+		}
+		// NOTE: the error is returned so that it is reported in the status;
+		// callers must not return it to controller-runtime since it ignores
+		// RequeueAfter when an error is returned
+		return ctrl.Result{RequeueAfter: delay}, err
+	}
+	runner.Backoff.Reset(runner.Key)
 	return ctrl.Result{}, err
 }
+
+// steps returns the Steps of the runner or, if unset, its Reconcilers run in order
+func (runner Runner) steps() []Step {
+	if runner.Steps != nil {
+		return runner.Steps
+	}
+	return Sequential(runner.Reconcilers...)
+}
+
+// insertSorted inserts step into the sorted steps so that ready steps are
+// started in the order they are declared
+func insertSorted(steps []int, step int) []int {
+	i, _ := slices.BinarySearch(steps, step)
+	return slices.Insert(steps, i, step)
+}
+
+// requeueDelay returns the delay before Key is reconciled again
+func (runner Runner) requeueDelay(requested time.Duration) time.Duration {
+	if runner.Backoff != nil {
+		return runner.Backoff.Next(runner.Key, requested)
+	}
+	if requested > 0 {
+		return requested
+	}
+	return DefaultRequeueBaseDelay
+}
+
+// ReconcilerError is the error returned by a sub-reconciler run by the Runner
+// tagged with the identity of the reconciler
+type ReconcilerError struct {
+	// Reconciler is the type of the reconciler; e.g. reconciler.Updater
+	Reconciler string
+	// Step is the name of the step of the reconciler
+	Step string
+	// GVK and Name identify the object reconciled, if known
+	GVK  schema.GroupVersionKind
+	Name string
+	Err  error
+}
+
+func (e *ReconcilerError) Error() string {
+	msg := e.Reconciler
+	if e.Step != "" {
+		msg = fmt.Sprintf("step %s: %s", e.Step, msg)
+	}
+	if e.Name == "" {
+		return fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+	return fmt.Sprintf("%s (%s %s): %v", msg, e.GVK.Kind, e.Name, e.Err)
+}
+
+func (e *ReconcilerError) Unwrap() error {
+	return e.Err
+}
+
+// Errors returns the errors joined in err; err itself if it isn't joined
+func Errors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+func (runner Runner) reconcilerError(step Step, err error) error {
+	recErr := &ReconcilerError{Reconciler: metrics.ReconcilerName(step.Reconciler), Step: step.Name, Err: err}
+	if obj := reconciledObject(step.Reconciler); obj != nil {
+		recErr.GVK = runner.gvk(obj)
+		recErr.Name = client.ObjectKeyFromObject(obj).String()
+	}
+	return recErr
+}
+
+// reconcile runs r in a span of its own and records its metrics
+func (runner Runner) reconcile(ctx context.Context, tracer trace.Tracer, r Reconciler) Result {
+	name := metrics.ReconcilerName(r)
+	ctx, span := tracer.Start(ctx, name, trace.WithAttributes(runner.resourceAttributes(r)...))
+	defer span.End()
+
+	start := time.Now()
+	result := r.Reconcile(ctx, runner.Client, runner.Scheme)
+	metrics.ObserveReconcile(name, time.Since(start), result.Error)
+
+	span.SetAttributes(attribute.String("reconciler.action", result.Action.String()))
+	if result.Error != nil {
+		span.RecordError(result.Error)
+		span.SetStatus(codes.Error, result.Error.Error())
+	}
+	return result
+}
+
+// resourceAttributes returns the span attributes identifying the object reconciled by r
+func (runner Runner) resourceAttributes(r Reconciler) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("reconciler", metrics.ReconcilerName(r))}
+
+	obj := reconciledObject(r)
+	if obj == nil {
+		return attrs
+	}
+
+	attrs = append(attrs,
+		attribute.String("k8s.resource.gvk", runner.gvk(obj).String()),
+		attribute.String("k8s.resource.name", obj.GetName()),
+	)
+	if ns := obj.GetNamespace(); ns != "" {
+		attrs = append(attrs, attribute.String("k8s.resource.namespace", ns))
+	}
+	return attrs
+}
+
+func (runner Runner) gvk(obj client.Object) schema.GroupVersionKind {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() && runner.Scheme != nil {
+		gvk, _ = apiutil.GVKForObject(obj, runner.Scheme)
+	}
+	return gvk
+}
+
+// reconciledObjecter is implemented by the reconcilers of a single object;
+// since the methods have value receivers, pointers to the reconcilers implement
+// it as well
+type reconciledObjecter interface {
+	reconciledObject() client.Object
+}
+
+// reconciledObject returns the object reconciled by r or nil if unknown
+func reconciledObject(r Reconciler) client.Object {
+	if ro, ok := r.(reconciledObjecter); ok {
+		return ro.reconciledObject()
+	}
+	return nil
+}
+
+func (r Updater) reconciledObject() client.Object                       { return r.Resource }
+func (r Deleter) reconciledObject() client.Object                       { return r.Resource }
+func (r Finalizer) reconciledObject() client.Object                     { return r.Resource }
+func (r DaemonSetUpdater) reconciledObject() client.Object              { return r.Ds }
+func (r NamespaceReconciler) reconciledObject() client.Object           { return r.Namespace }
+func (r NamespaceDeleter) reconciledObject() client.Object              { return r.Namespace }
+func (r PowerMonitorDeployer) reconciledObject() client.Object          { return r.Pmi }
+func (r SecretMounter) reconciledObject() client.Object                 { return r.Pmi }
+func (r KubeRBACProxyConfigReconciler) reconciledObject() client.Object { return r.Pmi }
+func (r CABundleConfigReconciler) reconciledObject() client.Object      { return r.Pmi }
+func (r UWMSecretTokenReconciler) reconciledObject() client.Object      { return r.Pmi }
+func (r KubeRBACProxyObjectsChecker) reconciledObject() client.Object   { return r.Pmi }
+func (r CarbonRulesReconciler) reconciledObject() client.Object         { return r.Rule }
+
+func (r PowerMonitorServiceMonitorReconciler) reconciledObject() client.Object {
+	monitor, _ := r.monitors()
+	return monitor
+}
@@ -5,17 +5,31 @@ package reconciler
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sustainable.computing.io/kepler-operator/internal/metrics"
 )
 
 // Test helpers and utilities
@@ -122,11 +136,19 @@ func TestRunner_Run(t *testing.T) {
 			expectedError:  false,
 		},
 		{
-			name: "reconciler - requeue with error",
+			name: "reconciler - requeue with error (error reported)",
 			reconcilers: []Reconciler{
 				newMockReconciler(Requeue, assert.AnError),
 			},
 			expectedResult: ctrl.Result{RequeueAfter: 5 * time.Second},
+			expectedError:  true,
+		},
+		{
+			name: "reconciler - requeue after requested delay",
+			reconcilers: []Reconciler{
+				&mockReconciler{result: Result{Action: Requeue, RequeueAfter: time.Minute}},
+			},
+			expectedResult: ctrl.Result{RequeueAfter: time.Minute},
 			expectedError:  false,
 		},
 	}
@@ -153,3 +175,327 @@ func TestRunner_Run(t *testing.T) {
 		})
 	}
 }
+
+// metricsReconciler is a reconciler with a type name unique to TestRunner_Metrics
+type metricsReconciler struct{ mockReconciler }
+
+func TestRunner_Metrics(t *testing.T) {
+	scheme, client := testSetup(t)
+	r := &metricsReconciler{mockReconciler{result: Result{Action: Continue, Error: assert.AnError}}}
+	name := metrics.ReconcilerName(r)
+	assert.Equal(t, "reconciler.metricsReconciler", name)
+
+	runner := createRunner([]Reconciler{r, r}, client, scheme)
+	_, err := runner.Run(context.TODO())
+	assert.Error(t, err)
+
+	m := &dto.Metric{}
+	require.NoError(t, metrics.ReconcilerErrors.WithLabelValues(name).Write(m))
+	assert.Equal(t, 2.0, m.GetCounter().GetValue())
+
+	m = &dto.Metric{}
+	require.NoError(t, metrics.ReconcilerDuration.WithLabelValues(name).(prometheus.Histogram).Write(m))
+	assert.Equal(t, uint64(2), m.GetHistogram().GetSampleCount())
+}
+
+func TestRunner_Tracing(t *testing.T) {
+	scheme, c := testSetup(t)
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa", Namespace: "ns"}}
+	// NOTE: reconcilers are built as pointers, as done by the controllers
+	runner := createRunner([]Reconciler{
+		&Deleter{Resource: cm},
+		// the fake client does not support apply patches, so the updater fails
+		&Updater{Resource: sa},
+		newMockReconciler(Stop, assert.AnError),
+	}, c, scheme)
+	runner.Tracer = tp.Tracer("test")
+
+	_, err := runner.Run(context.TODO())
+	assert.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 4)
+
+	attrs := func(s sdktrace.ReadOnlySpan) map[attribute.Key]string {
+		m := map[attribute.Key]string{}
+		for _, kv := range s.Attributes() {
+			m[kv.Key] = kv.Value.Emit()
+		}
+		return m
+	}
+
+	deleter := spans[0]
+	assert.Equal(t, "reconciler.Deleter", deleter.Name())
+	assert.Equal(t, map[attribute.Key]string{
+		"reconciler":             "reconciler.Deleter",
+		"k8s.resource.gvk":       "/v1, Kind=ConfigMap",
+		"k8s.resource.name":      "cm",
+		"k8s.resource.namespace": "ns",
+		"reconciler.action":      "Continue",
+	}, attrs(deleter))
+	assert.Equal(t, codes.Unset, deleter.Status().Code)
+
+	updater := spans[1]
+	assert.Equal(t, "reconciler.Updater", updater.Name())
+	assert.Equal(t, map[attribute.Key]string{
+		"reconciler":             "reconciler.Updater",
+		"k8s.resource.gvk":       "/v1, Kind=ServiceAccount",
+		"k8s.resource.name":      "sa",
+		"k8s.resource.namespace": "ns",
+		"reconciler.action":      "Continue",
+	}, attrs(updater))
+	assert.Equal(t, codes.Error, updater.Status().Code)
+
+	mock := spans[2]
+	assert.Equal(t, "reconciler.mockReconciler", mock.Name())
+	assert.Equal(t, "Stop", attrs(mock)["reconciler.action"])
+	assert.Equal(t, codes.Error, mock.Status().Code)
+
+	run := spans[3]
+	assert.Equal(t, "Runner.Run", run.Name())
+	assert.Equal(t, "3", attrs(run)["reconciler.count"])
+	assert.Equal(t, codes.Error, run.Status().Code)
+	assert.Equal(t, run.SpanContext().SpanID(), deleter.Parent().SpanID())
+	assert.Equal(t, run.SpanContext().SpanID(), mock.Parent().SpanID())
+}
+
+func TestRunner_AggregatesErrors(t *testing.T) {
+	scheme, c := testSetup(t)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "ns"}}
+	secretErr := &SecretNotFoundError{MissingSecrets: []string{"s"}, Namespace: "ns"}
+
+	runner := createRunner([]Reconciler{
+		newMockReconciler(Continue, assert.AnError),
+		// NOTE: the fake client does not support apply patches, so the updater fails
+		&Updater{Owner: owner, Resource: cm},
+		newMockReconciler(Continue, secretErr),
+		newMockReconciler(Continue, nil),
+	}, c, scheme)
+
+	_, err := runner.Run(context.TODO())
+	require.Error(t, err)
+
+	errs := Errors(err)
+	require.Len(t, errs, 3, "all failures must be reported: %v", err)
+
+	// every error names the failing reconciler
+	for _, e := range errs {
+		var recErr *ReconcilerError
+		require.ErrorAs(t, e, &recErr)
+	}
+
+	var updaterErr *ReconcilerError
+	require.ErrorAs(t, errs[1], &updaterErr)
+	assert.Equal(t, "reconciler.Updater", updaterErr.Reconciler)
+	assert.Equal(t, "ConfigMap", updaterErr.GVK.Kind)
+	assert.Equal(t, "ns/cm", updaterErr.Name)
+	assert.Equal(t, "1", updaterErr.Step)
+	assert.Contains(t, updaterErr.Error(), "step 1: reconciler.Updater (ConfigMap ns/cm): ")
+
+	// typed errors stay detectable
+	assert.ErrorIs(t, err, assert.AnError)
+	var notFound *SecretNotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, secretErr, notFound)
+}
+
+func TestRunner_StopReportsEarlierErrors(t *testing.T) {
+	scheme, c := testSetup(t)
+	last := newMockReconciler(Continue, nil)
+	runner := createRunner([]Reconciler{
+		newMockReconciler(Continue, assert.AnError),
+		newMockReconciler(Stop, errors.New("stop")),
+		last,
+	}, c, scheme)
+
+	_, err := runner.Run(context.TODO())
+	assert.Len(t, Errors(err), 2)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.ErrorContains(t, err, "reconciler.mockReconciler: stop")
+	assert.False(t, last.called)
+}
+
+func TestErrors(t *testing.T) {
+	assert.Nil(t, Errors(nil))
+	assert.Equal(t, []error{assert.AnError}, Errors(assert.AnError))
+
+	e1, e2 := errors.New("e1"), errors.New("e2")
+	assert.Equal(t, []error{e1, e2}, Errors(errors.Join(e1, e2)))
+}
+
+func TestRunner_Backoff(t *testing.T) {
+	scheme, c := testSetup(t)
+	key := types.NamespacedName{Name: "pm"}
+	backoff := NewBackoff(time.Second, 10*time.Second, 0)
+
+	requeue := newMockReconciler(Requeue, assert.AnError)
+	runner := createRunner([]Reconciler{requeue}, c, scheme)
+	runner.Backoff, runner.Key = backoff, key
+
+	for i, expected := range []time.Duration{1, 2, 4, 8, 10, 10} {
+		result, err := runner.Run(context.TODO())
+		assert.Error(t, err)
+		assert.Equal(t, expected*time.Second, result.RequeueAfter, "requeue %d", i+1)
+		assert.Equal(t, int32(i+1), backoff.Requeues(key))
+	}
+
+	// other objects back off independently
+	assert.Zero(t, backoff.Requeues(types.NamespacedName{Name: "other"}))
+
+	// a reconcile without a requeue resets the backoff
+	runner.Reconcilers = []Reconciler{newMockReconciler(Continue, nil)}
+	result, err := runner.Run(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	assert.Zero(t, backoff.Requeues(key))
+
+	runner.Reconcilers = []Reconciler{requeue}
+	result, _ = runner.Run(context.TODO())
+	assert.Equal(t, time.Second, result.RequeueAfter)
+}
+
+// stepReconciler records the order in which steps run; steps in wait block
+// until all of them are running so that a test fails unless they run concurrently
+type stepReconciler struct {
+	name    string
+	action  Action
+	log     *stepLog
+	barrier *sync.WaitGroup
+}
+
+type stepLog struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (l *stepLog) add(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.names = append(l.names, name)
+}
+
+func (r stepReconciler) Reconcile(ctx context.Context, c client.Client, scheme *runtime.Scheme) Result {
+	if r.barrier != nil {
+		r.barrier.Done()
+		r.barrier.Wait()
+	}
+	r.log.add(r.name)
+	return Result{Action: r.action}
+}
+
+func TestRunner_Steps(t *testing.T) {
+	scheme, c := testSetup(t)
+
+	t.Run("dependencies run first", func(t *testing.T) {
+		log := &stepLog{}
+		step := func(name string, deps ...string) Step {
+			return Step{Name: name, Reconciler: stepReconciler{name: name, log: log}, DependsOn: deps}
+		}
+		runner := createRunner(nil, c, scheme)
+		runner.Parallelism = 4
+		runner.Steps = []Step{
+			step("servicemonitor", "daemonset"),
+			step("daemonset", "deployer", "binding"),
+			step("binding", "role"),
+			step("role"),
+			step("deployer", "mounter"),
+			step("mounter"),
+		}
+
+		result, err := runner.Run(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		ran := log.names
+		require.Len(t, ran, 6)
+		before := func(a, b string) {
+			assert.Less(t, slices.Index(ran, a), slices.Index(ran, b), "%s must run before %s: %v", a, b, ran)
+		}
+		before("role", "binding")
+		before("mounter", "deployer")
+		before("binding", "daemonset")
+		before("deployer", "daemonset")
+		before("daemonset", "servicemonitor")
+	})
+
+	t.Run("independent steps run concurrently", func(t *testing.T) {
+		log := &stepLog{}
+		barrier := &sync.WaitGroup{}
+		barrier.Add(3)
+		runner := createRunner(nil, c, scheme)
+		runner.Parallelism = 3
+		for _, name := range []string{"a", "b", "c"} {
+			runner.Steps = append(runner.Steps, Step{Name: name, Reconciler: stepReconciler{name: name, log: log, barrier: barrier}})
+		}
+
+		done := make(chan error)
+		go func() {
+			_, err := runner.Run(context.TODO())
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []string{"a", "b", "c"}, log.names)
+		case <-time.After(5 * time.Second):
+			t.Fatal("independent steps did not run concurrently")
+		}
+	})
+
+	t.Run("stop skips dependents", func(t *testing.T) {
+		log := &stepLog{}
+		runner := createRunner(nil, c, scheme)
+		runner.Steps = []Step{
+			{Name: "stop", Reconciler: stepReconciler{name: "stop", action: Stop, log: log}},
+			{Name: "after", Reconciler: stepReconciler{name: "after", log: log}, DependsOn: []string{"stop"}},
+		}
+
+		result, err := runner.Run(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{Requeue: true}, result)
+		assert.Equal(t, []string{"stop"}, log.names)
+	})
+
+	t.Run("requeue takes precedence over stop", func(t *testing.T) {
+		runner := createRunner(nil, c, scheme)
+		runner.Parallelism = 2
+		runner.Steps = []Step{
+			{Name: "stop", Reconciler: newMockReconciler(Stop, nil)},
+			{Name: "requeue", Reconciler: &mockReconciler{result: Result{Action: Requeue, RequeueAfter: time.Minute}}},
+		}
+
+		result, err := runner.Run(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: time.Minute}, result)
+	})
+
+	t.Run("errors are reported in step order", func(t *testing.T) {
+		runner := createRunner(nil, c, scheme)
+		runner.Parallelism = 2
+		runner.Steps = []Step{
+			{Name: "first", Reconciler: newMockReconciler(Continue, errors.New("first"))},
+			{Name: "second", Reconciler: newMockReconciler(Continue, errors.New("second"))},
+		}
+
+		_, err := runner.Run(context.TODO())
+		errs := Errors(err)
+		require.Len(t, errs, 2)
+		assert.ErrorContains(t, errs[0], "first")
+		assert.ErrorContains(t, errs[1], "second")
+	})
+
+	t.Run("invalid graph runs nothing", func(t *testing.T) {
+		r := newMockReconciler(Continue, nil)
+		runner := createRunner(nil, c, scheme)
+		runner.Steps = []Step{{Name: "a", Reconciler: r, DependsOn: []string{"missing"}}}
+
+		_, err := runner.Run(context.TODO())
+		assert.EqualError(t, err, `reconcile step "a" depends on unknown step "missing"`)
+		assert.False(t, r.called)
+	})
+}
@@ -5,6 +5,7 @@ package reconciler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,23 +13,18 @@ import (
 	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
 	"github.com/sustainable.computing.io/kepler-operator/pkg/components"
 	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
-	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
 	appsv1 "k8s.io/api/apps/v1"
 	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-var (
-	openshiftTimeout         = 60 * time.Second
-	k8sTimeout               = 6 * time.Second
-	retryDelay               = 3 * time.Second
-	uwmTokenExpirationBuffer = 1 * time.Minute
-)
+var uwmTokenExpirationBuffer = 1 * time.Minute
 
 // KubeRBACProxyConfigReconciler reconciles configuration for allowed SAs
 type KubeRBACProxyConfigReconciler struct {
@@ -78,12 +74,51 @@ func (r CABundleConfigReconciler) Reconcile(ctx context.Context, c client.Client
 	return Updater{Owner: r.Pmi, Resource: caBundle}.Reconcile(ctx, c, s)
 }
 
+// WaitingError indicates that a reconciler is waiting for an object that is
+// created by someone else, e.g. by OpenShift. Reconcilers don't poll for such
+// objects; the reconcile is triggered again by a watch on the object.
+type WaitingError struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Hint explains who is expected to create the object
+	Hint string
+}
+
+func (e *WaitingError) Error() string {
+	msg := fmt.Sprintf("waiting for %s %q in %q namespace", e.Kind, e.Name, e.Namespace)
+	if e.Hint != "" {
+		msg += "; " + e.Hint
+	}
+	return msg
+}
+
+// IsWaiting returns true if err only reports objects that reconcilers are waiting for
+func IsWaiting(err error) bool {
+	errs := Errors(err)
+	for _, e := range errs {
+		var waitErr *WaitingError
+		if !errors.As(e, &waitErr) {
+			return false
+		}
+	}
+	return len(errs) > 0
+}
+
+// waitFor returns a Result that stops the reconciliation until the object is created
+func waitFor(kind, name, ns, hint string) Result {
+	return Result{
+		Action: Stop,
+		Error:  &WaitingError{Kind: kind, Name: name, Namespace: ns, Hint: hint},
+	}
+}
+
 // UWMSecretTokenReconciler reconciles the User Workload Monitoring Secret Token
 type UWMSecretTokenReconciler struct {
 	Pmi        *v1alpha1.PowerMonitorInternal
-	Cluster    k8s.Cluster
 	EnableRBAC bool
 	EnableUWM  bool
+	Recorder   record.EventRecorder
 }
 
 func (r UWMSecretTokenReconciler) Reconcile(ctx context.Context, c client.Client, s *runtime.Scheme) Result {
@@ -95,62 +130,30 @@ func (r UWMSecretTokenReconciler) Reconcile(ctx context.Context, c client.Client
 		)
 		return Deleter{Resource: tokenSecret}.Reconcile(ctx, c, s)
 	}
-	// set timeout based on cluster type
-	timeout := k8sTimeout
-	if r.Cluster == k8s.OpenShift {
-		timeout = openshiftTimeout
-	}
-	var promAccount *corev1.ServiceAccount
-	if err := retryWithTimeout(ctx, timeout, retryDelay, func() error {
-		var err error
-		promAccount, err = getServiceAccount(
-			ctx,
-			c,
-			powermonitor.UWMServiceAccountName,
-			powermonitor.UWMNamespace,
-		)
-		if err != nil {
-			return fmt.Errorf(
+	promAccount, err := getServiceAccount(ctx, c, powermonitor.UWMServiceAccountName, powermonitor.UWMNamespace)
+	if err != nil {
+		return Result{
+			Action: Stop,
+			Error: fmt.Errorf(
 				"error occurred while getting %q service account %w",
 				powermonitor.UWMServiceAccountName,
 				err,
-			)
-		}
-		if promAccount == nil {
-			return fmt.Errorf(
-				"missing %q in %q namespace yet; please enable user workload monitoring",
-				powermonitor.UWMServiceAccountName,
-				powermonitor.UWMNamespace,
-			)
+			),
 		}
-		return nil
-	}); err != nil {
+	}
+	if promAccount == nil {
+		return waitFor("serviceaccount", powermonitor.UWMServiceAccountName, powermonitor.UWMNamespace,
+			"please enable user workload monitoring")
+	}
+	promUWMSecretToken, err := getSecret(ctx, c, powermonitor.SecretUWMTokenName, r.Pmi.Namespace())
+	if err != nil {
 		return Result{
 			Action: Stop,
-			Error:  err,
-		}
-	}
-	var promUWMSecretToken *corev1.Secret
-	if err := retryWithTimeout(ctx, timeout, retryDelay, func() error {
-		var err error
-		promUWMSecretToken, err = getSecret(
-			ctx,
-			c,
-			powermonitor.SecretUWMTokenName,
-			r.Pmi.Spec.Kepler.Deployment.Namespace,
-		)
-		if err != nil {
-			return fmt.Errorf(
+			Error: fmt.Errorf(
 				"error occurred while getting %q secret %w",
 				powermonitor.SecretUWMTokenName,
 				err,
-			)
-		}
-		return nil
-	}); err != nil {
-		return Result{
-			Action: Stop,
-			Error:  err,
+			),
 		}
 	}
 	if promUWMSecretToken != nil {
@@ -193,15 +196,22 @@ func (r UWMSecretTokenReconciler) Reconcile(ctx context.Context, c client.Client
 	}
 	tokenSecret := powermonitor.NewPowerMonitorUWMTokenSecret(components.Full, r.Pmi, token)
 	powermonitor.AnnotateWithExpiration(&tokenSecret.ObjectMeta, powermonitor.SecretTokenExpirationAnnotation, powermonitor.TokenTTL)
-	return Updater{Owner: r.Pmi, Resource: tokenSecret}.Reconcile(ctx, c, s)
+	result := Updater{Owner: r.Pmi, Resource: tokenSecret}.Reconcile(ctx, c, s)
+	if result.Error == nil && result.Action == Continue {
+		recordNormal(r.Recorder, r.Pmi, EventTokenRotated,
+			"Rotated token of %q service account in secret %s/%s; expires at %s",
+			powermonitor.UWMServiceAccountName, tokenSecret.Namespace, tokenSecret.Name,
+			tokenSecret.Annotations[powermonitor.SecretTokenExpirationAnnotation])
+	}
+	return result
 }
 
 // KubeRBACProxyObjectsChecker checks if all required objects for kube-rbac-proxy are present
 type KubeRBACProxyObjectsChecker struct {
 	Pmi        *v1alpha1.PowerMonitorInternal
-	Cluster    k8s.Cluster
 	Ds         *appsv1.DaemonSet
 	Sm         *monv1.ServiceMonitor
+	Pm         *monv1.PodMonitor
 	EnableRBAC bool
 	EnableUWM  bool
 }
@@ -210,109 +220,65 @@ func (r KubeRBACProxyObjectsChecker) Reconcile(ctx context.Context, c client.Cli
 	if !r.EnableRBAC {
 		return Result{}
 	}
-	// set timeout based on cluster type
-	timeout := k8sTimeout
-	if r.Cluster == k8s.OpenShift {
-		timeout = openshiftTimeout
-	}
+	ns := r.Pmi.Namespace()
+
 	// check kube rbac proxy config secret
-	var proxyConfig *corev1.Secret
-	if err := retryWithTimeout(ctx, timeout, retryDelay, func() error {
-		var err error
-		proxyConfig, err = getSecret(
-			ctx,
-			c,
-			powermonitor.SecretKubeRBACProxyConfigName,
-			r.Pmi.Spec.Kepler.Deployment.Namespace,
-		)
-		if err != nil {
-			return fmt.Errorf(
+	proxyConfig, err := getSecret(ctx, c, powermonitor.SecretKubeRBACProxyConfigName, ns)
+	if err != nil {
+		return Result{
+			Action: Stop,
+			Error: fmt.Errorf(
 				"error occurred while getting %q secret %w",
 				powermonitor.SecretKubeRBACProxyConfigName,
 				err,
-			)
-		}
-		if proxyConfig == nil {
-			return fmt.Errorf(
-				"%q secret not created in %q namespace yet",
-				powermonitor.SecretKubeRBACProxyConfigName,
-				r.Pmi.Namespace(),
-			)
-		}
-		return nil
-	}); err != nil {
-		return Result{
-			Action: Stop,
-			Error:  err,
+			),
 		}
 	}
+	if proxyConfig == nil {
+		return waitFor("secret", powermonitor.SecretKubeRBACProxyConfigName, ns, "")
+	}
 	powermonitor.AnnotateWithSecretHash(&r.Ds.Spec.Template.ObjectMeta, proxyConfig, powermonitor.SecretTLSHashAnnotation)
+
 	// check power monitor tls secret
-	var pmTLS *corev1.Secret
-	if err := retryWithTimeout(ctx, timeout, retryDelay, func() error {
-		var err error
-		pmTLS, err = getSecret(
-			ctx,
-			c,
-			powermonitor.SecretTLSCertName,
-			r.Pmi.Spec.Kepler.Deployment.Namespace,
-		)
-		if err != nil {
-			return fmt.Errorf(
+	pmTLS, err := getSecret(ctx, c, powermonitor.SecretTLSCertName, ns)
+	if err != nil {
+		return Result{
+			Action: Stop,
+			Error: fmt.Errorf(
 				"error occurred while getting %q secret %w",
 				powermonitor.SecretTLSCertName,
 				err,
-			)
-		}
-		if pmTLS == nil {
-			return fmt.Errorf(
-				"%q secret not created in %q namespace yet",
-				powermonitor.SecretTLSCertName,
-				r.Pmi.Namespace(),
-			)
-		}
-		return nil
-	}); err != nil {
-		return Result{
-			Action: Stop,
-			Error:  err,
+			),
 		}
 	}
+	if pmTLS == nil {
+		return waitFor("secret", powermonitor.SecretTLSCertName, ns, "")
+	}
 	powermonitor.AnnotateWithSecretHash(&r.Ds.Spec.Template.ObjectMeta, pmTLS, powermonitor.SecretTLSHashAnnotation)
 
-	if r.EnableUWM {
-		// check ca bundle
-		var caBundle *corev1.ConfigMap
-		if err := retryWithTimeout(ctx, timeout, retryDelay, func() error {
-			var err error
-			caBundle, err = getConfigMap(
-				ctx,
-				c,
+	if !r.EnableUWM {
+		return Result{}
+	}
+
+	// check ca bundle
+	caBundle, err := getConfigMap(ctx, c, powermonitor.PowerMonitorCertsCABundleName, ns)
+	if err != nil {
+		return Result{
+			Action: Stop,
+			Error: fmt.Errorf(
+				"error occurred while getting %q configmap %w",
 				powermonitor.PowerMonitorCertsCABundleName,
-				r.Pmi.Spec.Kepler.Deployment.Namespace,
-			)
-			if err != nil {
-				return fmt.Errorf(
-					"error occurred while getting %q configmap %w",
-					powermonitor.PowerMonitorCertsCABundleName,
-					err,
-				)
-			}
-			if caBundle == nil {
-				return fmt.Errorf(
-					"missing %q in %q namespace yet; openshift is yet to create ca bundle validation",
-					powermonitor.PowerMonitorCertsCABundleName, r.Pmi.Namespace(),
-				)
-			}
-			return nil
-		}); err != nil {
-			return Result{
-				Action: Stop,
-				Error:  err,
-			}
+				err,
+			),
 		}
-		// insert ca bundle annotation to ServiceMonitor
-		err := powermonitor.AnnotateWithConfigMapHash(&r.Sm.ObjectMeta, caBundle, powermonitor.CABundleConfigMapAnnotation, "")
+	}
+	if caBundle == nil {
+		return waitFor("configmap", powermonitor.PowerMonitorCertsCABundleName, ns,
+			"openshift is yet to create ca bundle validation")
+	}
+	// insert ca bundle annotation to the ServiceMonitor and PodMonitor
+	for _, meta := range r.monitorsMeta() {
+		err = powermonitor.AnnotateWithConfigMapHash(meta, caBundle, powermonitor.CABundleConfigMapAnnotation, "")
 		if err != nil {
 			return Result{
 				Action: Stop,
@@ -323,63 +289,40 @@ func (r KubeRBACProxyObjectsChecker) Reconcile(ctx context.Context, c client.Cli
 				),
 			}
 		}
-		// check uwm token secret
-		var promUWMSecretToken *corev1.Secret
-		if err := retryWithTimeout(ctx, timeout, retryDelay, func() error {
-			var err error
-			promUWMSecretToken, err = getSecret(
-				ctx,
-				c,
+	}
+
+	// check uwm token secret
+	promUWMSecretToken, err := getSecret(ctx, c, powermonitor.SecretUWMTokenName, ns)
+	if err != nil {
+		return Result{
+			Action: Stop,
+			Error: fmt.Errorf(
+				"error occurred while getting %q secret %w",
 				powermonitor.SecretUWMTokenName,
-				r.Pmi.Spec.Kepler.Deployment.Namespace,
-			)
-			if err != nil {
-				return fmt.Errorf(
-					"error occurred while getting %q secret %w",
-					powermonitor.SecretUWMTokenName,
-					err,
-				)
-			}
-			if promUWMSecretToken == nil {
-				return fmt.Errorf(
-					"missing %q in %q namespace yet; operator is yet to create the token for %q sa",
-					powermonitor.SecretUWMTokenName,
-					r.Pmi.Namespace(),
-					powermonitor.UWMServiceAccountName,
-				)
-			}
-			return nil
-		}); err != nil {
-			return Result{
-				Action: Stop,
-				Error:  err,
-			}
+				err,
+			),
 		}
-		powermonitor.AnnotateWithSecretHash(&r.Sm.ObjectMeta, promUWMSecretToken, powermonitor.SecretTokenHashAnnotation)
+	}
+	if promUWMSecretToken == nil {
+		return waitFor("secret", powermonitor.SecretUWMTokenName, ns,
+			fmt.Sprintf("operator is yet to create the token for %q sa", powermonitor.UWMServiceAccountName))
+	}
+	for _, meta := range r.monitorsMeta() {
+		powermonitor.AnnotateWithSecretHash(meta, promUWMSecretToken, powermonitor.SecretTokenHashAnnotation)
 	}
 	return Result{}
 }
 
-// retryWithTimeout retries the operation until it succeeds or times out
-func retryWithTimeout(ctx context.Context, timeout, retryDelay time.Duration, operation func() error) error {
-	var lastErr error
-
-	err := wait.PollUntilContextTimeout(ctx, retryDelay, timeout, true, func(ctx context.Context) (bool, error) {
-		if err := operation(); err != nil {
-			lastErr = err
-			return false, nil
-		}
-		return true, nil
-	})
-
-	if err != nil {
-		if lastErr != nil {
-			return fmt.Errorf("timeout after %v: %w", timeout, lastErr)
-		}
-		return fmt.Errorf("timeout after %v: %w", timeout, err)
+// monitorsMeta returns the metadata of the monitors that scrape Kepler
+func (r KubeRBACProxyObjectsChecker) monitorsMeta() []*metav1.ObjectMeta {
+	meta := []*metav1.ObjectMeta{}
+	if r.Sm != nil {
+		meta = append(meta, &r.Sm.ObjectMeta)
 	}
-
-	return nil
+	if r.Pm != nil {
+		meta = append(meta, &r.Pm.ObjectMeta)
+	}
+	return meta
 }
 
 func getSecret(ctx context.Context, c client.Client, secretName, ns string) (*corev1.Secret, error) {
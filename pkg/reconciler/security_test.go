@@ -5,14 +5,13 @@ package reconciler
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"testing"
-	"time"
 
 	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
-	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
 	appsv1 "k8s.io/api/apps/v1"
 	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -349,7 +348,6 @@ func TestUWMSecretTokenReconciler(t *testing.T) {
 		name           string
 		enableRBAC     bool
 		enableUWM      bool
-		cluster        k8s.Cluster
 		mockClient     func() client.Client
 		expectedAction Action
 		expectedError  bool
@@ -359,7 +357,6 @@ func TestUWMSecretTokenReconciler(t *testing.T) {
 			name:       "RBAC disabled - should call deleter",
 			enableRBAC: false,
 			enableUWM:  false,
-			cluster:    k8s.Kubernetes,
 			mockClient: func() client.Client {
 				return newMockClientBuilder().build()
 			},
@@ -370,7 +367,6 @@ func TestUWMSecretTokenReconciler(t *testing.T) {
 			name:       "UWM disabled - should call deleter",
 			enableRBAC: true,
 			enableUWM:  false,
-			cluster:    k8s.Kubernetes,
 			mockClient: func() client.Client {
 				return newMockClientBuilder().build()
 			},
@@ -381,7 +377,6 @@ func TestUWMSecretTokenReconciler(t *testing.T) {
 			name:       "RBAC and UWM enabled with existing SA - should succeed",
 			enableRBAC: true,
 			enableUWM:  true,
-			cluster:    k8s.Kubernetes,
 			mockClient: func() client.Client {
 				return newMockClientBuilder().
 					withObjects(promSA).
@@ -395,27 +390,12 @@ func TestUWMSecretTokenReconciler(t *testing.T) {
 			name:       "UWM service account not found",
 			enableRBAC: true,
 			enableUWM:  true,
-			cluster:    k8s.Kubernetes,
 			mockClient: func() client.Client {
 				return newMockClientBuilder().build() // No SA created
 			},
 			expectedAction: Stop,
 			expectedError:  true,
-			errorContains:  "missing \"prometheus-user-workload\" in \"openshift-user-workload-monitoring\" namespace yet",
-		},
-		{
-			name:       "OpenShift cluster with longer timeout",
-			enableRBAC: true,
-			enableUWM:  true,
-			cluster:    k8s.OpenShift,
-			mockClient: func() client.Client {
-				return newMockClientBuilder().
-					withObjects(promSA).
-					withTokenRequest("test-token").
-					build()
-			},
-			expectedAction: Continue,
-			expectedError:  false,
+			errorContains:  "waiting for serviceaccount \"prometheus-user-workload\" in \"openshift-user-workload-monitoring\" namespace",
 		},
 	}
 
@@ -427,7 +407,6 @@ func TestUWMSecretTokenReconciler(t *testing.T) {
 
 			reconciler := UWMSecretTokenReconciler{
 				Pmi:        pmi,
-				Cluster:    tt.cluster,
 				EnableRBAC: tt.enableRBAC,
 				EnableUWM:  tt.enableUWM,
 			}
@@ -440,6 +419,8 @@ func TestUWMSecretTokenReconciler(t *testing.T) {
 				if tt.errorContains != "" {
 					assert.Contains(t, result.Error.Error(), tt.errorContains)
 				}
+				var waitErr *WaitingError
+				assert.ErrorAs(t, result.Error, &waitErr)
 			} else {
 				assert.NoError(t, result.Error)
 			}
@@ -448,23 +429,6 @@ func TestUWMSecretTokenReconciler(t *testing.T) {
 }
 
 func TestKubeRBACProxyObjectsChecker(t *testing.T) {
-	// Save original timeouts and restore them after test
-	originalOpenshiftTimeout := openshiftTimeout
-	originalK8sTimeout := k8sTimeout
-	originalRetryDelay := retryDelay
-
-	// Use much shorter timeouts for testing
-	openshiftTimeout = 100 * time.Millisecond
-	k8sTimeout = 50 * time.Millisecond
-	retryDelay = 10 * time.Millisecond
-
-	defer func() {
-		// Restore original timeouts
-		openshiftTimeout = originalOpenshiftTimeout
-		k8sTimeout = originalK8sTimeout
-		retryDelay = originalRetryDelay
-	}()
-
 	testDS := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test-ds",
@@ -515,7 +479,6 @@ func TestKubeRBACProxyObjectsChecker(t *testing.T) {
 		name           string
 		enableRBAC     bool
 		enableUWM      bool
-		cluster        k8s.Cluster
 		setupObjects   []client.Object
 		expectedAction Action
 		expectedError  bool
@@ -525,16 +488,14 @@ func TestKubeRBACProxyObjectsChecker(t *testing.T) {
 			name:           "RBAC disabled - should skip all checks",
 			enableRBAC:     false,
 			enableUWM:      false,
-			cluster:        k8s.Kubernetes,
 			setupObjects:   []client.Object{},
 			expectedAction: Continue,
 			expectedError:  false,
 		},
 		{
-			name:       "RBAC enabled - all required objects present (Kubernetes)",
+			name:       "RBAC enabled - all required objects present",
 			enableRBAC: true,
 			enableUWM:  false,
-			cluster:    k8s.Kubernetes,
 			setupObjects: []client.Object{
 				testSecrets[0], // rbac config secret
 				testSecrets[1], // tls secret
@@ -543,10 +504,9 @@ func TestKubeRBACProxyObjectsChecker(t *testing.T) {
 			expectedError:  false,
 		},
 		{
-			name:       "RBAC and UWM enabled - all objects present (OpenShift)",
+			name:       "RBAC and UWM enabled - all objects present ",
 			enableRBAC: true,
 			enableUWM:  true,
-			cluster:    k8s.OpenShift,
 			setupObjects: []client.Object{
 				testSecrets[0], // rbac config secret
 				testSecrets[1], // tls secret
@@ -560,7 +520,6 @@ func TestKubeRBACProxyObjectsChecker(t *testing.T) {
 			name:           "RBAC config secret missing",
 			enableRBAC:     true,
 			enableUWM:      false,
-			cluster:        k8s.Kubernetes,
 			setupObjects:   []client.Object{testSecrets[1]}, // only tls secret
 			expectedAction: Stop,
 			expectedError:  true,
@@ -570,7 +529,6 @@ func TestKubeRBACProxyObjectsChecker(t *testing.T) {
 			name:           "TLS secret missing",
 			enableRBAC:     true,
 			enableUWM:      false,
-			cluster:        k8s.Kubernetes,
 			setupObjects:   []client.Object{testSecrets[0]}, // only rbac config secret
 			expectedAction: Stop,
 			expectedError:  true,
@@ -580,7 +538,6 @@ func TestKubeRBACProxyObjectsChecker(t *testing.T) {
 			name:       "UWM enabled but CA bundle missing",
 			enableRBAC: true,
 			enableUWM:  true,
-			cluster:    k8s.OpenShift,
 			setupObjects: []client.Object{
 				testSecrets[0], // rbac config secret
 				testSecrets[1], // tls secret
@@ -595,7 +552,6 @@ func TestKubeRBACProxyObjectsChecker(t *testing.T) {
 			name:       "UWM enabled but token secret missing",
 			enableRBAC: true,
 			enableUWM:  true,
-			cluster:    k8s.OpenShift,
 			setupObjects: []client.Object{
 				testSecrets[0], // rbac config secret
 				testSecrets[1], // tls secret
@@ -616,7 +572,6 @@ func TestKubeRBACProxyObjectsChecker(t *testing.T) {
 
 			reconciler := KubeRBACProxyObjectsChecker{
 				Pmi:        pmi,
-				Cluster:    tt.cluster,
 				Ds:         testDS,
 				Sm:         testSM,
 				EnableRBAC: tt.enableRBAC,
@@ -631,9 +586,42 @@ func TestKubeRBACProxyObjectsChecker(t *testing.T) {
 				if tt.errorContains != "" {
 					assert.Contains(t, result.Error.Error(), tt.errorContains)
 				}
+				var waitErr *WaitingError
+				assert.ErrorAs(t, result.Error, &waitErr)
 			} else {
 				assert.NoError(t, result.Error)
 			}
 		})
 	}
 }
+
+func TestIsWaiting(t *testing.T) {
+	waiting := &WaitingError{Kind: "secret", Name: "token", Namespace: "ns"}
+
+	tt := []struct {
+		scenario string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"other error", fmt.Errorf("patch failed"), false},
+		{"waiting error", waiting, true},
+		{"wrapped waiting error", &ReconcilerError{Reconciler: "reconciler.UWMSecretTokenReconciler", Err: waiting}, true},
+		{"joined waiting errors", stderrors.Join(waiting, &WaitingError{Kind: "configmap", Name: "ca", Namespace: "ns"}), true},
+		{"waiting and other error", stderrors.Join(waiting, fmt.Errorf("patch failed")), false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsWaiting(tc.err))
+		})
+	}
+}
+
+func TestWaitingError(t *testing.T) {
+	err := &WaitingError{Kind: "configmap", Name: "ca", Namespace: "ns"}
+	assert.Equal(t, `waiting for configmap "ca" in "ns" namespace`, err.Error())
+
+	err.Hint = "openshift is yet to create ca bundle validation"
+	assert.Equal(t, `waiting for configmap "ca" in "ns" namespace; openshift is yet to create ca bundle validation`, err.Error())
+}
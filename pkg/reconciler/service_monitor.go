@@ -8,21 +8,46 @@ import (
 
 	monv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/sustainable.computing.io/kepler-operator/api/v1alpha1"
+	powermonitor "github.com/sustainable.computing.io/kepler-operator/pkg/components/power-monitor"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// PowerMonitorServiceMonitorReconciler reconciles the ServiceMonitor or the
+// PodMonitor that scrapes Kepler, as set in the spec of Pmi, and deletes the other one
 type PowerMonitorServiceMonitorReconciler struct {
 	Pmi        *v1alpha1.PowerMonitorInternal
 	Sm         *monv1.ServiceMonitor
+	Pm         *monv1.PodMonitor
 	EnableRBAC bool
 	EnableUWM  bool
 }
 
+// monitors returns the monitor to create and the one to delete, if any
+func (r PowerMonitorServiceMonitorReconciler) monitors() (monitor, stale client.Object) {
+	if powermonitor.UsePodMonitor(r.Pmi) {
+		if r.Sm != nil {
+			stale = r.Sm
+		}
+		return r.Pm, stale
+	}
+	if r.Pm != nil {
+		stale = r.Pm
+	}
+	return r.Sm, stale
+}
+
 func (r PowerMonitorServiceMonitorReconciler) Reconcile(ctx context.Context, cli client.Client, s *runtime.Scheme) Result {
+	monitor, stale := r.monitors()
+	if stale != nil {
+		if res := (Deleter{Resource: stale}).Reconcile(ctx, cli, s); res.Error != nil {
+			return res
+		}
+	}
+
 	if r.EnableRBAC && !r.EnableUWM {
-		return Deleter{Resource: r.Sm}.Reconcile(ctx, cli, s)
+		return Deleter{Resource: monitor}.Reconcile(ctx, cli, s)
 	}
 
-	return Updater{Owner: r.Pmi, Resource: r.Sm}.Reconcile(ctx, cli, s)
+	return Updater{Owner: r.Pmi, Resource: monitor}.Reconcile(ctx, cli, s)
 }
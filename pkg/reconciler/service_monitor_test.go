@@ -115,3 +115,45 @@ func TestPowerMonitorServiceMonitorReconciler(t *testing.T) {
 		})
 	}
 }
+
+func TestPowerMonitorServiceMonitorReconcilerKind(t *testing.T) {
+	scheme := serviceMonitorTestScheme()
+	sm := &monv1.ServiceMonitor{ObjectMeta: metav1.ObjectMeta{Name: "test-pmi", Namespace: "test-ns"}}
+	pm := &monv1.PodMonitor{ObjectMeta: metav1.ObjectMeta{Name: "test-pmi", Namespace: "test-ns"}}
+
+	tests := []struct {
+		name    string
+		kind    v1alpha1.MonitorKind
+		created client.Object
+	}{
+		{"default kind", "", sm},
+		{"ServiceMonitor", v1alpha1.MonitorKindServiceMonitor, sm},
+		{"PodMonitor", v1alpha1.MonitorKindPodMonitor, pm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pmi := serviceMonitorTestPMI()
+			pmi.Spec.Monitoring.ServiceMonitor.Kind = tt.kind
+			c := &serviceMonitorMockClient{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+			reconciler := PowerMonitorServiceMonitorReconciler{
+				Pmi: pmi,
+				Sm:  sm.DeepCopy(),
+				Pm:  pm.DeepCopy(),
+			}
+			result := reconciler.Reconcile(context.TODO(), c, scheme)
+			assert.NoError(t, result.Error)
+
+			// only the monitor of the kind in spec exists
+			for _, obj := range []client.Object{sm.DeepCopy(), pm.DeepCopy()} {
+				err := c.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+				if _, ok := obj.(*monv1.PodMonitor); ok == (tt.created == pm) {
+					assert.NoError(t, err)
+				} else {
+					assert.True(t, errors.IsNotFound(err))
+				}
+			}
+		})
+	}
+}
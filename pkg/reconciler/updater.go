@@ -11,6 +11,7 @@ import (
 	"github.com/sustainable.computing.io/kepler-operator/pkg/utils/k8s"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -24,10 +25,7 @@ type Updater struct {
 }
 
 func (r Updater) Reconcile(ctx context.Context, c client.Client, scheme *runtime.Scheme) Result {
-	ownerNs := r.Owner.GetNamespace()
-	resourceNs := r.Resource.GetNamespace()
-
-	if ownerNs == "" || ownerNs == resourceNs {
+	if r.Owner != nil && (r.Owner.GetNamespace() == "" || r.Owner.GetNamespace() == r.Resource.GetNamespace()) {
 		if err := ctrlutil.SetControllerReference(r.Owner, r.Resource, scheme); err != nil {
 			return Result{
 				Action: Stop,
@@ -36,9 +34,31 @@ func (r Updater) Reconcile(ctx context.Context, c client.Client, scheme *runtime
 		}
 	}
 
+	// NOTE: the fields of a drift only reported are left out of the object applied
+	var applied client.Object = r.Resource
+	if d := driftDetectorFrom(ctx); d != nil {
+		drift, err := detectDrift(ctx, c, scheme, r.Resource)
+		if err != nil {
+			// NOTE: drift is only reported, so failing to detect it must not fail the update
+			r.Logger.V(3).Info("failed to detect drift", "resource", k8s.GVKName(r.Resource), "error", err)
+		}
+		if drift != nil {
+			drift.Reverted = !d.ReportOnly
+			d.record(*drift)
+			if d.ReportOnly {
+				r.Logger.V(3).Info("leaving drifted fields as is", "resource", k8s.GVKName(r.Resource), "fields", drift.Fields)
+				u, err := withoutDrift(r.Resource, scheme, drift)
+				if err != nil {
+					return Result{Action: r.OnError, Error: r.error("removing drifted fields failed", err)}
+				}
+				applied = u
+			}
+		}
+	}
+
 	r.Logger.V(8).Info("updating resource", "resource", k8s.GVKName(r.Resource))
 
-	if err := c.Patch(ctx, r.Resource, client.Apply, client.ForceOwnership, client.FieldOwner("kepler-operator")); err != nil {
+	if err := c.Patch(ctx, applied, client.Apply, client.ForceOwnership, client.FieldOwner(FieldManager)); err != nil {
 		if errors.IsConflict(err) || errors.IsAlreadyExists(err) {
 			// the cache may be stale; requests a Reconcile
 			r.Logger.V(3).Error(err, "patch failed")
@@ -53,6 +73,12 @@ func (r Updater) Reconcile(ctx context.Context, c client.Client, scheme *runtime
 			Error:  r.error("patch failed", err),
 		}
 	}
+	if u, ok := applied.(*unstructured.Unstructured); ok {
+		// the object returned by the API server includes the drifted fields
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, r.Resource); err != nil {
+			return Result{Action: r.OnError, Error: r.error("converting applied object failed", err)}
+		}
+	}
 	return Result{}
 }
 
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+)
+
+// Capabilities are the optional APIs served by a cluster that the operator
+// integrates with
+type Capabilities struct {
+	// OpenShift is true if the cluster serves the OpenShift security and config APIs
+	OpenShift bool
+	// PrometheusOperator is true if the cluster serves the ServiceMonitor,
+	// PodMonitor and PrometheusRule CRDs of prometheus-operator
+	PrometheusOperator bool
+}
+
+// Cluster returns the kind of cluster with these capabilities
+func (c Capabilities) Cluster() Cluster {
+	if c.OpenShift {
+		return OpenShift
+	}
+	return Kubernetes
+}
+
+// resourcesServed reports whether all resources are served under groupVersion
+func resourcesServed(dc discovery.DiscoveryInterface, groupVersion string, resources ...string) (bool, error) {
+	list, err := dc.ServerResourcesForGroupVersion(groupVersion)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error discovering resources of %s: %w", groupVersion, err)
+	}
+	for _, r := range resources {
+		if !slices.ContainsFunc(list.APIResources, func(res metav1.APIResource) bool { return res.Name == r }) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// DetectCapabilities discovers the capabilities of a cluster from the APIs it serves
+func DetectCapabilities(dc discovery.DiscoveryInterface) (Capabilities, error) {
+	caps := Capabilities{}
+	for _, c := range []struct {
+		capable      *bool
+		groupVersion string
+		resources    []string
+	}{
+		{&caps.OpenShift, "security.openshift.io/v1", []string{"securitycontextconstraints"}},
+		{&caps.PrometheusOperator, "monitoring.coreos.com/v1", []string{"servicemonitors", "podmonitors", "prometheusrules"}},
+	} {
+		served, err := resourcesServed(dc, c.groupVersion, c.resources...)
+		if err != nil {
+			return caps, err
+		}
+		*c.capable = served
+	}
+
+	if caps.OpenShift {
+		// NOTE: clusters may serve some OpenShift APIs only; OpenShift serves
+		// both SCCs and ClusterVersion
+		served, err := resourcesServed(dc, "config.openshift.io/v1", "clusterversions")
+		if err != nil {
+			return caps, err
+		}
+		caps.OpenShift = served
+	}
+	return caps, nil
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2025 The Kepler Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func served(groupVersion string, resources ...string) *metav1.APIResourceList {
+	list := &metav1.APIResourceList{GroupVersion: groupVersion}
+	for _, r := range resources {
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: r})
+	}
+	return list
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	tt := []struct {
+		scenario  string
+		resources []*metav1.APIResourceList
+		caps      Capabilities
+	}{
+		{
+			scenario: "kubernetes",
+			resources: []*metav1.APIResourceList{
+				served("v1", "pods", "configmaps"),
+			},
+			caps: Capabilities{},
+		},
+		{
+			scenario: "openshift",
+			resources: []*metav1.APIResourceList{
+				served("security.openshift.io/v1", "securitycontextconstraints"),
+				served("config.openshift.io/v1", "clusterversions"),
+				served("monitoring.coreos.com/v1", "servicemonitors", "prometheusrules", "podmonitors"),
+			},
+			caps: Capabilities{OpenShift: true, PrometheusOperator: true},
+		},
+		{
+			scenario: "sccs without cluster version",
+			resources: []*metav1.APIResourceList{
+				served("security.openshift.io/v1", "securitycontextconstraints"),
+			},
+			caps: Capabilities{},
+		},
+		{
+			scenario: "partial prometheus-operator",
+			resources: []*metav1.APIResourceList{
+				served("monitoring.coreos.com/v1", "servicemonitors"),
+			},
+			caps: Capabilities{},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.scenario, func(t *testing.T) {
+			dc := &fake.FakeDiscovery{Fake: &k8stesting.Fake{Resources: tc.resources}}
+			caps, err := DetectCapabilities(dc)
+			require.NoError(t, err)
+			assert.Equal(t, tc.caps, caps)
+			assert.Equal(t, tc.caps.OpenShift, caps.Cluster() == OpenShift)
+		})
+	}
+}
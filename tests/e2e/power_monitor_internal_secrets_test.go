@@ -54,14 +54,14 @@ var _ = Describe("PowerMonitorInternal Secrets", Serial, func() {
 			f.ExpectResourceExists(pmi.DaemonsetName(), testNs, ds, utils.Timeout(1*time.Minute))
 
 			By("verifying PowerMonitorInternal is degraded due to missing secret")
-			pmi = f.ExpectPowerMonitorInternalCondition(name, v1alpha1.Available, v1alpha1.ConditionDegraded, utils.Timeout(5*time.Second))
+			pmi = f.ExpectPowerMonitorInternalCondition(name, v1alpha1.Degraded, v1alpha1.ConditionTrue, utils.Timeout(5*time.Second))
 
-			availableCondition, err := k8s.FindCondition(pmi.Status.Conditions, v1alpha1.Available)
-			Expect(err).NotTo(HaveOccurred(), "Should find Available condition")
-			Expect(availableCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitorInternal should be degraded due to missing secret")
-			Expect(availableCondition.Message).To(ContainSubstring(secretName), "Error message should mention the missing secret")
-			Expect(availableCondition.Message).To(ContainSubstring(testNs), "Error message should mention the namespace")
-			GinkgoWriter.Printf("PowerMonitorInternal is correctly marked as degraded due to missing secret: %s\n", availableCondition.Message)
+			degradedCondition, err := k8s.FindCondition(pmi.Status.Conditions, v1alpha1.Degraded)
+			Expect(err).NotTo(HaveOccurred(), "Should find Degraded condition")
+			Expect(degradedCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitorInternal should be degraded due to missing secret")
+			Expect(degradedCondition.Message).To(ContainSubstring(secretName), "Error message should mention the missing secret")
+			Expect(degradedCondition.Message).To(ContainSubstring(testNs), "Error message should mention the namespace")
+			GinkgoWriter.Printf("PowerMonitorInternal is correctly marked as degraded due to missing secret: %s\n", degradedCondition.Message)
 
 			// Verify namespace was created despite missing secret
 			ns := &corev1.Namespace{}
@@ -250,15 +250,15 @@ var _ = Describe("PowerMonitorInternal Secrets", Serial, func() {
 			f.WaitForNamespace(testNs, utils.Timeout(2*time.Minute))
 
 			// Wait for PowerMonitorInternal to reach degraded state due to missing secret
-			pmi = f.ExpectPowerMonitorInternalCondition(name, v1alpha1.Available, v1alpha1.ConditionDegraded, utils.Timeout(3*time.Minute))
+			pmi = f.ExpectPowerMonitorInternalCondition(name, v1alpha1.Degraded, v1alpha1.ConditionTrue, utils.Timeout(3*time.Minute))
 
 			// Assert that the degraded condition is specifically due to missing secret
-			availableCondition, err := k8s.FindCondition(pmi.Status.Conditions, v1alpha1.Available)
-			Expect(err).NotTo(HaveOccurred(), "Should find Available condition")
-			Expect(availableCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitorInternal should be degraded due to missing secret")
-			Expect(availableCondition.Message).To(ContainSubstring(nonExistentSecretName), "Error message should mention the missing secret")
-			Expect(availableCondition.Message).To(ContainSubstring(testNs), "Error message should mention the namespace")
-			GinkgoWriter.Printf("PowerMonitorInternal is correctly marked as degraded due to missing secret: %s\n", availableCondition.Message)
+			degradedCondition, err := k8s.FindCondition(pmi.Status.Conditions, v1alpha1.Degraded)
+			Expect(err).NotTo(HaveOccurred(), "Should find Degraded condition")
+			Expect(degradedCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitorInternal should be degraded due to missing secret")
+			Expect(degradedCondition.Message).To(ContainSubstring(nonExistentSecretName), "Error message should mention the missing secret")
+			Expect(degradedCondition.Message).To(ContainSubstring(testNs), "Error message should mention the namespace")
+			GinkgoWriter.Printf("PowerMonitorInternal is correctly marked as degraded due to missing secret: %s\n", degradedCondition.Message)
 		})
 	})
 
@@ -295,14 +295,14 @@ var _ = Describe("PowerMonitorInternal Secrets", Serial, func() {
 			}
 
 			By("waiting for PMI to become degraded due to missing secret")
-			pmi = f.ExpectPowerMonitorInternalCondition(name, v1alpha1.Available, v1alpha1.ConditionDegraded, utils.Timeout(2*time.Minute))
+			pmi = f.ExpectPowerMonitorInternalCondition(name, v1alpha1.Degraded, v1alpha1.ConditionTrue, utils.Timeout(2*time.Minute))
 
-			availableCondition, err := k8s.FindCondition(pmi.Status.Conditions, v1alpha1.Available)
-			Expect(err).NotTo(HaveOccurred(), "Should find Available condition")
-			Expect(availableCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitorInternal should be degraded due to missing secret")
-			Expect(availableCondition.Message).To(ContainSubstring(secretName), "Error message should mention the missing secret")
-			Expect(availableCondition.Message).To(ContainSubstring(testNs), "Error message should mention the namespace")
-			GinkgoWriter.Printf("PowerMonitorInternal correctly degraded due to missing secret: %s\n", availableCondition.Message)
+			degradedCondition, err := k8s.FindCondition(pmi.Status.Conditions, v1alpha1.Degraded)
+			Expect(err).NotTo(HaveOccurred(), "Should find Degraded condition")
+			Expect(degradedCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitorInternal should be degraded due to missing secret")
+			Expect(degradedCondition.Message).To(ContainSubstring(secretName), "Error message should mention the missing secret")
+			Expect(degradedCondition.Message).To(ContainSubstring(testNs), "Error message should mention the namespace")
+			GinkgoWriter.Printf("PowerMonitorInternal correctly degraded due to missing secret: %s\n", degradedCondition.Message)
 
 			By("creating the missing secret and waiting for recovery")
 			f.CreateTestSecret(secretName, testNs, map[string]string{
@@ -353,11 +353,11 @@ var _ = Describe("PowerMonitorInternal Secrets", Serial, func() {
 			f.DeleteTestSecret(secretName, testNs)
 
 			// Wait for PowerMonitorInternal to become degraded again due to missing secret
-			pmi = f.ExpectPowerMonitorInternalCondition(name, v1alpha1.Available, v1alpha1.ConditionDegraded, utils.Timeout(2*time.Minute))
+			pmi = f.ExpectPowerMonitorInternalCondition(name, v1alpha1.Degraded, v1alpha1.ConditionTrue, utils.Timeout(2*time.Minute))
 
 			// Assert that the degraded condition is again due to missing secret
-			degradedAgainCondition, err := k8s.FindCondition(pmi.Status.Conditions, v1alpha1.Available)
-			Expect(err).NotTo(HaveOccurred(), "Should find Available condition")
+			degradedAgainCondition, err := k8s.FindCondition(pmi.Status.Conditions, v1alpha1.Degraded)
+			Expect(err).NotTo(HaveOccurred(), "Should find Degraded condition")
 			Expect(degradedAgainCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitorInternal should be degraded again due to missing secret")
 			Expect(degradedAgainCondition.Message).To(ContainSubstring(secretName), "Error message should mention the missing secret")
 			GinkgoWriter.Printf("PowerMonitorInternal correctly degraded again after secret deletion: %s\n", degradedAgainCondition.Message)
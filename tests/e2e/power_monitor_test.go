@@ -597,14 +597,14 @@ dev:
 			}
 
 			By("waiting for PowerMonitor to become degraded due to missing secret")
-			pm = f.ExpectPowerMonitorCondition(name, v1alpha1.Available, v1alpha1.ConditionDegraded, utils.Timeout(2*time.Minute))
+			pm = f.ExpectPowerMonitorCondition(name, v1alpha1.Degraded, v1alpha1.ConditionTrue, utils.Timeout(2*time.Minute))
 
-			availableCondition, err := k8s.FindCondition(pm.Status.Conditions, v1alpha1.Available)
-			Expect(err).NotTo(HaveOccurred(), "Should find Available condition")
-			Expect(availableCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitor should be degraded due to missing secret")
-			Expect(availableCondition.Message).To(ContainSubstring(secretName), "Error message should mention the missing secret")
-			Expect(availableCondition.Message).To(ContainSubstring(testNs), "Error message should mention the namespace")
-			GinkgoWriter.Printf("PowerMonitor correctly degraded due to missing secret: %s\n", availableCondition.Message)
+			degradedCondition, err := k8s.FindCondition(pm.Status.Conditions, v1alpha1.Degraded)
+			Expect(err).NotTo(HaveOccurred(), "Should find Degraded condition")
+			Expect(degradedCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitor should be degraded due to missing secret")
+			Expect(degradedCondition.Message).To(ContainSubstring(secretName), "Error message should mention the missing secret")
+			Expect(degradedCondition.Message).To(ContainSubstring(testNs), "Error message should mention the namespace")
+			GinkgoWriter.Printf("PowerMonitor correctly degraded due to missing secret: %s\n", degradedCondition.Message)
 
 			By("creating the missing secret and waiting for recovery")
 			f.CreateTestSecret(secretName, testNs, map[string]string{
@@ -656,11 +656,11 @@ dev:
 			f.DeleteTestSecret(secretName, testNs)
 
 			// Wait for PowerMonitor to become degraded again due to missing secret
-			pm = f.ExpectPowerMonitorCondition(name, v1alpha1.Available, v1alpha1.ConditionDegraded, utils.Timeout(2*time.Minute))
+			pm = f.ExpectPowerMonitorCondition(name, v1alpha1.Degraded, v1alpha1.ConditionTrue, utils.Timeout(2*time.Minute))
 
 			// Assert that the degraded condition is again due to missing secret
-			degradedAgainCondition, err := k8s.FindCondition(pm.Status.Conditions, v1alpha1.Available)
-			Expect(err).NotTo(HaveOccurred(), "Should find Available condition")
+			degradedAgainCondition, err := k8s.FindCondition(pm.Status.Conditions, v1alpha1.Degraded)
+			Expect(err).NotTo(HaveOccurred(), "Should find Degraded condition")
 			Expect(degradedAgainCondition.Reason).To(Equal(v1alpha1.SecretNotFound), "PowerMonitor should be degraded again due to missing secret")
 			Expect(degradedAgainCondition.Message).To(ContainSubstring(secretName), "Error message should mention the missing secret")
 			GinkgoWriter.Printf("PowerMonitor correctly degraded again after secret deletion: %s\n", degradedAgainCondition.Message)